@@ -0,0 +1,106 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// CaptureAdditionalData controls whether json properties that are not mapped to a field are kept in the AdditionalData
+// of User, Group, Calendar, CalendarEvent, Message, Drive and DriveItem. Set it to false to save memory on bulk listings.
+// It is read without synchronization while decoding, hence it must be set before any API-calls are made or entities
+// are json-unmarshalled, and must not be changed afterwards.
+var CaptureAdditionalData = true
+
+// AdditionalData contains the json properties of an entity that are not mapped to a field of its struct, e.g. properties
+// that have been added to msgraph recently or directory extensions of the tenant. The values are kept as raw json, hence
+// they are emitted unchanged when the entity is json-marshalled again. OData annotations like @odata.context are not kept.
+type AdditionalData map[string]json.RawMessage
+
+// Get json-unmarshals the property with the given key into v. Returns false if there is no such property.
+func (a AdditionalData) Get(key string, v interface{}) (bool, error) {
+	value, ok := a[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(value, v)
+}
+
+// Set json-marshals value and sets it as the property with the given key
+func (a *AdditionalData) Set(key string, value interface{}) error {
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if *a == nil {
+		*a = AdditionalData{}
+	}
+	(*a)[key] = marshalled
+	return nil
+}
+
+// extractAdditionalData returns the properties of the json object data that are not read by json.Unmarshal into the
+// struct known points to, nil if there are none or CaptureAdditionalData is false. Like json.Unmarshal, the keys of
+// data are compared to the fields of known case-insensitively.
+func extractAdditionalData(data []byte, known interface{}) (AdditionalData, error) {
+	if !CaptureAdditionalData {
+		return nil, nil
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	knownKeys := jsonKeys(reflect.TypeOf(known))
+	var additional AdditionalData
+	for key, value := range properties {
+		if knownKeys[strings.ToLower(key)] || strings.HasPrefix(key, "@odata.") {
+			continue
+		}
+		if additional == nil {
+			additional = AdditionalData{}
+		}
+		additional[key] = value
+	}
+	return additional, nil
+}
+
+// mergeAdditionalData adds the properties of additional to the marshalled json object, properties that are already
+// part of the object are not overwritten
+func mergeAdditionalData(marshalled []byte, additional AdditionalData) ([]byte, error) {
+	if len(additional) == 0 {
+		return marshalled, nil
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(marshalled, &properties); err != nil {
+		return nil, err
+	}
+	for key, value := range additional {
+		if _, ok := properties[key]; !ok {
+			properties[key] = value
+		}
+	}
+	return json.Marshal(properties)
+}
+
+// jsonKeys returns the lower case json keys of the exported fields of the struct t (or pointer to it)
+func jsonKeys(t reflect.Type) map[string]bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	keys := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys[strings.ToLower(name)] = true
+	}
+	return keys
+}
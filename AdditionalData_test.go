@@ -0,0 +1,168 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAdditionalData_RoundTrip(t *testing.T) {
+	unknown := map[string]string{
+		"employeeId":                    `"4711"`,
+		"extension_b7d8e5fa_costCenter": `"AT-Vienna-01"`,
+		"onPremisesExtensionAttributes": `{"extensionAttribute1":"Technician","extensionAttribute2":null}`,
+	}
+	payload := `{"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#users/$entity", "id": "u1", "displayName": "Alice",
+		"userPrincipalName": "alice@contoso.com", "employeeId": "4711", "extension_b7d8e5fa_costCenter": "AT-Vienna-01",
+		"onPremisesExtensionAttributes": {"extensionAttribute1":"Technician","extensionAttribute2":null}}`
+
+	var user User
+	if err := json.Unmarshal([]byte(payload), &user); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(user.AdditionalData) != len(unknown) {
+		t.Errorf("User.AdditionalData = %v, want %v properties", user.AdditionalData, len(unknown))
+	}
+	encoded, err := json.Marshal(user)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &properties); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for key, want := range unknown {
+		if got := string(properties[key]); got != want {
+			t.Errorf("json.Marshal() %v = %v, want %v", key, got, want)
+		}
+	}
+	if string(properties["userPrincipalName"]) != `"alice@contoso.com"` {
+		t.Errorf("json.Marshal() = %s, want the known properties too", encoded)
+	}
+
+	var employeeID string
+	if ok, err := user.AdditionalData.Get("employeeId", &employeeID); !ok || err != nil || employeeID != "4711" {
+		t.Errorf("AdditionalData.Get() = %v, %v, %v", employeeID, ok, err)
+	}
+	if ok, _ := user.AdditionalData.Get("employeeType", &employeeID); ok {
+		t.Errorf("AdditionalData.Get() of a missing property = true")
+	}
+	var group Group
+	if err := group.AdditionalData.Set("extension_b7d8e5fa_site", []string{"Vienna"}); err != nil || string(group.AdditionalData["extension_b7d8e5fa_site"]) != `["Vienna"]` {
+		t.Errorf("AdditionalData.Set() = %s, %v", group.AdditionalData["extension_b7d8e5fa_site"], err)
+	}
+}
+
+func TestAdditionalData_RoundTripEntities(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		t.Skipf("cannot load Europe/Vienna: %v", err)
+	}
+	origTimeZones, origFullDay := globalSupportedTimeZones, FullDayEventTimeZone
+	globalSupportedTimeZones = supportedTimeZones{Value: []supportedTimeZone{{Alias: "W. Europe Standard Time", TimeLoc: vienna}, {Alias: "UTC", TimeLoc: time.UTC}}}
+	FullDayEventTimeZone = vienna
+	t.Cleanup(func() { globalSupportedTimeZones, FullDayEventTimeZone = origTimeZones, origFullDay })
+
+	event := `"id": "e1", "subject": "Meeting", "createdDateTime": "2021-02-01T10:00:00Z", "lastModifiedDateTime": "2021-02-01T10:30:00.123Z",
+		"iCalUId": "ical", "importance": "high", "sensitivity": "normal", "isOrganizer": true, "showAs": "busy", "type": "singleInstance",
+		"responseStatus": {"response": "organizer", "time": "0001-01-01T00:00:00Z"}, "body": {"contentType": "html", "content": "<p>Hi</p>"},
+		"bodyPreview": "Hi", "organizer": {"emailAddress": {"name": "Alice", "address": "alice@contoso.com"}},
+		"attendees": [{"type": "required", "status": {"response": "accepted", "time": "2021-02-02T08:00:00Z"}, "emailAddress": {"name": "Bob", "address": "bob@contoso.com"}}],
+		"onlineMeetingUrl": "https://teams.example.com/m1"`
+	tests := []struct {
+		name     string
+		payload  string
+		new      func() interface{}
+		wantKeys []string
+	}{
+		{
+			name: "Group",
+			payload: `{"id": "g1", "displayName": "Technicians", "createdDateTime": "2021-02-01T10:00:00Z", "groupTypes": ["Unified"],
+				"mailEnabled": true, "mailNickname": "technicians", "proxyAddresses": ["SMTP:technicians@contoso.com"],
+				"visibility": "Private", "classification": "internal"}`,
+			new:      func() interface{} { return &Group{} },
+			wantKeys: []string{"id", "displayName", "createdDateTime", "groupTypes", "mailNickname", "visibility", "classification"},
+		},
+		{
+			name: "Calendar",
+			payload: `{"id": "c1", "name": "Calendar", "canEdit": true, "changeKey": "k1",
+				"owner": {"name": "Alice", "address": "alice@contoso.com"}, "color": "auto"}`,
+			new:      func() interface{} { return &Calendar{} },
+			wantKeys: []string{"id", "name", "canEdit", "changeKey", "owner", "color"},
+		},
+		{
+			name: "CalendarEvent",
+			payload: `{` + event + `, "originalStartTimeZone": "W. Europe Standard Time", "originalEndTimeZone": "W. Europe Standard Time",
+				"start": {"dateTime": "2021-03-01T08:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2021-03-01T09:00:00.0000000", "timeZone": "UTC"}}`,
+			new: func() interface{} { return &CalendarEvent{} },
+			wantKeys: []string{"id", "createdDateTime", "originalStartTimeZone", "iCalUId", "responseStatus", "start", "end",
+				"attendees", "organizer", "onlineMeetingUrl"},
+		},
+		{
+			name: "CalendarEvent full-day",
+			payload: `{` + event + `, "isAllDay": true, "originalStartTimeZone": "UTC", "originalEndTimeZone": "UTC",
+				"start": {"dateTime": "2021-03-01T00:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2021-03-02T00:00:00.0000000", "timeZone": "UTC"}}`,
+			new:      func() interface{} { return &CalendarEvent{} },
+			wantKeys: []string{"isAllDay", "start", "end", "onlineMeetingUrl"},
+		},
+		{
+			name: "Message",
+			payload: `{"subject": "Hello", "body": {"contentType": "text", "content": "Hi"},
+				"toRecipients": [{"emailAddress": {"name": "Bob", "address": "bob@contoso.com"}}],
+				"from": {"emailAddress": {"address": "alice@contoso.com"}}, "bodyPreview": "Hi", "isRead": false}`,
+			new:      func() interface{} { return &Message{} },
+			wantKeys: []string{"subject", "body", "toRecipients", "from", "bodyPreview", "isRead"},
+		},
+		{
+			name: "Drive",
+			payload: `{"id": "d1", "name": "Documents", "driveType": "business", "webUrl": "https://contoso.sharepoint.com/Documents",
+				"createdDateTime": "2021-02-01T10:00:00Z", "lastModifiedDateTime": "2021-02-02T10:00:00Z", "quota": {"used":42}}`,
+			new:      func() interface{} { return &Drive{} },
+			wantKeys: []string{"id", "name", "driveType", "webUrl", "createdDateTime", "lastModifiedDateTime", "quota"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded := tt.new()
+			if err := json.Unmarshal([]byte(tt.payload), decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			encoded, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var properties map[string]json.RawMessage
+			if err := json.Unmarshal(encoded, &properties); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := properties[key]; !ok {
+					t.Errorf("json.Marshal() = %s, want the property %v", encoded, key)
+				}
+			}
+			redecoded := tt.new()
+			if err := json.Unmarshal(encoded, redecoded); err != nil {
+				t.Fatalf("json.Unmarshal() of %s error = %v", encoded, err)
+			}
+			if !reflect.DeepEqual(decoded, redecoded) {
+				t.Errorf("json.Unmarshal() of %s = %+v, want %+v", encoded, redecoded, decoded)
+			}
+		})
+	}
+}
+
+func TestAdditionalData_Disabled(t *testing.T) {
+	CaptureAdditionalData = false
+	defer func() { CaptureAdditionalData = true }()
+
+	var group Group
+	if err := json.Unmarshal([]byte(`{"id": "g1", "displayName": "Technicians", "classification": "internal"}`), &group); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if group.AdditionalData != nil || group.DisplayName != "Technicians" {
+		t.Errorf("Group = %v, AdditionalData = %v, want no additional data", group, group.AdditionalData)
+	}
+}
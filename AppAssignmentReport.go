@@ -0,0 +1,103 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// appAssignmentReportConcurrency is the maximum amount of service principals whose assignments are loaded at the same time
+const appAssignmentReportConcurrency = 4
+
+// AppAssignmentReportRow represents a single user, group or service principal that is assigned to an enterprise application
+type AppAssignmentReportRow struct {
+	AppName       string    // the display name of the service principal of the application
+	PrincipalType string    // either User, Group or ServicePrincipal
+	PrincipalName string    // the display name of the assigned principal
+	RoleID        string    // the ID of the assigned app role, 00000000-0000-0000-0000-000000000000 is the default role
+	AssignedDate  time.Time // the time when the assignment has been created
+}
+
+func (a AppAssignmentReportRow) String() string {
+	return fmt.Sprintf("AppAssignmentReportRow(AppName: \"%v\", PrincipalType: \"%v\", PrincipalName: \"%v\", RoleID: \"%v\", AssignedDate: \"%v\")",
+		a.AppName, a.PrincipalType, a.PrincipalName, a.RoleID, a.AssignedDate)
+}
+
+// AppAssignmentReport contains all assignments of the enterprise applications that have been part of the
+// report. Applications whose assignments could not be loaded are not part of the Rows but of the Errors,
+// keyed by the ID of the service principal, as display names are not unique.
+type AppAssignmentReport struct {
+	Rows                []AppAssignmentReportRow
+	Errors              map[string]error // errors of the service principals whose assignments could not be loaded, keyed by their ID
+	PrincipalNamesError error            // the error of directoryObjects/getByIds, the Rows then contain the principalDisplayName of the assignments
+}
+
+// BuildAppAssignmentReport builds a report of all users, groups and service principals that are assigned
+// to the service principals matching the given OData $filter, e.g. "startswith(displayName,'Contoso')".
+// All service principals are part of the report if the filter is empty. The names of the principals are
+// resolved with directoryObjects/getByIds.
+//
+// Failing to load the assignments of a single application is not fatal, the error is collected within
+// AppAssignmentReport.Errors instead. An error is only returned if the service principals cannot be listed.
+func (g *GraphClient) BuildAppAssignmentReport(appFilter string) (AppAssignmentReport, error) {
+	return g.BuildAppAssignmentReportContext(context.Background(), appFilter)
+}
+
+// BuildAppAssignmentReportContext is BuildAppAssignmentReport with a context.
+func (g *GraphClient) BuildAppAssignmentReportContext(ctx context.Context, appFilter string) (AppAssignmentReport, error) {
+	report := AppAssignmentReport{Errors: map[string]error{}}
+	servicePrincipals, err := g.listServicePrincipals(ctx, appFilter)
+	if err != nil {
+		return report, fmt.Errorf("cannot list service principals: %w", err)
+	}
+
+	// load the assignments of all service principals concurrently, keep the order of the service principals
+	assignments := make([][]AppRoleAssignment, len(servicePrincipals))
+	errs := make([]error, len(servicePrincipals))
+	forEachConcurrently(len(servicePrincipals), appAssignmentReportConcurrency, func(i int) {
+		assignments[i], errs[i] = g.ListServicePrincipalAppRoleAssignedToContext(ctx, servicePrincipals[i].ID)
+	})
+
+	// resolve the names of all principals at once
+	var principalIDs []string
+	seen := map[string]bool{}
+	for _, spAssignments := range assignments {
+		for _, assignment := range spAssignments {
+			if !seen[assignment.PrincipalID] {
+				seen[assignment.PrincipalID] = true
+				principalIDs = append(principalIDs, assignment.PrincipalID)
+			}
+		}
+	}
+	principalNames := map[string]string{}
+	if len(principalIDs) > 0 {
+		principals, err := g.GetDirectoryObjectsByIDsContext(ctx, principalIDs, "user", "group", "servicePrincipal")
+		if err != nil {
+			report.PrincipalNamesError = err // fall back to the principalDisplayName of the assignments
+		}
+		for _, principal := range principals {
+			principalNames[principal.ID] = principal.DisplayName
+		}
+	}
+
+	for i, servicePrincipal := range servicePrincipals {
+		if errs[i] != nil {
+			report.Errors[servicePrincipal.ID] = fmt.Errorf("cannot load the assignments of %v: %w", servicePrincipal.DisplayName, errs[i])
+			continue
+		}
+		for _, assignment := range assignments[i] {
+			principalName, ok := principalNames[assignment.PrincipalID]
+			if !ok {
+				principalName = assignment.PrincipalDisplayName
+			}
+			report.Rows = append(report.Rows, AppAssignmentReportRow{
+				AppName:       servicePrincipal.DisplayName,
+				PrincipalType: assignment.PrincipalType,
+				PrincipalName: principalName,
+				RoleID:        assignment.AppRoleID,
+				AssignedDate:  assignment.CreatedDateTime,
+			})
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,77 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_BuildAppAssignmentReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/servicePrincipals", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("$filter"); got != "startswith(displayName,'Contoso')" {
+			t.Errorf("GET /servicePrincipals $filter = %v", got)
+		}
+		fmt.Fprint(w, `{"value": [{"id": "sp1", "displayName": "Contoso HR"}, {"id": "sp2", "displayName": "Contoso Sales"},
+			{"id": "sp3", "displayName": "Contoso Sales"}]}`)
+	})
+	mux.HandleFunc("/v1.0/servicePrincipals/sp1/appRoleAssignedTo", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$skiptoken") == "" { // first page
+			fmt.Fprint(w, `{"@odata.nextLink": "https://graph.microsoft.com/v1.0/servicePrincipals/sp1/appRoleAssignedTo?$skiptoken=page2",
+				"value": [{"id": "a1", "appRoleId": "role1", "createdDateTime": "2021-01-02T03:04:05Z", "principalId": "u1", "principalType": "User", "principalDisplayName": "Old Name"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "a2", "appRoleId": "role2", "createdDateTime": "2021-02-03T04:05:06Z", "principalId": "g1", "principalType": "Group", "principalDisplayName": "Technicians"}]}`)
+	})
+	failing := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": "Authorization_RequestDenied", "message": ""}}`)
+	}
+	mux.HandleFunc("/v1.0/servicePrincipals/sp2/appRoleAssignedTo", failing)
+	mux.HandleFunc("/v1.0/servicePrincipals/sp3/appRoleAssignedTo", failing)
+	mux.HandleFunc("/v1.0/directoryObjects/getByIds", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("directoryObjects/getByIds method = %v, want POST", r.Method)
+		}
+		fmt.Fprint(w, `{"value": [{"@odata.type": "#microsoft.graph.user", "id": "u1", "displayName": "Alice"}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	got, err := g.BuildAppAssignmentReport("startswith(displayName,'Contoso')")
+	if err != nil {
+		t.Fatalf("GraphClient.BuildAppAssignmentReport() error = %v", err)
+	}
+	wantRows := []AppAssignmentReportRow{
+		{AppName: "Contoso HR", PrincipalType: "User", PrincipalName: "Alice", RoleID: "role1", AssignedDate: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{AppName: "Contoso HR", PrincipalType: "Group", PrincipalName: "Technicians", RoleID: "role2", AssignedDate: time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(got.Rows, wantRows) {
+		t.Errorf("GraphClient.BuildAppAssignmentReport() Rows = %v, want %v", got.Rows, wantRows)
+	}
+	if len(got.Errors) != 2 || !errors.Is(got.Errors["sp2"], ErrForbidden) || !errors.Is(got.Errors["sp3"], ErrForbidden) || got.PrincipalNamesError != nil {
+		t.Errorf("GraphClient.BuildAppAssignmentReport() Errors = %v, PrincipalNamesError = %v, want an error for both Contoso Sales", got.Errors, got.PrincipalNamesError)
+	}
+}
+
+func TestGraphClient_ListServicePrincipalAppRoleAssignedTo(t *testing.T) {
+	var pages int
+	g := newTestGraphClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.0/servicePrincipals/sp1/appRoleAssignedTo" || r.URL.Query().Get("$select") != "principalId,appRoleId" {
+			t.Errorf("unexpected request %v", r.URL)
+		}
+		if pages++; pages == 1 {
+			fmt.Fprint(w, `{"@odata.nextLink": "https://graph.microsoft.com/v1.0/servicePrincipals/sp1/appRoleAssignedTo?$skiptoken=page2",
+				"value": [{"principalId": "u1", "appRoleId": "role1"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"principalId": "g1", "appRoleId": "role2"}]}`)
+	}))
+
+	got, err := g.ListServicePrincipalAppRoleAssignedTo("sp1", Select("principalId", "appRoleId"))
+	if err != nil || len(got) != 2 || got[1].PrincipalID != "g1" {
+		t.Errorf("GraphClient.ListServicePrincipalAppRoleAssignedTo() = %v, %v, want both pages", got, err)
+	}
+}
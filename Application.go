@@ -0,0 +1,22 @@
+package msgraph
+
+import (
+	"fmt"
+	"time"
+)
+
+// Application represents an application registration in the directory
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/application
+type Application struct {
+	ID              string    `json:"id"`
+	AppID           string    `json:"appId"` // the client id of the application
+	DisplayName     string    `json:"displayName"`
+	SignInAudience  string    `json:"signInAudience"` // e.g. AzureADMyOrg or AzureADMultipleOrgs
+	CreatedDateTime time.Time `json:"createdDateTime"`
+}
+
+func (a Application) String() string {
+	return fmt.Sprintf("Application(ID: \"%v\", AppID: \"%v\", DisplayName: \"%v\", SignInAudience: \"%v\", CreatedDateTime: \"%v\")",
+		a.ID, a.AppID, a.DisplayName, a.SignInAudience, a.CreatedDateTime)
+}
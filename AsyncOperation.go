@@ -0,0 +1,133 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// operationPollInterval is the delay before the first poll of an Operation without Retry-After, it doubles with
+// every further poll up to maxOperationPollInterval
+var operationPollInterval = 10 * time.Second
+
+// maxOperationPollInterval is the maximum delay between two polls of an Operation without Retry-After
+const maxOperationPollInterval = time.Minute
+
+// Operation is an asynchronous operation started by an API-call that msgraph answered with 202 Accepted, e.g. the
+// registration of an ExternalItemSchema. Its state is polled with PollOperation.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/teamsasyncoperation
+type Operation struct {
+	StatusCode int           // the status code of the API-call that started the operation
+	Location   string        // the Operation-Location, or the Location if there is none, of the operation's state
+	RetryAfter time.Duration // the delay requested by msgraph before the first poll, 0 if none
+}
+
+func (o Operation) String() string {
+	return fmt.Sprintf("Operation(StatusCode: \"%v\", Location: \"%v\", RetryAfter: \"%v\")", o.StatusCode, o.Location, o.RetryAfter)
+}
+
+// IsPending returns true if the operation runs asynchronously and has to be polled, otherwise it has been
+// completed by the API-call that started it
+func (o Operation) IsPending() bool {
+	return o.StatusCode == http.StatusAccepted && o.Location != ""
+}
+
+// newOperation returns the Operation started by a response with the given status code and header
+func newOperation(statusCode int, header http.Header) Operation {
+	op := Operation{StatusCode: statusCode, Location: header.Get("Operation-Location")}
+	if op.Location == "" {
+		op.Location = header.Get("Location")
+	}
+	if header.Get("Retry-After") != "" {
+		op.RetryAfter = retryDelay(header, 0)
+	}
+	return op
+}
+
+// startOperation performs an API-call that may start an asynchronous operation and returns it, see Operation.IsPending.
+// The response, if any, is json-unmarshalled into v.
+func (g *GraphClient) startOperation(ctx context.Context, method, apiCall string, body, v interface{}) (Operation, error) {
+	var header http.Header
+	var statusCode int
+	err := g.makeAPICall(ctx, method, apiCall, nil, body, v, ResponseHeader(&header), func(o *requestOptions) {
+		o.statusCode = &statusCode
+	})
+	return newOperation(statusCode, header), err
+}
+
+// PollOperation polls the state of the operation until it has succeeded or failed and json-unmarshals the final
+// state into v, if v is not nil. It waits as long as requested by the Retry-After of msgraph between two polls,
+// otherwise with an exponential backoff. Returns an error including the code and message of msgraph if the
+// operation failed, or without polling if an absolute Location does not point to the host of msgraph.
+func (g *GraphClient) PollOperation(op Operation, v interface{}) error {
+	return g.PollOperationContext(context.Background(), op, v)
+}
+
+// PollOperationContext is PollOperation with a context, polling stops as soon as ctx is done.
+func (g *GraphClient) PollOperationContext(ctx context.Context, op Operation, v interface{}) error {
+	if op.Location == "" {
+		return fmt.Errorf("operation has no location to poll")
+	}
+	location := op.Location
+	if strings.HasPrefix(location, "/") { // e.g. /teams('{id}')/operations('{id}') relative to the API version
+		apiVersion := g.apiVersion
+		if apiVersion == "" {
+			apiVersion = APIVersion
+		}
+		var err error
+		if location, err = g.buildAPIURL(apiVersion, location, nil); err != nil {
+			return err
+		}
+	}
+	if err := g.checkLinkHost(location); err != nil { // the token must not be sent to the host of a manipulated Location
+		return err
+	}
+
+	delay, interval := op.RetryAfter, operationPollInterval
+	for {
+		if delay <= 0 {
+			delay = interval
+			if interval *= 2; interval > maxOperationPollInterval {
+				interval = maxOperationPollInterval
+			}
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		var state json.RawMessage
+		var header http.Header
+		if err := g.makeAPICallURL(ctx, http.MethodGet, location, nil, &state, ResponseHeader(&header)); err != nil {
+			return err
+		}
+		var status struct {
+			Status string `json:"status"`
+			Error  struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(state, &status); err != nil {
+			return fmt.Errorf("cannot unmarshal state of operation %v: %v", op.Location, err)
+		}
+		switch strings.ToLower(status.Status) {
+		case "succeeded", "completed":
+			if v == nil {
+				return nil
+			}
+			return json.Unmarshal(state, v)
+		case "failed":
+			return fmt.Errorf("operation %v failed: %v %v", op.Location, status.Error.Code, status.Error.Message)
+		}
+		delay = 0
+		if header.Get("Retry-After") != "" {
+			delay = retryDelay(header, 0)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_PollOperation(t *testing.T) {
+	origInterval := operationPollInterval
+	operationPollInterval = time.Millisecond
+	defer func() { operationPollInterval = origInterval }()
+
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/teams/t1/clone", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/teams('t2')/operations('op1')")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1.0/teams('t2')/operations('op1')", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "inProgress"
+		if polls == 3 {
+			status = "succeeded"
+		}
+		fmt.Fprintf(w, `{"id": "op1", "status": "%v", "targetResourceId": "t2"}`, status)
+	})
+	mux.HandleFunc("/v1.0/teams('t3')/operations('op2')", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "op2", "status": "failed", "error": {"code": "Conflict", "message": "team exists"}}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	op, err := g.startOperation(context.Background(), http.MethodPost, "/teams/t1/clone", map[string]string{"displayName": "Clone"}, nil)
+	if err != nil || !op.IsPending() || op.Location != "/teams('t2')/operations('op1')" {
+		t.Fatalf("GraphClient.startOperation() = %v, %v, want a pending operation", op, err)
+	}
+	var state struct {
+		TargetResourceID string `json:"targetResourceId"`
+	}
+	if err := g.PollOperation(op, &state); err != nil || polls != 3 || state.TargetResourceID != "t2" {
+		t.Errorf("GraphClient.PollOperation() error = %v after %v polls with %v, want nil after 3 with t2", err, polls, state)
+	}
+
+	err = g.PollOperation(Operation{StatusCode: http.StatusAccepted, Location: "/teams('t3')/operations('op2')"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "Conflict team exists") {
+		t.Errorf("GraphClient.PollOperation() of a failed operation error = %v, want its code and message", err)
+	}
+
+	err = g.PollOperation(Operation{StatusCode: http.StatusAccepted, Location: "https://attacker.example.com/operations('op3')"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "unexpected host") {
+		t.Errorf("GraphClient.PollOperation() of a foreign Location error = %v, want it refused", err)
+	}
+}
+
+func TestNewOperation(t *testing.T) {
+	header := http.Header{"Location": {"https://graph.microsoft.com/v1.0/a"}, "Operation-Location": {"https://graph.microsoft.com/v1.0/b"}, "Retry-After": {"5"}}
+	if op := newOperation(http.StatusAccepted, header); !op.IsPending() || op.Location != "https://graph.microsoft.com/v1.0/b" || op.RetryAfter != 5*time.Second {
+		t.Errorf("newOperation() = %v, want the Operation-Location and 5s", op)
+	}
+	if op := newOperation(http.StatusNoContent, http.Header{}); op.IsPending() {
+		t.Errorf("newOperation() of 204 = %v, want it not pending", op)
+	}
+}
@@ -5,9 +5,24 @@ import (
 	"fmt"
 )
 
+// AttendeeType is the type of the invitation of an Attendee
+type AttendeeType string
+
+// Attendee types as returned by msgraph in attendee.type
+const (
+	AttendeeTypeRequired AttendeeType = "required"
+	AttendeeTypeOptional AttendeeType = "optional"
+	AttendeeTypeResource AttendeeType = "resource" // e.g. a room or equipment
+)
+
+// IsValid returns true if t is one of the AttendeeType constants
+func (t AttendeeType) IsValid() bool {
+	return t == AttendeeTypeRequired || t == AttendeeTypeOptional || t == AttendeeTypeResource
+}
+
 // Attendee struct represents an attendee for a CalendarEvent
 type Attendee struct {
-	Type           string         // the type of the invitation, e.g. required, optional etc.
+	Type           AttendeeType   // the type of the invitation, e.g. required, optional etc.
 	Name           string         // the name of the person, comes from the E-Mail Address - hence not a reliable name to search for
 	Email          string         // the e-mail address of the person - use this to identify the user
 	ResponseStatus ResponseStatus // the ResponseStatus for that particular Attendee for the CalendarEvent
@@ -23,10 +38,21 @@ func (a Attendee) Equal(other Attendee) bool {
 	return a.Type == other.Type && a.Name == other.Name && a.Email == other.Email && a.ResponseStatus.Equal(other.ResponseStatus)
 }
 
+// Validate returns an error if the Type or the response of the Attendee is unknown to msgraph
+func (a Attendee) Validate() error {
+	if !a.Type.IsValid() {
+		return fmt.Errorf("invalid Type %q of attendee %v", a.Type, a.Email)
+	}
+	if !a.ResponseStatus.Response.IsValid() {
+		return fmt.Errorf("invalid response %q of attendee %v", a.ResponseStatus.Response, a.Email)
+	}
+	return nil
+}
+
 // UnmarshalJSON implements the json unmarshal to be used by the json-library
 func (a *Attendee) UnmarshalJSON(data []byte) error {
 	tmp := struct {
-		Type         string         `json:"type"`
+		Type         AttendeeType   `json:"type"`
 		Status       ResponseStatus `json:"status"`
 		EmailAddress struct {
 			Name    string `json:"name"`
@@ -46,3 +72,18 @@ func (a *Attendee) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements the json marshal to be used by the json-library, it is the inverse of UnmarshalJSON
+func (a Attendee) MarshalJSON() ([]byte, error) {
+	tmp := struct {
+		Type         AttendeeType   `json:"type"`
+		Status       ResponseStatus `json:"status"`
+		EmailAddress struct {
+			Name    string `json:"name"`
+			Address string `json:"address"`
+		} `json:"emailAddress"`
+	}{Type: a.Type, Status: a.ResponseStatus}
+	tmp.EmailAddress.Name = a.Name
+	tmp.EmailAddress.Address = a.Email
+	return json.Marshal(tmp)
+}
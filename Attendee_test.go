@@ -106,3 +106,22 @@ func TestAttendee_String(t *testing.T) {
 		})
 	}
 }
+
+func TestAttendee_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       Attendee
+		wantErr bool
+	}{
+		{name: "all good", a: Attendee{Type: AttendeeTypeResource, ResponseStatus: ResponseStatus{Response: ResponseTypeAccepted}}, wantErr: false},
+		{name: "invalid type", a: Attendee{Type: "attendee", ResponseStatus: ResponseStatus{Response: ResponseTypeAccepted}}, wantErr: true},
+		{name: "invalid response", a: Attendee{Type: AttendeeTypeRequired, ResponseStatus: ResponseStatus{Response: "maybe"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.a.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Attendee.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
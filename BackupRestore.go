@@ -0,0 +1,266 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Destination types of a RestoreArtifact
+const (
+	RestoreDestinationNew     = "new"     // restore into a new folder of the mailbox
+	RestoreDestinationInPlace = "inPlace" // overwrite the current items of the mailbox
+)
+
+// Status values of a RestoreArtifact
+const (
+	RestoreArtifactStatusAdded      = "added"
+	RestoreArtifactStatusScheduling = "scheduling"
+	RestoreArtifactStatusScheduled  = "scheduled"
+	RestoreArtifactStatusInProgress = "inProgress"
+	RestoreArtifactStatusSucceeded  = "succeeded"
+	RestoreArtifactStatusFailed     = "failed"
+)
+
+// RestorePoint represents a point in time a protection unit, e.g. a mailbox, has been backed up by Microsoft 365 Backup Storage
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/restorepoint
+type RestorePoint struct {
+	ID                 string
+	ProtectionUnitID   string
+	ProtectionDateTime time.Time // the time the backup has been taken
+	ExpirationDateTime time.Time // the time the restore point will be deleted
+	Tags               []string  // e.g. fastRestore
+}
+
+func (r RestorePoint) String() string {
+	return fmt.Sprintf("RestorePoint(ID: \"%v\", ProtectionUnitID: \"%v\", ProtectionDateTime: \"%v\", ExpirationDateTime: \"%v\", Tags: \"%v\")",
+		r.ID, r.ProtectionUnitID, r.ProtectionDateTime, r.ExpirationDateTime, r.Tags)
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (r *RestorePoint) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ID                 string    `json:"id"`
+		ProtectionDateTime time.Time `json:"protectionDateTime"`
+		ExpirationDateTime time.Time `json:"expirationDateTime"`
+		Tags               string    `json:"tags"` // flags enum, e.g. "none" or "fastRestore"
+		ProtectionUnit     struct {
+			ID string `json:"id"`
+		} `json:"protectionUnit"`
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	r.ID = tmp.ID
+	r.ProtectionUnitID = tmp.ProtectionUnit.ID
+	r.ProtectionDateTime = tmp.ProtectionDateTime
+	r.ExpirationDateTime = tmp.ExpirationDateTime
+	r.Tags = nil
+	for _, tag := range strings.Split(tmp.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && tag != "none" {
+			r.Tags = append(r.Tags, tag)
+		}
+	}
+	return nil
+}
+
+// RestoreArtifact represents the restoration of a single mailbox from a RestorePoint. It always belongs to
+// an exchange restore session, which is identified by SessionID.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/mailboxrestoreartifact
+type RestoreArtifact struct {
+	ID                 string
+	SessionID          string
+	Status             string // one of the RestoreArtifactStatus constants
+	DestinationType    string // RestoreDestinationNew or RestoreDestinationInPlace
+	RestorePointID     string
+	ProtectionUnitID   string
+	StartDateTime      time.Time
+	CompletionDateTime time.Time // zero until the restoration has finished
+	Error              string    // the error message if Status is failed
+}
+
+func (r RestoreArtifact) String() string {
+	return fmt.Sprintf("RestoreArtifact(ID: \"%v\", SessionID: \"%v\", Status: \"%v\", DestinationType: \"%v\", RestorePointID: \"%v\", "+
+		"ProtectionUnitID: \"%v\", StartDateTime: \"%v\", CompletionDateTime: \"%v\", Error: \"%v\")",
+		r.ID, r.SessionID, r.Status, r.DestinationType, r.RestorePointID, r.ProtectionUnitID, r.StartDateTime, r.CompletionDateTime, r.Error)
+}
+
+// IsFinished returns true if the restoration either succeeded or failed
+func (r RestoreArtifact) IsFinished() bool {
+	return r.Status == RestoreArtifactStatusSucceeded || r.Status == RestoreArtifactStatusFailed
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (r *RestoreArtifact) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ID                 string       `json:"id"`
+		Status             string       `json:"status"`
+		DestinationType    string       `json:"destinationType"`
+		StartDateTime      time.Time    `json:"startDateTime"`
+		CompletionDateTime time.Time    `json:"completionDateTime"`
+		RestorePoint       RestorePoint `json:"restorePoint"`
+		Error              *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	r.ID = tmp.ID
+	r.Status = tmp.Status
+	r.DestinationType = tmp.DestinationType
+	r.StartDateTime = tmp.StartDateTime
+	r.CompletionDateTime = tmp.CompletionDateTime
+	r.RestorePointID = tmp.RestorePoint.ID
+	r.ProtectionUnitID = tmp.RestorePoint.ProtectionUnitID
+	r.Error = ""
+	if tmp.Error != nil {
+		r.Error = tmp.Error.Message
+	}
+	return nil
+}
+
+// ListRestorePoints returns all restore points of the protection unit (e.g. a mailbox) identified by protectionUnitID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/restorepoint-search
+func (g *GraphClient) ListRestorePoints(protectionUnitID string) ([]RestorePoint, error) {
+	return g.ListRestorePointsContext(context.Background(), protectionUnitID)
+}
+
+// ListRestorePointsContext is ListRestorePoints with a context.
+func (g *GraphClient) ListRestorePointsContext(ctx context.Context, protectionUnitID string) ([]RestorePoint, error) {
+	body := struct {
+		ProtectionUnitIDs []string `json:"protectionUnitIds"`
+	}{ProtectionUnitIDs: []string{protectionUnitID}}
+	var marsh struct {
+		SearchResult []struct {
+			ProtectionUnitID string         `json:"protectionUnitId"`
+			RestorePoints    []RestorePoint `json:"restorePoints"`
+		} `json:"searchResult"`
+	}
+	err := g.makePOSTAPICall(ctx, "/solutions/backupRestore/restorePoints/search", body, &marsh)
+	if err != nil {
+		return nil, err
+	}
+	var restorePoints []RestorePoint
+	for _, result := range marsh.SearchResult {
+		for _, restorePoint := range result.RestorePoints {
+			if restorePoint.ProtectionUnitID == "" {
+				restorePoint.ProtectionUnitID = result.ProtectionUnitID
+			}
+			restorePoints = append(restorePoints, restorePoint)
+		}
+	}
+	return restorePoints, nil
+}
+
+// CreateRestoreArtifact restores the mailbox of the protection unit identified by protectionUnitID from the given
+// restore point. destinationType is either RestoreDestinationNew or RestoreDestinationInPlace.
+//
+// msgraph only restores within an exchange restore session, hence a new session containing the artifact is
+// created and activated. The restoration runs asynchronously, use GetRestoreArtifact or WaitForRestoreArtifact
+// with the returned SessionID and ID to follow its status.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/backuprestoreroot-post-exchangerestoresessions
+func (g *GraphClient) CreateRestoreArtifact(protectionUnitID, restorePointID, destinationType string) (RestoreArtifact, error) {
+	return g.CreateRestoreArtifactContext(context.Background(), protectionUnitID, restorePointID, destinationType)
+}
+
+// CreateRestoreArtifactContext is CreateRestoreArtifact with a context.
+func (g *GraphClient) CreateRestoreArtifactContext(ctx context.Context, protectionUnitID, restorePointID, destinationType string) (RestoreArtifact, error) {
+	type restorePointRef struct {
+		ID string `json:"id"`
+	}
+	body := struct {
+		MailboxRestoreArtifacts []struct {
+			RestorePoint    restorePointRef `json:"restorePoint"`
+			DestinationType string          `json:"destinationType"`
+		} `json:"mailboxRestoreArtifacts"`
+	}{}
+	body.MailboxRestoreArtifacts = append(body.MailboxRestoreArtifacts, struct {
+		RestorePoint    restorePointRef `json:"restorePoint"`
+		DestinationType string          `json:"destinationType"`
+	}{RestorePoint: restorePointRef{ID: restorePointID}, DestinationType: destinationType})
+
+	var session struct {
+		ID string `json:"id"`
+	}
+	err := g.makePOSTAPICall(ctx, "/solutions/backupRestore/exchangeRestoreSessions", body, &session)
+	if err != nil {
+		return RestoreArtifact{}, fmt.Errorf("cannot create exchange restore session: %w", err)
+	}
+
+	var artifacts struct {
+		Value []RestoreArtifact `json:"value"`
+	}
+	resource := fmt.Sprintf("/solutions/backupRestore/exchangeRestoreSessions/%v/mailboxRestoreArtifacts", session.ID)
+	err = g.makeGETAPICall(ctx, resource, nil, &artifacts)
+	if err != nil {
+		return RestoreArtifact{}, fmt.Errorf("cannot get the artifacts of exchange restore session %v: %w", session.ID, err)
+	}
+	if len(artifacts.Value) == 0 {
+		return RestoreArtifact{}, fmt.Errorf("exchange restore session %v contains no artifact", session.ID)
+	}
+
+	resource = fmt.Sprintf("/solutions/backupRestore/exchangeRestoreSessions/%v/activate", session.ID)
+	err = g.makePOSTAPICall(ctx, resource, nil, nil)
+	if err != nil {
+		return RestoreArtifact{}, fmt.Errorf("cannot activate exchange restore session %v: %w", session.ID, err)
+	}
+
+	artifact := artifacts.Value[0]
+	artifact.SessionID = session.ID
+	if artifact.ProtectionUnitID == "" {
+		artifact.ProtectionUnitID = protectionUnitID
+	}
+	return artifact, nil
+}
+
+// GetRestoreArtifact returns the restore artifact identified by artifactID within the exchange restore session
+// identified by sessionID, e.g. to check its Status.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/mailboxrestoreartifact-get
+func (g *GraphClient) GetRestoreArtifact(sessionID, artifactID string) (RestoreArtifact, error) {
+	return g.GetRestoreArtifactContext(context.Background(), sessionID, artifactID)
+}
+
+// GetRestoreArtifactContext is GetRestoreArtifact with a context.
+func (g *GraphClient) GetRestoreArtifactContext(ctx context.Context, sessionID, artifactID string) (RestoreArtifact, error) {
+	resource := fmt.Sprintf("/solutions/backupRestore/exchangeRestoreSessions/%v/mailboxRestoreArtifacts/%v", sessionID, artifactID)
+	var artifact RestoreArtifact
+	err := g.makeGETAPICall(ctx, resource, nil, &artifact)
+	artifact.SessionID = sessionID
+	return artifact, err
+}
+
+// WaitForRestoreArtifact polls the restore artifact every pollInterval until it is finished, see RestoreArtifact.IsFinished,
+// and returns it. An error is returned if the artifact is not finished after timeout.
+func (g *GraphClient) WaitForRestoreArtifact(sessionID, artifactID string, pollInterval, timeout time.Duration) (RestoreArtifact, error) {
+	return g.WaitForRestoreArtifactContext(context.Background(), sessionID, artifactID, pollInterval, timeout)
+}
+
+// WaitForRestoreArtifactContext is WaitForRestoreArtifact with a context, polling stops as soon as ctx is done.
+func (g *GraphClient) WaitForRestoreArtifactContext(ctx context.Context, sessionID, artifactID string, pollInterval, timeout time.Duration) (RestoreArtifact, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		artifact, err := g.GetRestoreArtifactContext(ctx, sessionID, artifactID)
+		if err != nil {
+			return artifact, err
+		}
+		if artifact.IsFinished() {
+			return artifact, nil
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			return artifact, fmt.Errorf("restore artifact %v is still %v after %v", artifactID, artifact.Status, timeout)
+		}
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return artifact, ctx.Err()
+		}
+	}
+}
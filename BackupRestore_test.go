@@ -0,0 +1,89 @@
+package msgraph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_ListRestorePoints(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/solutions/backupRestore/restorePoints/search", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || !strings.Contains(string(body), `"protectionUnitIds":["pu1"]`) {
+			t.Errorf("restorePoints/search %v body = %s", r.Method, body)
+		}
+		fmt.Fprint(w, `{"searchResult": [{"protectionUnitId": "pu1", "restorePoints": [
+			{"id": "rp1", "protectionDateTime": "2024-01-02T03:04:05Z", "expirationDateTime": "2025-01-02T03:04:05Z", "tags": "fastRestore"},
+			{"id": "rp2", "protectionDateTime": "2024-01-01T03:04:05Z", "expirationDateTime": "2025-01-01T03:04:05Z", "tags": "none"}]}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	got, err := g.ListRestorePoints("pu1")
+	if err != nil {
+		t.Fatalf("GraphClient.ListRestorePoints() error = %v", err)
+	}
+	want := []RestorePoint{
+		{ID: "rp1", ProtectionUnitID: "pu1", ProtectionDateTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ExpirationDateTime: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), Tags: []string{"fastRestore"}},
+		{ID: "rp2", ProtectionUnitID: "pu1", ProtectionDateTime: time.Date(2024, 1, 1, 3, 4, 5, 0, time.UTC),
+			ExpirationDateTime: time.Date(2025, 1, 1, 3, 4, 5, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GraphClient.ListRestorePoints() = %v, want %v", got, want)
+	}
+}
+
+func TestGraphClient_CreateRestoreArtifact(t *testing.T) {
+	var activated bool
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/solutions/backupRestore/exchangeRestoreSessions", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"restorePoint":{"id":"rp1"},"destinationType":"new"`) {
+			t.Errorf("exchangeRestoreSessions body = %s", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "s1", "status": "draft"}`)
+	})
+	mux.HandleFunc("/v1.0/solutions/backupRestore/exchangeRestoreSessions/s1/mailboxRestoreArtifacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "a1", "status": "added", "destinationType": "new", "restorePoint": {"id": "rp1"}}]}`)
+	})
+	mux.HandleFunc("/v1.0/solutions/backupRestore/exchangeRestoreSessions/s1/activate", func(w http.ResponseWriter, r *http.Request) {
+		activated = true
+		fmt.Fprint(w, `{"id": "s1", "status": "activating"}`)
+	})
+	mux.HandleFunc("/v1.0/solutions/backupRestore/exchangeRestoreSessions/s1/mailboxRestoreArtifacts/a1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 2 {
+			fmt.Fprint(w, `{"id": "a1", "status": "inProgress", "restorePoint": {"id": "rp1"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": "a1", "status": "succeeded", "completionDateTime": "2024-01-02T03:04:05Z", "restorePoint": {"id": "rp1"}}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	artifact, err := g.CreateRestoreArtifact("pu1", "rp1", RestoreDestinationNew)
+	if err != nil {
+		t.Fatalf("GraphClient.CreateRestoreArtifact() error = %v", err)
+	}
+	if !activated {
+		t.Errorf("GraphClient.CreateRestoreArtifact() did not activate the restore session")
+	}
+	want := RestoreArtifact{ID: "a1", SessionID: "s1", Status: RestoreArtifactStatusAdded, DestinationType: RestoreDestinationNew, RestorePointID: "rp1", ProtectionUnitID: "pu1"}
+	if !reflect.DeepEqual(artifact, want) {
+		t.Errorf("GraphClient.CreateRestoreArtifact() = %v, want %v", artifact, want)
+	}
+
+	artifact, err = g.WaitForRestoreArtifact(artifact.SessionID, artifact.ID, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("GraphClient.WaitForRestoreArtifact() error = %v", err)
+	}
+	if artifact.Status != RestoreArtifactStatusSucceeded || artifact.CompletionDateTime.IsZero() || polls != 2 {
+		t.Errorf("GraphClient.WaitForRestoreArtifact() = %v after %v polls, want succeeded after 2 polls", artifact, polls)
+	}
+}
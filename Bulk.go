@@ -0,0 +1,110 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type bulkOptions struct {
+	progress func(done, total int)
+}
+
+// BulkOption configures the bulk helpers, e.g. GetUsersByIdentifiers
+type BulkOption func(*bulkOptions)
+
+// BulkProgress sets a callback that is called after every identifier has been processed, successful or not. The
+// calls are serialized, done counts up to total.
+func BulkProgress(progress func(done, total int)) BulkOption {
+	return func(o *bulkOptions) { o.progress = progress }
+}
+
+// forEachIdentifier calls fn for every identifier with at most concurrency calls at the same time and collects
+// the errors returned by fn keyed by the identifier
+func forEachIdentifier(identifiers []string, concurrency int, opts []BulkOption, fn func(i int, identifier string) error) map[string]error {
+	var options bulkOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var done int
+	forEachConcurrently(len(identifiers), concurrency, func(i int) {
+		err := fn(i, identifiers[i])
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[identifiers[i]] = err
+		}
+		done++
+		if options.progress != nil {
+			options.progress(done, len(identifiers))
+		}
+	})
+	return errs
+}
+
+// GetUsersByIdentifiers returns the users identified by either the given IDs or userPrincipalNames, fetching at most
+// concurrency users at the same time. The users are returned in the order of the identifiers, users that could not
+// be fetched are missing and their error is returned in the map, keyed by the identifier.
+func (g *GraphClient) GetUsersByIdentifiers(identifiers []string, concurrency int, opts ...BulkOption) (Users, map[string]error) {
+	return g.GetUsersByIdentifiersContext(context.Background(), identifiers, concurrency, opts...)
+}
+
+// GetUsersByIdentifiersContext is GetUsersByIdentifiers with a context.
+func (g *GraphClient) GetUsersByIdentifiersContext(ctx context.Context, identifiers []string, concurrency int, opts ...BulkOption) (Users, map[string]error) {
+	users := make([]*User, len(identifiers))
+	errs := forEachIdentifier(identifiers, concurrency, opts, func(i int, identifier string) error {
+		user, err := g.GetUserContext(ctx, identifier)
+		if err == nil {
+			users[i] = &user
+		}
+		return err
+	})
+
+	var result Users
+	for _, user := range users {
+		if user != nil {
+			result = append(result, *user)
+		}
+	}
+	return result, errs
+}
+
+// ListCalendarViewsForUsers returns the calendar views between startDateTime and endDateTime of the users identified by
+// either the given IDs or userPrincipalNames, see User.ListCalendarView. At most concurrency calendar views are fetched
+// at the same time. The calendar views are keyed by the identifier, for users whose calendar view could not be fetched
+// the error is returned in the second map instead.
+func (g *GraphClient) ListCalendarViewsForUsers(identifiers []string, startDateTime, endDateTime time.Time, concurrency int, opts ...BulkOption) (map[string]CalendarEvents, map[string]error) {
+	return g.ListCalendarViewsForUsersContext(context.Background(), identifiers, startDateTime, endDateTime, concurrency, opts...)
+}
+
+// ListCalendarViewsForUsersContext is ListCalendarViewsForUsers with a context.
+func (g *GraphClient) ListCalendarViewsForUsersContext(ctx context.Context, identifiers []string, startDateTime, endDateTime time.Time, concurrency int, opts ...BulkOption) (map[string]CalendarEvents, map[string]error) {
+	calendarViews := make(map[string]CalendarEvents, len(identifiers))
+	if len(identifiers) == 0 {
+		return calendarViews, map[string]error{}
+	}
+	// the supported time zones are loaded by the first ListCalendarView, do that once before the calls run concurrently
+	if err := g.loadSupportedTimeZones(ctx, fmt.Sprintf("/users/%s", identifiers[0])); err != nil {
+		errs := make(map[string]error, len(identifiers))
+		for _, identifier := range identifiers {
+			errs[identifier] = fmt.Errorf("cannot get supported time zones: %w", err)
+		}
+		return calendarViews, errs
+	}
+
+	var mu sync.Mutex
+	errs := forEachIdentifier(identifiers, concurrency, opts, func(i int, identifier string) error {
+		calendarView, err := User{ID: identifier, graphClient: g}.ListCalendarViewContext(ctx, startDateTime, endDateTime)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		calendarViews[identifier] = calendarView
+		mu.Unlock()
+		return nil
+	})
+	return calendarViews, errs
+}
@@ -0,0 +1,81 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_GetUsersByIdentifiers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1.0/users/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": "Request_ResourceNotFound", "message": ""}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id": "%v", "userPrincipalName": "%v@contoso.com"}`, id, id)
+	})
+	g := newTestGraphClient(t, mux)
+
+	var progress []int
+	ids := []string{"u1", "u2", "missing", "u3", "u4"}
+	users, errs := g.GetUsersByIdentifiers(ids, 2, BulkProgress(func(done, total int) {
+		if total != len(ids) {
+			t.Errorf("BulkProgress() total = %v, want %v", total, len(ids))
+		}
+		progress = append(progress, done)
+	}))
+	if len(errs) != 1 || errs["missing"] == nil {
+		t.Errorf("GraphClient.GetUsersByIdentifiers() errs = %v, want an error for missing", errs)
+	}
+	var got []string
+	for _, user := range users {
+		got = append(got, user.ID)
+	}
+	if want := []string{"u1", "u2", "u3", "u4"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("GraphClient.GetUsersByIdentifiers() = %v, want %v", got, want)
+	}
+	if fmt.Sprint(progress) != "[1 2 3 4 5]" {
+		t.Errorf("BulkProgress() calls = %v, want [1 2 3 4 5]", progress)
+	}
+}
+
+func TestGraphClient_ListCalendarViewsForUsers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1.0/users/"), "/")
+		switch {
+		case len(parts) == 3 && parts[2] == "supportedTimeZones":
+			fmt.Fprint(w, `{"value": [{"alias": "W. Europe Standard Time", "displayName": "(UTC+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna"}]}`)
+		case parts[0] == "u2":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error": {"code": "ErrorAccessDenied", "message": ""}}`)
+		case len(parts) == 3 && parts[2] == "calendarview":
+			if r.URL.Query().Get("startdatetime") != "2021-03-01T00:00:00" {
+				t.Errorf("startdatetime = %v", r.URL.Query().Get("startdatetime"))
+			}
+			fmt.Fprintf(w, `{"value": [{"id": "%v-event", "subject": "Meeting",
+				"createdDateTime": "2021-02-01T10:00:00Z", "lastModifiedDateTime": "2021-02-01T10:00:00Z",
+				"originalStartTimeZone": "W. Europe Standard Time", "originalEndTimeZone": "W. Europe Standard Time",
+				"start": {"dateTime": "2021-03-01T08:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2021-03-01T09:00:00.0000000", "timeZone": "UTC"}}]}`, parts[0])
+		default:
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	g := newTestGraphClient(t, mux)
+
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	views, errs := g.ListCalendarViewsForUsers([]string{"u1", "u2", "u3"}, start, start.Add(24*time.Hour), 3)
+	if len(errs) != 1 || errs["u2"] == nil {
+		t.Errorf("GraphClient.ListCalendarViewsForUsers() errs = %v, want an error for u2", errs)
+	}
+	if len(views) != 2 || len(views["u1"]) != 1 || views["u3"][0].ID != "u3-event" {
+		t.Errorf("GraphClient.ListCalendarViewsForUsers() = %v, want one event for u1 and u3", views)
+	}
+}
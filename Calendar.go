@@ -9,14 +9,16 @@ import (
 //
 // See https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/resources/calendar
 type Calendar struct {
-	ID                  string // The group's unique identifier. Read-only.
-	Name                string // The calendar name.
-	CanEdit             bool   // True if the user can write to the calendar, false otherwise. This property is true for the user who created the calendar. This property is also true for a user who has been shared a calendar and granted write access.
-	CanShare            bool   // True if the user has the permission to share the calendar, false otherwise. Only the user who created the calendar can share it.
-	CanViewPrivateItems bool   // True if the user can read calendar items that have been marked private, false otherwise.
-	ChangeKey           string // Identifies the version of the calendar object. Every time the calendar is changed, changeKey changes as well. This allows Exchange to apply changes to the correct version of the object. Read-only.
+	ID                  string `json:"id"`                  // The group's unique identifier. Read-only.
+	Name                string `json:"name"`                // The calendar name.
+	CanEdit             bool   `json:"canEdit"`             // True if the user can write to the calendar, false otherwise. This property is true for the user who created the calendar. This property is also true for a user who has been shared a calendar and granted write access.
+	CanShare            bool   `json:"canShare"`            // True if the user has the permission to share the calendar, false otherwise. Only the user who created the calendar can share it.
+	CanViewPrivateItems bool   `json:"canViewPrivateItems"` // True if the user can read calendar items that have been marked private, false otherwise.
+	ChangeKey           string `json:"changeKey"`           // Identifies the version of the calendar object. Every time the calendar is changed, changeKey changes as well. This allows Exchange to apply changes to the correct version of the object. Read-only.
 
-	Owner EmailAddress // If set, this represents the user who created or added the calendar. For a calendar that the user created or added, the owner property is set to the user. For a calendar shared with the user, the owner property is set to the person who shared that calendar with the user.
+	AdditionalData AdditionalData `json:"-"` // properties that are not mapped to a field, see CaptureAdditionalData
+
+	Owner EmailAddress `json:"owner"` // If set, this represents the user who created or added the calendar. For a calendar that the user created or added, the owner property is set to the user. For a calendar shared with the user, the owner property is set to the person who shared that calendar with the user.
 
 	graphClient *GraphClient // the graphClient that created this instance
 }
@@ -35,13 +37,13 @@ func (c *Calendar) setGraphClient(graphClient *GraphClient) {
 // UnmarshalJSON implements the json unmarshal to be used by the json-library
 func (c *Calendar) UnmarshalJSON(data []byte) error {
 	tmp := struct {
-		ID                  string `json:"id"`                  // the calendars ID
-		Name                string `json:"name"`                // the name of the calendar
-		CanShare            bool   `json:"canShare"`            // true if the current account can shares this calendar
-		CanViewPrivateItems bool   `json:"canViewPrivateItems"` // true if the current account can view private entries
-		CanEdit             bool   `json:"canEdit"`             // true if the current account can edit the calendar
-		ChangeKey           string `json:"changeKey"`
-		Owner               EmailAddress
+		ID                  string       `json:"id"`                  // the calendars ID
+		Name                string       `json:"name"`                // the name of the calendar
+		CanShare            bool         `json:"canShare"`            // true if the current account can shares this calendar
+		CanViewPrivateItems bool         `json:"canViewPrivateItems"` // true if the current account can view private entries
+		CanEdit             bool         `json:"canEdit"`             // true if the current account can edit the calendar
+		ChangeKey           string       `json:"changeKey"`
+		Owner               EmailAddress `json:"owner"`
 	}{}
 	err := json.Unmarshal(data, &tmp)
 	if err != nil {
@@ -56,6 +58,17 @@ func (c *Calendar) UnmarshalJSON(data []byte) error {
 	c.ChangeKey = tmp.ChangeKey
 
 	c.Owner = tmp.Owner
+	c.AdditionalData, err = extractAdditionalData(data, &tmp)
+
+	return err
+}
 
-	return nil
+// MarshalJSON implements the json marshal to be used by the json-library, the AdditionalData is included
+func (c Calendar) MarshalJSON() ([]byte, error) {
+	type calendar Calendar // calendar has no MarshalJSON, prevents the recursion
+	marshalled, err := json.Marshal(calendar(c))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, c.AdditionalData)
 }
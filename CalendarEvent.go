@@ -1,11 +1,81 @@
 package msgraph
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 )
 
+// Importance is the importance of a CalendarEvent
+type Importance string
+
+// Importances as returned by msgraph in importance
+const (
+	ImportanceLow    Importance = "low"
+	ImportanceNormal Importance = "normal"
+	ImportanceHigh   Importance = "high"
+)
+
+// IsValid returns true if i is one of the Importance constants
+func (i Importance) IsValid() bool {
+	return i == ImportanceLow || i == ImportanceNormal || i == ImportanceHigh
+}
+
+// Sensitivity is the sensitivity of a CalendarEvent
+type Sensitivity string
+
+// Sensitivities as returned by msgraph in sensitivity
+const (
+	SensitivityNormal       Sensitivity = "normal"
+	SensitivityPersonal     Sensitivity = "personal"
+	SensitivityPrivate      Sensitivity = "private"
+	SensitivityConfidential Sensitivity = "confidential"
+)
+
+// IsValid returns true if s is one of the Sensitivity constants
+func (s Sensitivity) IsValid() bool {
+	return s == SensitivityNormal || s == SensitivityPersonal || s == SensitivityPrivate || s == SensitivityConfidential
+}
+
+// FreeBusyStatus is the status a CalendarEvent shows the calendar owner as
+type FreeBusyStatus string
+
+// Free/busy statuses as returned by msgraph in showAs
+const (
+	FreeBusyStatusUnknown          FreeBusyStatus = "unknown"
+	FreeBusyStatusFree             FreeBusyStatus = "free"
+	FreeBusyStatusTentative        FreeBusyStatus = "tentative"
+	FreeBusyStatusBusy             FreeBusyStatus = "busy"
+	FreeBusyStatusOof              FreeBusyStatus = "oof" // out of office
+	FreeBusyStatusWorkingElsewhere FreeBusyStatus = "workingElsewhere"
+)
+
+// IsValid returns true if s is one of the FreeBusyStatus constants
+func (s FreeBusyStatus) IsValid() bool {
+	switch s {
+	case FreeBusyStatusUnknown, FreeBusyStatusFree, FreeBusyStatusTentative, FreeBusyStatusBusy, FreeBusyStatusOof, FreeBusyStatusWorkingElsewhere:
+		return true
+	}
+	return false
+}
+
+// EventType is the type of a CalendarEvent with regards to recurrence
+type EventType string
+
+// Event types as returned by msgraph in type
+const (
+	EventTypeSingleInstance EventType = "singleInstance"
+	EventTypeOccurrence     EventType = "occurrence"
+	EventTypeException      EventType = "exception"
+	EventTypeSeriesMaster   EventType = "seriesMaster"
+)
+
+// IsValid returns true if t is one of the EventType constants
+func (t EventType) IsValid() bool {
+	return t == EventTypeSingleInstance || t == EventTypeOccurrence || t == EventTypeException || t == EventTypeSeriesMaster
+}
+
 // CalendarEvent represents a single event within a calendar
 type CalendarEvent struct {
 	ID                    string
@@ -15,17 +85,21 @@ type CalendarEvent struct {
 	OriginalEndTimeZone   *time.Location // The original end-timezone, is already integrated in the calendartimes. Caution: is UTC on full day events
 	ICalUID               string
 	Subject               string
-	Importance            string
-	Sensitivity           string
+	Importance            Importance
+	Sensitivity           Sensitivity
 	IsAllDay              bool   // true = full day event, otherwise false
 	IsCancelled           bool   // calendar event has been cancelled but is still in the calendar
 	IsOrganizer           bool   // true if the calendar owner is the organizer
 	SeriesMasterID        string // the ID of the master-entry of this series-event if any
-	ShowAs                string
-	Type                  string
+	ShowAs                FreeBusyStatus
+	Type                  EventType
 	ResponseStatus        ResponseStatus // how the calendar-owner responded to the event (normally "organizer" because support-calendar is the host)
 	StartTime             time.Time      // starttime of the Event, correct timezone is set
 	EndTime               time.Time      // endtime of the event, correct timezone is set
+	Body                  MsgBody        // the body of the event, mostly HTML
+	UniqueBody            *MsgBody       // the part of the body that is unique to this event, only returned by msgraph if selected
+	BodyPreview           string         // the first 255 characters of the body as text
+	AdditionalData        AdditionalData `json:"-"` // properties that are not mapped to a field, see CaptureAdditionalData
 
 	Attendees      Attendees // represents all attendees to this CalendarEvent
 	OrganizerName  string    // the name of the organizer from the e-mail, not reliable to identify anyone
@@ -44,6 +118,29 @@ func (c CalendarEvent) GetFirstAttendee() Attendee {
 	return Attendee{Name: "None"}
 }
 
+// BodyText returns the body of the event as readable plain text, see MsgBody.Text
+func (c CalendarEvent) BodyText() string {
+	return c.Body.Text()
+}
+
+// UniqueBodyText returns the unique body of the event as readable plain text if it has been
+// returned by msgraph, otherwise the whole body as plain text. See MsgBody.Text
+func (c CalendarEvent) UniqueBodyText() string {
+	if c.UniqueBody != nil {
+		return c.UniqueBody.Text()
+	}
+	return c.BodyText()
+}
+
+// BodyPreviewTruncated returns the BodyPreview (or the body as text if there's no preview) truncated to at most n runes.
+// A truncated preview ends with "…".
+func (c CalendarEvent) BodyPreviewTruncated(n int) string {
+	if c.BodyPreview != "" {
+		return truncateRunes(c.BodyPreview, n)
+	}
+	return truncateRunes(c.BodyText(), n)
+}
+
 func (c CalendarEvent) String() string {
 	return fmt.Sprintf("CalendarEvent(ID: \"%v\", CreatedDateTime: \"%v\", LastModifiedDateTime: \"%v\", "+
 		"ICalUId: \"%v\", Subject: \"%v\", "+
@@ -55,6 +152,32 @@ func (c CalendarEvent) String() string {
 		c.Type, c.ResponseStatus, c.Attendees, c.OrganizerName+" "+c.OrganizerEMail, c.StartTime, c.EndTime)
 }
 
+// Validate returns an error if an enum field of the CalendarEvent or of one of its Attendees has a value
+// that is unknown to msgraph
+func (c CalendarEvent) Validate() error {
+	if !c.Importance.IsValid() {
+		return fmt.Errorf("invalid Importance %q", c.Importance)
+	}
+	if !c.Sensitivity.IsValid() {
+		return fmt.Errorf("invalid Sensitivity %q", c.Sensitivity)
+	}
+	if !c.ShowAs.IsValid() {
+		return fmt.Errorf("invalid ShowAs %q", c.ShowAs)
+	}
+	if !c.Type.IsValid() {
+		return fmt.Errorf("invalid Type %q", c.Type)
+	}
+	if !c.ResponseStatus.Response.IsValid() {
+		return fmt.Errorf("invalid response %q", c.ResponseStatus.Response)
+	}
+	for _, attendee := range c.Attendees {
+		if err := attendee.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // PrettySimpleString returns all Calendar Events in a readable format, mostly used for logging purposes
 func (c CalendarEvent) PrettySimpleString() string {
 	return fmt.Sprintf("{ %v (%v) [%v - %v] }", c.Subject, c.GetFirstAttendee().Name, c.StartTime, c.EndTime)
@@ -80,17 +203,20 @@ func (c *CalendarEvent) UnmarshalJSON(data []byte) error {
 		OriginalEndTimeZone   string            `json:"originalEndTimeZone"`
 		ICalUID               string            `json:"iCalUId"`
 		Subject               string            `json:"subject"`
-		Importance            string            `json:"importance"`
-		Sensitivity           string            `json:"sensitivity"`
+		Importance            Importance        `json:"importance"`
+		Sensitivity           Sensitivity       `json:"sensitivity"`
 		IsAllDay              bool              `json:"isAllDay"`
 		IsCancelled           bool              `json:"isCancelled"`
 		IsOrganizer           bool              `json:"isOrganizer"`
 		SeriesMasterID        string            `json:"seriesMasterId"`
-		ShowAs                string            `json:"showAs"`
-		Type                  string            `json:"type"`
+		ShowAs                FreeBusyStatus    `json:"showAs"`
+		Type                  EventType         `json:"type"`
 		ResponseStatus        ResponseStatus    `json:"responseStatus"`
 		Start                 map[string]string `json:"start"`
 		End                   map[string]string `json:"end"`
+		Body                  MsgBody           `json:"body"`
+		UniqueBody            *MsgBody          `json:"uniqueBody"`
+		BodyPreview           string            `json:"bodyPreview"`
 		Attendees             Attendees         `json:"attendees"`
 		Organizer             struct {
 			EmailAddress struct {
@@ -134,9 +260,16 @@ func (c *CalendarEvent) UnmarshalJSON(data []byte) error {
 	c.ShowAs = tmp.ShowAs
 	c.Type = tmp.Type
 	c.ResponseStatus = tmp.ResponseStatus
+	c.Body = tmp.Body
+	c.UniqueBody = tmp.UniqueBody
+	c.BodyPreview = tmp.BodyPreview
 	c.Attendees = tmp.Attendees
 	c.OrganizerName = tmp.Organizer.EmailAddress.Name
 	c.OrganizerEMail = tmp.Organizer.EmailAddress.Address
+	c.AdditionalData, err = extractAdditionalData(data, &tmp)
+	if err != nil {
+		return err
+	}
 
 	// Parse event start & endtime with timezone
 	c.StartTime, err = parseTimeAndLocation(tmp.Start["dateTime"], tmp.Start["timeZone"]) // the timeZone is normally ALWAYS UTC, microsoft converts time date & time to that
@@ -170,6 +303,89 @@ func (c *CalendarEvent) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json marshal to be used by the json-library, it emits the properties of msgraph, i.e. it is
+// the inverse of UnmarshalJSON. The start and end are emitted in UTC, the wall clock of full-day events is kept, see
+// FullDayEventTimeZone. The AdditionalData is included.
+func (c CalendarEvent) MarshalJSON() ([]byte, error) {
+	tmp := struct {
+		ID                    string            `json:"id"`
+		CreatedDateTime       string            `json:"createdDateTime"`
+		LastModifiedDateTime  string            `json:"lastModifiedDateTime"`
+		OriginalStartTimeZone string            `json:"originalStartTimeZone"`
+		OriginalEndTimeZone   string            `json:"originalEndTimeZone"`
+		ICalUID               string            `json:"iCalUId"`
+		Subject               string            `json:"subject"`
+		Importance            Importance        `json:"importance"`
+		Sensitivity           Sensitivity       `json:"sensitivity"`
+		IsAllDay              bool              `json:"isAllDay"`
+		IsCancelled           bool              `json:"isCancelled"`
+		IsOrganizer           bool              `json:"isOrganizer"`
+		SeriesMasterID        string            `json:"seriesMasterId"`
+		ShowAs                FreeBusyStatus    `json:"showAs"`
+		Type                  EventType         `json:"type"`
+		ResponseStatus        ResponseStatus    `json:"responseStatus"`
+		Start                 map[string]string `json:"start"`
+		End                   map[string]string `json:"end"`
+		Body                  MsgBody           `json:"body"`
+		UniqueBody            *MsgBody          `json:"uniqueBody,omitempty"`
+		BodyPreview           string            `json:"bodyPreview"`
+		Attendees             Attendees         `json:"attendees"`
+		Organizer             struct {
+			EmailAddress struct {
+				Name    string `json:"name"`
+				Address string `json:"address"`
+			} `json:"emailAddress"`
+		} `json:"organizer"`
+	}{
+		ID:                    c.ID,
+		CreatedDateTime:       c.CreatedDateTime.Format(time.RFC3339Nano),
+		LastModifiedDateTime:  c.LastModifiedDateTime.Format(time.RFC3339Nano),
+		OriginalStartTimeZone: timeZoneString(c.OriginalStartTimeZone),
+		OriginalEndTimeZone:   timeZoneString(c.OriginalEndTimeZone),
+		ICalUID:               c.ICalUID,
+		Subject:               c.Subject,
+		Importance:            c.Importance,
+		Sensitivity:           c.Sensitivity,
+		IsAllDay:              c.IsAllDay,
+		IsCancelled:           c.IsCancelled,
+		IsOrganizer:           c.IsOrganizer,
+		SeriesMasterID:        c.SeriesMasterID,
+		ShowAs:                c.ShowAs,
+		Type:                  c.Type,
+		ResponseStatus:        c.ResponseStatus,
+		Start:                 formatTimeAndLocation(c.StartTime, c.IsAllDay),
+		End:                   formatTimeAndLocation(c.EndTime, c.IsAllDay),
+		Body:                  c.Body,
+		UniqueBody:            c.UniqueBody,
+		BodyPreview:           c.BodyPreview,
+		Attendees:             c.Attendees,
+	}
+	tmp.Organizer.EmailAddress.Name = c.OrganizerName
+	tmp.Organizer.EmailAddress.Address = c.OrganizerEMail
+
+	marshalled, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, c.AdditionalData)
+}
+
+// DeleteCalendarEvent deletes the event identified by eventID from the calendar of the user identified by either
+// the given ID or userPrincipalName
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/event-delete
+func (g *GraphClient) DeleteCalendarEvent(userID, eventID string) error {
+	return g.DeleteCalendarEventContext(context.Background(), userID, eventID)
+}
+
+// DeleteCalendarEventContext is DeleteCalendarEvent with a context.
+func (g *GraphClient) DeleteCalendarEventContext(ctx context.Context, userID, eventID string) error {
+	if err := g.checkUserIdentifier(userID); err != nil {
+		return err
+	}
+	return g.makeDELETEAPICall(ctx, fmt.Sprintf("/users/%v/events/%v", userID, eventID))
+}
+
 // parseTimeAndLocation is just a helper method to shorten the code in the Unmarshal json
 func parseTimeAndLocation(timeToParse, locationToParse string) (time.Time, error) {
 	parsedTime, err := time.Parse("2006-01-02T15:04:05.999999999", timeToParse)
@@ -188,5 +404,30 @@ func mapTimeZoneStrings(timeZone string) (*time.Location, error) {
 	if timeZone == "tzone://Microsoft/Custom" {
 		return FullDayEventTimeZone, nil
 	}
-	return globalSupportedTimeZones.GetTimeZoneByAlias(timeZone)
+	return currentSupportedTimeZones().GetTimeZoneByAlias(timeZone)
+}
+
+// formatTimeAndLocation is the inverse of parseTimeAndLocation, it returns the dateTime and timeZone of msgraph for t.
+// The wall clock of a full-day event is emitted as UTC, reverting the shift to the FullDayEventTimeZone of UnmarshalJSON.
+func formatTimeAndLocation(t time.Time, isAllDay bool) map[string]string {
+	const layout = "2006-01-02T15:04:05.0000000"
+	if isAllDay && FullDayEventTimeZone != time.UTC {
+		return map[string]string{"dateTime": t.Format(layout), "timeZone": "UTC"}
+	}
+	return map[string]string{"dateTime": t.UTC().Format(layout), "timeZone": "UTC"}
+}
+
+// timeZoneString is the inverse of mapTimeZoneStrings, it returns the alias used by Microsoft for the given location
+// or the name of the location if no alias is found
+func timeZoneString(loc *time.Location) string {
+	if loc == nil {
+		return ""
+	}
+	if alias, err := currentSupportedTimeZones().GetAliasByTimeZone(loc); err == nil {
+		return alias
+	}
+	if loc == FullDayEventTimeZone {
+		return "tzone://Microsoft/Custom"
+	}
+	return loc.String()
 }
@@ -0,0 +1,203 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Change types of a ChangeNotification
+const (
+	ChangeTypeCreated = "created"
+	ChangeTypeUpdated = "updated"
+	ChangeTypeDeleted = "deleted"
+)
+
+// changeNotificationMetadata are the keys of the resourceData of a ChangeNotification that do not carry properties
+// of the changed object. A resourceData with other keys includes the changed object, see includeResourceData.
+var changeNotificationMetadata = map[string]bool{
+	"@odata.type": true, "@odata.id": true, "@odata.etag": true, "id": true, "organizationId": true, "sequenceNumber": true,
+}
+
+// ChangeNotification is a single notification of a msgraph subscription, as posted to its notificationUrl
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/changenotification
+type ChangeNotification struct {
+	SubscriptionID string          `json:"subscriptionId"`
+	ClientState    string          `json:"clientState"` // verify it before routing, see VerifyNotificationSignature
+	ChangeType     string          `json:"changeType"`  // one of the ChangeType constants
+	Resource       string          `json:"resource"`    // e.g. Users/{id}
+	TenantID       string          `json:"tenantId"`
+	ResourceData   json.RawMessage `json:"resourceData"`
+}
+
+func (n ChangeNotification) String() string {
+	return fmt.Sprintf("ChangeNotification(SubscriptionID: \"%v\", ChangeType: \"%v\", Resource: \"%v\", TenantID: \"%v\")",
+		n.SubscriptionID, n.ChangeType, n.Resource, n.TenantID)
+}
+
+// resourceTypeAndID returns the lower case type, e.g. "user", and the ID of the changed object. The type is taken
+// from the @odata.type of the resourceData, or from the resource if the resourceData has none.
+func (n ChangeNotification) resourceTypeAndID() (string, string) {
+	var data struct {
+		Type string `json:"@odata.type"`
+		ID   string `json:"id"`
+	}
+	json.Unmarshal(n.ResourceData, &data)
+	segments := strings.Split(strings.Trim(n.Resource, "/"), "/")
+	if data.ID == "" {
+		data.ID = segments[len(segments)-1]
+	}
+	resourceType := strings.ToLower(data.Type[strings.LastIndex(data.Type, ".")+1:])
+	if resourceType == "" && len(segments) > 1 {
+		resourceType = strings.TrimSuffix(strings.ToLower(segments[len(segments)-2]), "s")
+	}
+	return resourceType, data.ID
+}
+
+// includesResourceData returns true if the resourceData carries properties of the changed object, hence it does not
+// have to be fetched
+func (n ChangeNotification) includesResourceData() bool {
+	var data map[string]json.RawMessage
+	json.Unmarshal(n.ResourceData, &data)
+	for key := range data {
+		if !changeNotificationMetadata[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationRouter dispatches the change notifications of users, groups and devices to typed callbacks, see
+// GraphClient.NewNotificationRouter. The changed object is fetched from msgraph unless the notification includes
+// it. Deleted objects cannot be fetched anymore, their callback receives an object with just the ID set.
+//
+// The objects of several notifications are fetched concurrently, the callbacks of the notifications of the same
+// object are however called one after another in the order the notifications have been passed to Route, even
+// across concurrent calls of Route.
+type NotificationRouter struct {
+	OnUserChanged   func(user User, changeType string)
+	OnGroupChanged  func(group Group, changeType string)
+	OnDeviceChanged func(device Device, changeType string)
+
+	graphClient *GraphClient
+	concurrency int
+	mu          sync.Mutex
+	pending     map[string]chan struct{} // closed after the last routed notification of the object has been dispatched
+}
+
+// NewNotificationRouter returns a NotificationRouter that fetches at most concurrency changed objects at the same
+// time. Set its callbacks before the first call of Route, notifications without a callback are dropped.
+func (g *GraphClient) NewNotificationRouter(concurrency int) *NotificationRouter {
+	return &NotificationRouter{graphClient: g, concurrency: concurrency, pending: make(map[string]chan struct{})}
+}
+
+// Route dispatches the notifications of the given body, the raw JSON posted by msgraph to the notificationUrl,
+// and returns as soon as all of them have been dispatched. Returns an error if the body cannot be parsed or any
+// changed object could not be fetched, the other notifications are dispatched anyway.
+func (r *NotificationRouter) Route(body []byte) error {
+	return r.RouteContext(context.Background(), body)
+}
+
+// RouteContext is Route with a context.
+func (r *NotificationRouter) RouteContext(ctx context.Context, body []byte) error {
+	var notifications struct {
+		Value []ChangeNotification `json:"value"`
+	}
+	if err := json.Unmarshal(body, &notifications); err != nil {
+		return fmt.Errorf("cannot unmarshal change notifications: %v", err)
+	}
+	return r.RouteNotificationsContext(ctx, notifications.Value)
+}
+
+// RouteNotifications dispatches the given notifications, see Route
+func (r *NotificationRouter) RouteNotifications(notifications []ChangeNotification) error {
+	return r.RouteNotificationsContext(context.Background(), notifications)
+}
+
+// RouteNotificationsContext is RouteNotifications with a context.
+func (r *NotificationRouter) RouteNotificationsContext(ctx context.Context, notifications []ChangeNotification) error {
+	type routed struct {
+		notification ChangeNotification
+		key          string
+		previous     chan struct{} // closed when the previous notification of the same object has been dispatched
+		done         chan struct{}
+	}
+	// queue the notifications under the lock, hence the order per object is the order of the calls of Route
+	queue := make([]routed, len(notifications))
+	r.mu.Lock()
+	for i, notification := range notifications {
+		resourceType, id := notification.resourceTypeAndID()
+		key := resourceType + "/" + id
+		queue[i] = routed{notification: notification, key: key, previous: r.pending[key], done: make(chan struct{})}
+		r.pending[key] = queue[i].done
+	}
+	r.mu.Unlock()
+
+	errs := make([]error, len(queue))
+	forEachConcurrently(len(queue), r.concurrency, func(i int) {
+		q := queue[i]
+		dispatch, err := r.prepare(ctx, q.notification)
+		if q.previous != nil {
+			<-q.previous
+		}
+		if err == nil {
+			dispatch()
+		}
+		errs[i] = err
+		close(q.done)
+		r.mu.Lock()
+		if r.pending[q.key] == q.done {
+			delete(r.pending, q.key)
+		}
+		r.mu.Unlock()
+	})
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("cannot route %v of %v change notifications: %v", len(failed), len(queue), failed[0])
+	}
+	return nil
+}
+
+// prepare fetches or unmarshals the changed object of the notification and returns the call of its callback
+func (r *NotificationRouter) prepare(ctx context.Context, notification ChangeNotification) (func(), error) {
+	resourceType, id := notification.resourceTypeAndID()
+	deleted := notification.ChangeType == ChangeTypeDeleted
+	included := !deleted && notification.includesResourceData()
+	var err error
+	switch {
+	case resourceType == "user" && r.OnUserChanged != nil:
+		user := User{ID: id, graphClient: r.graphClient}
+		if included {
+			err = json.Unmarshal(notification.ResourceData, &user)
+		} else if !deleted {
+			user, err = r.graphClient.GetUserContext(ctx, id)
+		}
+		return func() { r.OnUserChanged(user, notification.ChangeType) }, err
+	case resourceType == "group" && r.OnGroupChanged != nil:
+		group := Group{ID: id, graphClient: r.graphClient}
+		if included {
+			err = json.Unmarshal(notification.ResourceData, &group)
+		} else if !deleted {
+			group, err = r.graphClient.GetGroupContext(ctx, id)
+		}
+		return func() { r.OnGroupChanged(group, notification.ChangeType) }, err
+	case resourceType == "device" && r.OnDeviceChanged != nil:
+		device := Device{ID: id}
+		if included {
+			err = json.Unmarshal(notification.ResourceData, &device)
+		} else if !deleted {
+			device, err = r.graphClient.GetDeviceContext(ctx, id)
+		}
+		return func() { r.OnDeviceChanged(device, notification.ChangeType) }, err
+	}
+	return func() {}, nil // no callback for the type
+}
@@ -0,0 +1,67 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotificationRouter_Route(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond) // the deletion of u1 is ready long before its update has been fetched
+		fmt.Fprint(w, `{"id": "u1", "displayName": "Alice"}`)
+	})
+	mux.HandleFunc("/v1.0/users/u2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "u2", "displayName": "Bob"}`)
+	})
+	mux.HandleFunc("/v1.0/devices/d1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "d1", "displayName": "Laptop"}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	var mu sync.Mutex
+	var got []string
+	record := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, fmt.Sprintf(format, args...))
+	}
+	router := g.NewNotificationRouter(4)
+	router.OnUserChanged = func(user User, changeType string) { record("user %v %v %q", user.ID, changeType, user.DisplayName) }
+	router.OnGroupChanged = func(group Group, changeType string) {
+		record("group %v %v %q", group.ID, changeType, group.DisplayName)
+	}
+	router.OnDeviceChanged = func(device Device, changeType string) {
+		record("device %v %v %q", device.ID, changeType, device.DisplayName)
+	}
+
+	err := router.Route([]byte(`{"value": [
+		{"changeType": "updated", "resource": "Users/u1", "resourceData": {"@odata.type": "#Microsoft.Graph.User", "@odata.id": "Users/u1", "id": "u1"}},
+		{"changeType": "updated", "resource": "Users/u2", "resourceData": {"@odata.type": "#Microsoft.Graph.User", "@odata.id": "Users/u2", "id": "u2"}},
+		{"changeType": "deleted", "resource": "Users/u1", "resourceData": {"@odata.type": "#Microsoft.Graph.User", "@odata.id": "Users/u1", "id": "u1"}},
+		{"changeType": "created", "resource": "Groups/g1", "resourceData": {"@odata.type": "#microsoft.graph.group", "id": "g1", "displayName": "Sales"}},
+		{"changeType": "updated", "resource": "Devices/d1", "resourceData": {}}]}`))
+	if err != nil {
+		t.Fatalf("NotificationRouter.Route() error = %v", err)
+	}
+
+	index := map[string]int{}
+	for i, call := range got {
+		index[call] = i + 1
+	}
+	for _, want := range []string{`user u1 updated "Alice"`, `user u1 deleted ""`, `user u2 updated "Bob"`, `group g1 created "Sales"`, `device d1 updated "Laptop"`} {
+		if index[want] == 0 {
+			t.Errorf("NotificationRouter.Route() callbacks %q, missing %q", got, want)
+		}
+	}
+	if index[`user u1 updated "Alice"`] > index[`user u1 deleted ""`] {
+		t.Errorf("NotificationRouter.Route() callbacks %q, want the update of u1 before its deletion", got)
+	}
+
+	if err := router.Route([]byte(`{"value": [{"changeType": "updated", "resource": "Users/unknown"}]}`)); err == nil {
+		t.Errorf("NotificationRouter.Route() of a user that cannot be fetched error = nil, want an error")
+	}
+}
@@ -0,0 +1,230 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ExportFormat is the output format of ExportChannelConversation and ExportChatConversation
+type ExportFormat string
+
+// Supported ExportFormats
+const (
+	ExportFormatJSONLines ExportFormat = "jsonl" // one json-marshalled ChatMessage per line
+	ExportFormatHTML      ExportFormat = "html"  // a self-contained HTML transcript, inline images are embedded as data URIs
+)
+
+// hostedContentSrc matches the src attribute of an inline image of a ChatMessage body, which references a hostedContent
+var hostedContentSrc = regexp.MustCompile(`src="(https://[^"]+/hostedContents/([^/"]+)/\$value)"`)
+
+type exportOptions struct {
+	resumeAfter      string
+	hostedContentDir string
+	progress         func(messageID string)
+}
+
+// ExportOption configures ExportChannelConversation and ExportChatConversation
+type ExportOption func(*exportOptions)
+
+// ExportResumeAfter skips all messages up to and including the message with the given ID, e.g. the last message
+// written by an export that failed because of throttling. The export fails if the message is not found.
+func ExportResumeAfter(messageID string) ExportOption {
+	return func(o *exportOptions) { o.resumeAfter = messageID }
+}
+
+// ExportHostedContentDir saves the inline images of ExportFormatJSONLines exports into dir and rewrites the
+// message bodies to reference the saved files by their name. Without it the bodies keep referencing msgraph.
+func ExportHostedContentDir(dir string) ExportOption {
+	return func(o *exportOptions) { o.hostedContentDir = dir }
+}
+
+// ExportProgress sets a callback that is called with the ID of every message after it has been written, e.g. to
+// remember where to resume with ExportResumeAfter
+func ExportProgress(progress func(messageID string)) ExportOption {
+	return func(o *exportOptions) { o.progress = progress }
+}
+
+// ExportChannelConversation writes the messages of the channel to w in the given format, oldest thread first. A
+// thread is exported with all its replies if its root message has been modified since the given time, use the zero
+// time to export the whole channel. Deleted and edited messages are exported with DeletedDateTime and
+// LastEditedDateTime set, inline images are downloaded, see ExportFormat and ExportHostedContentDir.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/channel-list-messages
+func (g *GraphClient) ExportChannelConversation(teamID, channelID string, since time.Time, w io.Writer, format ExportFormat, opts ...ExportOption) error {
+	return g.ExportChannelConversationContext(context.Background(), teamID, channelID, since, w, format, opts...)
+}
+
+// ExportChannelConversationContext is ExportChannelConversation with a context.
+func (g *GraphClient) ExportChannelConversationContext(ctx context.Context, teamID, channelID string, since time.Time, w io.Writer, format ExportFormat, opts ...ExportOption) error {
+	resource := fmt.Sprintf("/teams/%v/channels/%v/messages", teamID, channelID)
+	roots, err := g.listChatMessages(ctx, resource, nil)
+	if err != nil {
+		return err
+	}
+	var messages []ChatMessage
+	for _, root := range roots {
+		if root.LastModifiedDateTime.Before(since) {
+			continue
+		}
+		replies, err := g.listChatMessages(ctx, fmt.Sprintf("%v/%v/replies", resource, root.ID), nil)
+		if err != nil {
+			return err
+		}
+		messages = append(append(messages, root), replies...)
+	}
+	return g.exportChatMessages(ctx, messages, w, format, opts)
+}
+
+// ExportChatConversation writes the messages of the chat that have been modified since the given time to w in the
+// given format, oldest message first. Use the zero time to export the whole chat, see ExportChannelConversation.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/chat-list-messages
+func (g *GraphClient) ExportChatConversation(chatID string, since time.Time, w io.Writer, format ExportFormat, opts ...ExportOption) error {
+	return g.ExportChatConversationContext(context.Background(), chatID, since, w, format, opts...)
+}
+
+// ExportChatConversationContext is ExportChatConversation with a context.
+func (g *GraphClient) ExportChatConversationContext(ctx context.Context, chatID string, since time.Time, w io.Writer, format ExportFormat, opts ...ExportOption) error {
+	getParams := url.Values{}
+	if !since.IsZero() {
+		getParams.Add("$orderby", "lastModifiedDateTime desc") // required by the $filter
+		getParams.Add("$filter", fmt.Sprintf("lastModifiedDateTime gt %v", since.UTC().Format(time.RFC3339)))
+	}
+	messages, err := g.listChatMessages(ctx, fmt.Sprintf("/chats/%v/messages", chatID), getParams)
+	if err != nil {
+		return err
+	}
+	return g.exportChatMessages(ctx, messages, w, format, opts)
+}
+
+// exportChatMessages writes the messages to w in the given format
+func (g *GraphClient) exportChatMessages(ctx context.Context, messages []ChatMessage, w io.Writer, format ExportFormat, opts []ExportOption) error {
+	var options exportOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if format != ExportFormatJSONLines && format != ExportFormatHTML {
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+	if options.resumeAfter != "" {
+		resumed := false
+		for i, message := range messages {
+			if message.ID == options.resumeAfter {
+				messages, resumed = messages[i+1:], true
+				break
+			}
+		}
+		if !resumed {
+			return fmt.Errorf("cannot resume after message %v, it is not part of the export", options.resumeAfter)
+		}
+	}
+
+	if format == ExportFormatHTML {
+		if _, err := io.WriteString(w, "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>Conversation</title></head>\n<body>\n"); err != nil {
+			return err
+		}
+	}
+	for _, message := range messages {
+		content, err := g.rewriteHostedContents(ctx, message, format, options.hostedContentDir)
+		if err != nil {
+			return err
+		}
+		message.Body.Content = content
+		if format == ExportFormatHTML {
+			_, err = io.WriteString(w, chatMessageHTML(message))
+		} else {
+			var line []byte
+			if line, err = json.Marshal(message); err == nil {
+				_, err = w.Write(append(line, '\n'))
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("cannot write message %v: %v", message.ID, err)
+		}
+		if options.progress != nil {
+			options.progress(message.ID)
+		}
+	}
+	if format == ExportFormatHTML {
+		_, err := io.WriteString(w, "</body>\n</html>\n")
+		return err
+	}
+	return nil
+}
+
+// rewriteHostedContents downloads the inline images of the HTML body of the message and returns the body referencing
+// them as data URIs (ExportFormatHTML) or as files saved into dir (ExportFormatJSONLines). The body is returned
+// unchanged if there is nothing to download.
+func (g *GraphClient) rewriteHostedContents(ctx context.Context, message ChatMessage, format ExportFormat, dir string) (string, error) {
+	if !strings.EqualFold(message.Body.ContentType, "html") || (format == ExportFormatJSONLines && dir == "") {
+		return message.Body.Content, nil
+	}
+	var err error
+	var index int
+	content := hostedContentSrc.ReplaceAllStringFunc(message.Body.Content, func(src string) string {
+		if err != nil {
+			return src
+		}
+		var data []byte
+		if err = g.makeNextLinkAPICall(ctx, hostedContentSrc.FindStringSubmatch(src)[1], &data); err != nil {
+			err = fmt.Errorf("cannot download hosted content of message %v: %w", message.ID, err)
+			return src
+		}
+		contentType := http.DetectContentType(data)
+		if format == ExportFormatHTML {
+			return fmt.Sprintf(`src="data:%v;base64,%v"`, contentType, base64.StdEncoding.EncodeToString(data))
+		}
+		index++
+		name := fmt.Sprintf("%v-%v", message.ID, index)
+		if extensions, _ := mime.ExtensionsByType(contentType); len(extensions) > 0 {
+			name += extensions[0]
+		}
+		if err = ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return src
+		}
+		return fmt.Sprintf(`src="%v"`, name)
+	})
+	return content, err
+}
+
+// chatMessageHTML renders the message as a section of the HTML transcript
+func chatMessageHTML(message ChatMessage) string {
+	class := "message"
+	if message.ReplyToID != "" {
+		class += " reply"
+	}
+	meta := fmt.Sprintf("%v, %v", html.EscapeString(message.From.DisplayName()), message.CreatedDateTime.Format(time.RFC3339))
+	if message.LastEditedDateTime != nil {
+		meta += fmt.Sprintf(", edited %v", message.LastEditedDateTime.Format(time.RFC3339))
+	}
+	body := message.Body.Content
+	if !strings.EqualFold(message.Body.ContentType, "html") {
+		body = html.EscapeString(body)
+	}
+	if message.DeletedDateTime != nil {
+		meta += fmt.Sprintf(", deleted %v", message.DeletedDateTime.Format(time.RFC3339))
+		body = "<em>This message has been deleted.</em>"
+	}
+	if message.Subject != "" {
+		body = fmt.Sprintf("<h3>%v</h3>%v", html.EscapeString(message.Subject), body)
+	}
+	for _, attachment := range message.Attachments {
+		if attachment.ContentURL != "" {
+			body += fmt.Sprintf("<p>Attachment: <a href=\"%v\">%v</a></p>", html.EscapeString(attachment.ContentURL), html.EscapeString(attachment.Name))
+		}
+	}
+	return fmt.Sprintf("<div class=\"%v\" id=\"%v\">\n<p class=\"meta\">%v</p>\n<div class=\"body\">%v</div>\n</div>\n",
+		class, html.EscapeString(message.ID), meta, body)
+}
@@ -0,0 +1,104 @@
+package msgraph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPNG is the signature of a PNG file, enough for http.DetectContentType
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+func newTestChatExportClient(t *testing.T) *GraphClient {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/teams/t1/channels/c1/messages", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$top") != "50" {
+			t.Errorf("channel messages requested with $top=%v, want 50", r.URL.Query().Get("$top"))
+		}
+		fmt.Fprint(w, `{"value": [
+			{"id": "1700000002000", "messageType": "message", "createdDateTime": "2023-11-14T22:13:20Z", "lastModifiedDateTime": "2023-11-15T08:00:00Z",
+			 "from": {"user": {"id": "u2", "displayName": "Bob"}}, "body": {"contentType": "html",
+			 "content": "<p>See <img src=\"https://graph.microsoft.com/v1.0/teams/t1/channels/c1/messages/1700000002000/hostedContents/aW1n/$value\"></p>"}},
+			{"id": "1600000000000", "messageType": "message", "createdDateTime": "2020-09-13T12:26:40Z", "lastModifiedDateTime": "2020-09-13T12:26:40Z",
+			 "from": {"user": {"id": "u1", "displayName": "Alice"}}, "body": {"contentType": "text", "content": "too old"}}]}`)
+	})
+	mux.HandleFunc("/v1.0/teams/t1/channels/c1/messages/1700000002000/replies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"id": "1700000004000", "replyToId": "1700000002000", "messageType": "message", "createdDateTime": "2023-11-14T22:13:24Z",
+			 "lastModifiedDateTime": "2023-11-15T09:00:00Z", "deletedDateTime": "2023-11-15T09:00:00Z", "from": null, "body": {"contentType": "html", "content": ""}},
+			{"id": "1700000003000", "replyToId": "1700000002000", "messageType": "message", "createdDateTime": "2023-11-14T22:13:23Z",
+			 "lastModifiedDateTime": "2023-11-15T08:30:00Z", "lastEditedDateTime": "2023-11-15T08:30:00Z",
+			 "from": {"user": {"id": "u1", "displayName": "Alice"}}, "body": {"contentType": "text", "content": "a <b> c"}}]}`)
+	})
+	mux.HandleFunc("/v1.0/teams/t1/channels/c1/messages/1700000002000/hostedContents/aW1n/$value", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(testPNG)
+	})
+	return newTestGraphClient(t, mux)
+}
+
+func TestGraphClient_ExportChannelConversation(t *testing.T) {
+	g := newTestChatExportClient(t)
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	dir := t.TempDir()
+	var jsonLines bytes.Buffer
+	var progress []string
+	err := g.ExportChannelConversation("t1", "c1", since, &jsonLines, ExportFormatJSONLines,
+		ExportHostedContentDir(dir), ExportProgress(func(id string) { progress = append(progress, id) }))
+	if err != nil {
+		t.Fatalf("GraphClient.ExportChannelConversation() error = %v", err)
+	}
+	var messages []ChatMessage
+	scanner := bufio.NewScanner(&jsonLines)
+	for scanner.Scan() {
+		var message ChatMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatalf("cannot unmarshal exported line %v: %v", scanner.Text(), err)
+		}
+		messages = append(messages, message)
+	}
+	if fmt.Sprint(progress) != "[1700000002000 1700000003000 1700000004000]" || len(messages) != 3 {
+		t.Fatalf("GraphClient.ExportChannelConversation() exported %v, want the thread of 1700000002000 oldest first", progress)
+	}
+	if !strings.Contains(messages[0].Body.Content, `src="1700000002000-1.png"`) {
+		t.Errorf("exported body = %v, want the image to reference the saved file", messages[0].Body.Content)
+	}
+	if saved, err := ioutil.ReadFile(filepath.Join(dir, "1700000002000-1.png")); err != nil || !bytes.Equal(saved, testPNG) {
+		t.Errorf("saved hosted content = %q, %v, want %q", saved, err, testPNG)
+	}
+	if messages[1].LastEditedDateTime == nil || messages[2].DeletedDateTime == nil {
+		t.Errorf("exported messages %v, want the edited and deleted reply to be marked", messages[1:])
+	}
+
+	var transcript bytes.Buffer
+	if err := g.ExportChannelConversation("t1", "c1", since, &transcript, ExportFormatHTML, ExportResumeAfter("1700000002000")); err != nil {
+		t.Fatalf("GraphClient.ExportChannelConversation() to HTML error = %v", err)
+	}
+	for _, want := range []string{"a &lt;b&gt; c", "This message has been deleted.", "</html>"} {
+		if !strings.Contains(transcript.String(), want) {
+			t.Errorf("HTML transcript does not contain %q:\n%v", want, transcript.String())
+		}
+	}
+	if strings.Contains(transcript.String(), `id="1700000002000"`) {
+		t.Errorf("HTML transcript contains the message it was resumed after")
+	}
+
+	transcript.Reset()
+	if err := g.ExportChannelConversation("t1", "c1", since, &transcript, ExportFormatHTML); err != nil {
+		t.Fatalf("GraphClient.ExportChannelConversation() to HTML error = %v", err)
+	}
+	if !strings.Contains(transcript.String(), `src="data:image/png;base64,`) {
+		t.Errorf("HTML transcript does not embed the inline image:\n%v", transcript.String())
+	}
+
+	if err := g.ExportChannelConversation("t1", "c1", since, &transcript, ExportFormatHTML, ExportResumeAfter("42")); err == nil {
+		t.Errorf("GraphClient.ExportChannelConversation() resuming after an unknown message error = nil, want an error")
+	}
+}
@@ -0,0 +1,89 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// chatMessagePageSize is the maximum page size of chat and channel messages, they do not support MaxPageSize
+const chatMessagePageSize = 50
+
+// ChatMessage represents a message of a Teams chat or channel. Deleted messages are still returned by msgraph,
+// with DeletedDateTime set and an empty Body.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/chatmessage
+type ChatMessage struct {
+	ID                   string                  `json:"id"`
+	ReplyToID            string                  `json:"replyToId,omitempty"` // the ID of the root message of the thread, empty for root messages
+	MessageType          string                  `json:"messageType"`         // e.g. message or systemEventMessage
+	CreatedDateTime      time.Time               `json:"createdDateTime"`
+	LastModifiedDateTime time.Time               `json:"lastModifiedDateTime"`
+	LastEditedDateTime   *time.Time              `json:"lastEditedDateTime,omitempty"` // nil if the message has never been edited
+	DeletedDateTime      *time.Time              `json:"deletedDateTime,omitempty"`    // nil if the message has not been deleted
+	Subject              string                  `json:"subject,omitempty"`
+	From                 ChatMessageFrom         `json:"from"`
+	Body                 MsgBody                 `json:"body"`
+	Attachments          []ChatMessageAttachment `json:"attachments,omitempty"`
+}
+
+func (m ChatMessage) String() string {
+	return fmt.Sprintf("ChatMessage(ID: \"%v\", ReplyToID: \"%v\", MessageType: \"%v\", CreatedDateTime: \"%v\", From: \"%v\", "+
+		"Edited: %v, Deleted: %v, Attachments: %v)",
+		m.ID, m.ReplyToID, m.MessageType, m.CreatedDateTime, m.From.DisplayName(), m.LastEditedDateTime != nil, m.DeletedDateTime != nil, len(m.Attachments))
+}
+
+// ChatMessageFrom is the sender of a ChatMessage, either a user or an application. Both are nil for system messages.
+type ChatMessageFrom struct {
+	User        *ChatIdentity `json:"user,omitempty"`
+	Application *ChatIdentity `json:"application,omitempty"`
+}
+
+// DisplayName returns the display name of the user or application that sent the message, empty for system messages
+func (f ChatMessageFrom) DisplayName() string {
+	switch {
+	case f.User != nil:
+		return f.User.DisplayName
+	case f.Application != nil:
+		return f.Application.DisplayName
+	}
+	return ""
+}
+
+// ChatIdentity identifies a user or an application in a chat
+type ChatIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// ChatMessageAttachment is a file, card or message reference attached to a ChatMessage. Files are not stored in the
+// message itself, ContentURL references them in SharePoint or OneDrive.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/chatmessageattachment
+type ChatMessageAttachment struct {
+	ID          string `json:"id"`
+	ContentType string `json:"contentType"` // e.g. reference for files
+	ContentURL  string `json:"contentUrl,omitempty"`
+	Content     string `json:"content,omitempty"` // e.g. the JSON of an adaptive card
+	Name        string `json:"name,omitempty"`
+}
+
+// listChatMessages returns all messages of the given resource, e.g. /chats/{id}/messages, sorted by CreatedDateTime
+func (g *GraphClient) listChatMessages(ctx context.Context, resource string, getParams url.Values) ([]ChatMessage, error) {
+	if getParams == nil {
+		getParams = url.Values{}
+	}
+	getParams.Set("$top", fmt.Sprint(chatMessagePageSize))
+	var messages []ChatMessage
+	err := g.makePagedGETAPICall(ctx, resource, getParams, func(value json.RawMessage) (bool, error) {
+		var page []ChatMessage
+		err := json.Unmarshal(value, &page)
+		messages = append(messages, page...)
+		return true, err
+	})
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].CreatedDateTime.Before(messages[j].CreatedDateTime) })
+	return messages, err
+}
@@ -0,0 +1,73 @@
+package msgraph
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// clientAssertionType is the client_assertion_type of a token request authenticated with a certificate
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// clientAssertionLifetime is how long a client assertion is valid, it is only used for a single token request
+const clientAssertionLifetime = 10 * time.Minute
+
+// NewGraphClientWithCertificate creates a new GraphClient instance that authenticates the application with the given
+// certificate instead of a client secret, e.g. for tenants that forbid client secrets. The certificate has to be
+// uploaded to the app registration, the key is its RSA private key. The opts are applied as for NewGraphClient.
+//
+// See https://docs.microsoft.com/en-us/azure/active-directory/develop/active-directory-certificate-credentials
+func NewGraphClientWithCertificate(tenantID, applicationID string, cert *x509.Certificate, key *rsa.PrivateKey, opts ...ClientOption) (*GraphClient, error) {
+	withCertificate := func(g *GraphClient) error {
+		if cert == nil {
+			return fmt.Errorf("certificate must not be nil")
+		}
+		if key == nil {
+			return fmt.Errorf("private key must not be nil")
+		}
+		g.certificate = cert
+		g.privateKey = key
+		return nil
+	}
+	return NewGraphClient(tenantID, applicationID, "", append([]ClientOption{withCertificate}, opts...)...)
+}
+
+// clientAssertion returns a JWT signed with the private key of the certificate of g, which authenticates the
+// application at the given token endpoint. The x5t header is the SHA-1 thumbprint of the certificate.
+func (g *GraphClient) clientAssertion(tokenEndpoint string) (string, error) {
+	thumbprint := sha1.Sum(g.certificate.Raw)
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	})
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": tokenEndpoint,
+		"iss": g.ApplicationID,
+		"sub": g.ApplicationID,
+		"jti": NewRequestID(),
+		"nbf": now.Unix(),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(nil, g.privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("cannot sign client assertion: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
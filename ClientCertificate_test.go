@@ -0,0 +1,92 @@
+package msgraph
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewGraphClientWithCertificate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "go-msgraph"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() error = %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	var forms []url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		forms = append(forms, r.PostForm)
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "access_token": "%v"}`,
+			time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix(), testAppToken)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClientWithCertificate("tenant", "app", cert, key)
+	if err != nil {
+		t.Fatalf("NewGraphClientWithCertificate() error = %v", err)
+	}
+	g.tokenLock.Lock()
+	err = g.refreshToken(context.Background())
+	g.tokenLock.Unlock()
+	if err != nil || len(forms) != 2 {
+		t.Fatalf("GraphClient.refreshToken() error = %v after %v token requests, want 2", err, len(forms))
+	}
+
+	form := forms[0]
+	if form.Get("client_secret") != "" || form.Get("client_assertion_type") != clientAssertionType {
+		t.Errorf("token request = %v, want a client assertion instead of a client secret", form)
+	}
+	parts := strings.Split(form.Get("client_assertion"), ".")
+	if len(parts) != 3 {
+		t.Fatalf("client_assertion = %v, want a JWT", form.Get("client_assertion"))
+	}
+	var header map[string]string
+	var claims map[string]interface{}
+	headerJSON, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	json.Unmarshal(headerJSON, &header)
+	json.Unmarshal(claimsJSON, &claims)
+	thumbprint := sha1.Sum(der)
+	if header["alg"] != "RS256" || header["x5t"] != base64.RawURLEncoding.EncodeToString(thumbprint[:]) {
+		t.Errorf("client assertion header = %v, want RS256 with the x5t thumbprint of the certificate", header)
+	}
+	exp, _ := claims["exp"].(float64)
+	if claims["aud"] != "https://login.microsoftonline.com/tenant/oauth2/token" || claims["iss"] != "app" || claims["sub"] != "app" ||
+		claims["jti"] == "" || claims["nbf"] == nil || time.Until(time.Unix(int64(exp), 0)) <= 0 {
+		t.Errorf("client assertion claims = %v, want the token endpoint as aud and the application as iss and sub", claims)
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature); err != nil {
+		t.Errorf("client assertion signature error = %v", err)
+	}
+	if forms[1].Get("client_assertion") == form.Get("client_assertion") {
+		t.Errorf("client assertion of the token refresh = the first one, want a new assertion for every token request")
+	}
+
+	if _, err := NewGraphClientWithCertificate("tenant", "app", cert, nil); err == nil {
+		t.Errorf("NewGraphClientWithCertificate() without key error = nil, want an error")
+	}
+}
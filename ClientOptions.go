@@ -0,0 +1,139 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ClientOption configures a GraphClient, see NewGraphClient
+type ClientOption func(*GraphClient) error
+
+// RequireRoles makes NewGraphClient fail with ErrMissingRoles if the acquired token is not granted all the given
+// permissions, e.g. RequireRoles("User.Read.All", "Mail.Send"). The error lists the missing ones, hence missing
+// application permissions are detected at startup rather than by a 403 of some later API-call.
+func RequireRoles(roles ...string) ClientOption {
+	return func(g *GraphClient) error {
+		g.requiredRoles = append(g.requiredRoles, roles...)
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every http request of the GraphClient, defaults to 10 seconds. It overrides the
+// Timeout of the http.Client of WithHTTPClient. A single API-call is limited further by a context with a deadline,
+// e.g. passed to GetUserContext. The error of a request that timed out says so and contains its URL without the query.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(g *GraphClient) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be positive, got %v", timeout)
+		}
+		g.timeout = timeout
+		return nil
+	}
+}
+
+// WithHTTPClient makes the GraphClient perform all http requests, including the token acquisition, with the given
+// http.Client, e.g. to use a proxy, custom TLS roots or connection limits. Without it a shared http.Client with a
+// timeout of 10 seconds is used.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(g *GraphClient) error {
+		if httpClient == nil {
+			return fmt.Errorf("http client must not be nil")
+		}
+		g.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithMaxRetries makes the GraphClient retry an API-call up to maxRetries times if msgraph throttles it with
+// StatusCode 429 or fails with a 5xx StatusCode. It waits as long as requested by the Retry-After header of the
+// response, or with an exponential backoff if there is none. Other errors are not retried, defaults to 0 retries.
+// A POST, which is not idempotent, is only retried on a 5xx StatusCode if it is 503 with a Retry-After header,
+// see RetryServerErrors.
+func WithMaxRetries(maxRetries int) ClientOption {
+	return func(g *GraphClient) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("max retries must not be negative, got %v", maxRetries)
+		}
+		g.maxRetries = maxRetries
+		return nil
+	}
+}
+
+// WithTokenResource sets the resource the token of the GraphClient is requested for, defaults to the BaseURL of
+// its cloud, see WithCloud.
+func WithTokenResource(resource string) ClientOption {
+	return func(g *GraphClient) error {
+		if resource == "" {
+			return fmt.Errorf("token resource must not be empty")
+		}
+		g.tokenResource = resource
+		return nil
+	}
+}
+
+// WithTokenScopes adds the given scopes to the scope parameter of the token request, e.g. "openid" or the scopes of
+// a further resource. It cannot be combined with WithTokenEndpointV2.
+func WithTokenScopes(scopes ...string) ClientOption {
+	return func(g *GraphClient) error {
+		g.tokenScopes = append(g.tokenScopes, scopes...)
+		return nil
+	}
+}
+
+// WithCloud makes the GraphClient acquire its token from and perform its API-calls against the given cloud, e.g.
+// CloudUSGov for GCC High tenants. Defaults to CloudPublic.
+func WithCloud(cloud CloudEndpoints) ClientOption {
+	return func(g *GraphClient) error {
+		if cloud.LoginBaseURL == "" || cloud.BaseURL == "" {
+			return fmt.Errorf("cloud endpoints must not be empty, got %v", cloud)
+		}
+		g.cloud = cloud
+		return nil
+	}
+}
+
+// WithTokenEndpointV2 makes the GraphClient acquire its token from the v2.0 endpoint of Azure AD
+// (/oauth2/v2.0/token) instead of the v1.0 endpoint, e.g. if the tenant restricts the latter. The token is
+// requested with the .default scope of the resource, see WithTokenResource. The client credentials flow of the v2.0
+// endpoint allows no further scopes, hence the GraphClient cannot be created if WithTokenScopes is given too.
+func WithTokenEndpointV2() ClientOption {
+	return func(g *GraphClient) error {
+		g.tokenEndpointV2 = true
+		return nil
+	}
+}
+
+// WithTokenRefreshMargin makes the GraphClient refresh its token the given margin before it expires instead of 10
+// seconds before, e.g. 5 minutes for long batch jobs. Returns an error if the margin is negative.
+func WithTokenRefreshMargin(margin time.Duration) ClientOption {
+	return func(g *GraphClient) error {
+		if margin < 0 {
+			return fmt.Errorf("token refresh margin must not be negative: %v", margin)
+		}
+		g.tokenRefreshMargin = margin
+		return nil
+	}
+}
+
+// OnTokenRefresh makes the GraphClient call callback with every new token, e.g. to log its ExpiresOn or to persist
+// it. The callback is called while the API-calls of the GraphClient wait for the token, hence it must not call them.
+func OnTokenRefresh(callback func(Token)) ClientOption {
+	return func(g *GraphClient) error {
+		g.onTokenRefresh = callback
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits the http requests the GraphClient performs at the same time to n, e.g. to stay
+// below the throttling limits of msgraph when many goroutines use the same GraphClient. Further API-calls wait for
+// a running one to finish. Clones of the GraphClient share the limit. Returns an error if n is lower than 1.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(g *GraphClient) error {
+		if n < 1 {
+			return fmt.Errorf("max concurrent requests must be at least 1: %v", n)
+		}
+		g.requests = make(chan struct{}, n)
+		return nil
+	}
+}
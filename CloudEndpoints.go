@@ -0,0 +1,30 @@
+package msgraph
+
+import "fmt"
+
+// CloudEndpoints are the endpoints of a Microsoft cloud, i.e. where the token is acquired and where the msgraph
+// API is hosted. Use WithCloud to make a GraphClient use a national cloud instead of CloudPublic.
+//
+// See https://docs.microsoft.com/en-us/graph/deployments
+type CloudEndpoints struct {
+	LoginBaseURL string // the Azure AD authority, e.g. https://login.microsoftonline.us
+	BaseURL      string // the msgraph endpoint, e.g. https://graph.microsoft.us. It is also the resource of the token
+}
+
+func (c CloudEndpoints) String() string {
+	return fmt.Sprintf("CloudEndpoints(LoginBaseURL: \"%v\", BaseURL: \"%v\")", c.LoginBaseURL, c.BaseURL)
+}
+
+// Endpoints of the Microsoft clouds
+var (
+	// CloudPublic is the worldwide cloud, the default of every GraphClient
+	CloudPublic = CloudEndpoints{LoginBaseURL: LoginBaseURL, BaseURL: BaseURL}
+	// CloudUSGov is Microsoft Graph for US Government L4, i.e. GCC High
+	CloudUSGov = CloudEndpoints{LoginBaseURL: "https://login.microsoftonline.us", BaseURL: "https://graph.microsoft.us"}
+	// CloudUSGovDoD is Microsoft Graph for US Government L5, i.e. DoD
+	CloudUSGovDoD = CloudEndpoints{LoginBaseURL: "https://login.microsoftonline.us", BaseURL: "https://dod-graph.microsoft.us"}
+	// CloudGermany is Microsoft Graph Germany
+	CloudGermany = CloudEndpoints{LoginBaseURL: "https://login.microsoftonline.de", BaseURL: "https://graph.microsoft.de"}
+	// CloudChina is Microsoft Graph China operated by 21Vianet
+	CloudChina = CloudEndpoints{LoginBaseURL: "https://login.chinacloudapi.cn", BaseURL: "https://microsoftgraph.chinacloudapi.cn"}
+)
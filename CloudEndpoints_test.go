@@ -0,0 +1,48 @@
+package msgraph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewGraphClient_WithCloud(t *testing.T) {
+	var requests []string
+	var resource string
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Host+req.URL.Path)
+		body := `{"value": [{"id": "u1"}], "@odata.nextLink": "https://graph.microsoft.us/v1.0/users?$skiptoken=2"}`
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/oauth2/token"):
+			req.ParseForm()
+			resource = req.PostForm.Get("resource")
+			body = fmt.Sprintf(`{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "access_token": "%v"}`,
+				time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix(), testAppToken)
+		case req.URL.Query().Get("$skiptoken") != "":
+			body = `{"value": [{"id": "u2"}]}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})}
+
+	g, err := NewGraphClient("tenant", "app", "secret", WithCloud(CloudUSGov), WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("NewGraphClient() error = %v", err)
+	}
+	if users, err := g.ListUsers(); err != nil || len(users) != 2 {
+		t.Fatalf("GraphClient.ListUsers() = %v, %v, want 2 users", users, err)
+	}
+	want := "[login.microsoftonline.us/tenant/oauth2/token graph.microsoft.us/v1.0/users graph.microsoft.us/v1.0/users]"
+	if fmt.Sprint(requests) != want {
+		t.Errorf("requests of the http.Client = %v, want %v", requests, want)
+	}
+	if resource != CloudUSGov.BaseURL {
+		t.Errorf("token request resource = %v, want %v", resource, CloudUSGov.BaseURL)
+	}
+
+	if _, err := NewGraphClient("tenant", "app", "secret", WithCloud(CloudEndpoints{})); err == nil {
+		t.Errorf("NewGraphClient(WithCloud(CloudEndpoints{})) error = nil, want an error")
+	}
+}
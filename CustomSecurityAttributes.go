@@ -0,0 +1,188 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// odataTypeCustomSecurityAttributeValue is the @odata.type of an attribute set within customSecurityAttributes
+const odataTypeCustomSecurityAttributeValue = "#Microsoft.DirectoryServices.CustomSecurityAttributeValue"
+
+// customSecurityAttributeNameRegex matches valid attribute set and attribute names: up to 32
+// characters, letters and numbers only, no spaces or special characters
+var customSecurityAttributeNameRegex = regexp.MustCompile(`^[A-Za-z0-9]{1,32}$`)
+
+// CustomSecurityAttributes represents the customSecurityAttributes of a user. The key is the name of
+// the attribute set, e.g. "Engineering", the value is the AttributeSet that contains the attribute values.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/customsecurityattributevalue
+type CustomSecurityAttributes map[string]AttributeSet
+
+// AttributeSet represents the attribute values of one attribute set. The key is the name of the
+// attribute, e.g. "Project". Supported values are string, bool, int, []string and []int.
+type AttributeSet map[string]interface{}
+
+// Get returns the value of the given attribute within the given attribute set. The second return
+// value is false if the attribute is not set.
+func (c CustomSecurityAttributes) Get(attributeSet, attribute string) (interface{}, bool) {
+	value, ok := c[attributeSet][attribute]
+	return value, ok
+}
+
+// Set sets the value of the given attribute within the given attribute set. The attribute set
+// will be created if it does not exist yet.
+func (c CustomSecurityAttributes) Set(attributeSet, attribute string, value interface{}) {
+	if c[attributeSet] == nil {
+		c[attributeSet] = AttributeSet{}
+	}
+	c[attributeSet][attribute] = value
+}
+
+// Validate checks the names of all attribute sets and attributes as well as the types of all values
+func (c CustomSecurityAttributes) Validate() error {
+	for setName, attributeSet := range c {
+		if !customSecurityAttributeNameRegex.MatchString(setName) {
+			return fmt.Errorf("invalid attribute set name %q: only letters and numbers, up to 32 characters", setName)
+		}
+		for name, value := range attributeSet {
+			if !customSecurityAttributeNameRegex.MatchString(name) {
+				return fmt.Errorf("invalid attribute name %q in attribute set %q: only letters and numbers, up to 32 characters", name, setName)
+			}
+			switch value.(type) {
+			case string, bool, int, []string, []int:
+			default:
+				return fmt.Errorf("unsupported type %T of attribute %v.%v", value, setName, name)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library. The @odata.type
+// annotations required by msgraph are added for every attribute set, integer and collection.
+func (a AttributeSet) MarshalJSON() ([]byte, error) {
+	tmp := map[string]interface{}{"@odata.type": odataTypeCustomSecurityAttributeValue}
+	for name, value := range a {
+		switch value.(type) {
+		case int:
+			tmp[name+"@odata.type"] = "#Int32"
+		case []string:
+			tmp[name+"@odata.type"] = "#Collection(String)"
+		case []int:
+			tmp[name+"@odata.type"] = "#Collection(Int32)"
+		}
+		tmp[name] = value
+	}
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library. The @odata.type
+// annotations are used to restore integers and collections and are not part of the AttributeSet.
+func (a *AttributeSet) UnmarshalJSON(data []byte) error {
+	tmp := map[string]interface{}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	*a = AttributeSet{}
+	for name, value := range tmp {
+		if strings.Contains(name, "@odata.") {
+			continue
+		}
+		annotation, _ := tmp[name+"@odata.type"].(string)
+		switch v := value.(type) {
+		case float64:
+			(*a)[name] = int(v)
+		case []interface{}:
+			if annotation == "#Collection(Int32)" {
+				ints := make([]int, len(v))
+				for i, item := range v {
+					number, _ := item.(float64)
+					ints[i] = int(number)
+				}
+				(*a)[name] = ints
+			} else {
+				strs := make([]string, len(v))
+				for i, item := range v {
+					strs[i] = fmt.Sprint(item)
+				}
+				(*a)[name] = strs
+			}
+		default:
+			(*a)[name] = v
+		}
+	}
+	return nil
+}
+
+// GetUserCustomSecurityAttributes returns the customSecurityAttributes of the user identified by either
+// the given ID or userPrincipalName.
+//
+// Hint: reading custom security attributes requires the CustomSecAttributeAssignment.Read.All permission
+// and the application must have the Attribute Assignment Reader role. Without the role msgraph returns
+// an empty result or ErrCustomSecurityAttributesPermission.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/custom-security-attributes-examples
+func (g *GraphClient) GetUserCustomSecurityAttributes(identifier string) (CustomSecurityAttributes, error) {
+	return g.GetUserCustomSecurityAttributesContext(context.Background(), identifier)
+}
+
+// GetUserCustomSecurityAttributesContext is GetUserCustomSecurityAttributes with a context.
+func (g *GraphClient) GetUserCustomSecurityAttributesContext(ctx context.Context, identifier string) (CustomSecurityAttributes, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	resource := fmt.Sprintf("/users/%v", identifier)
+	getParams := url.Values{}
+	getParams.Add("$select", "customSecurityAttributes")
+
+	var marsh struct {
+		CustomSecurityAttributes CustomSecurityAttributes `json:"customSecurityAttributes"`
+	}
+	err := g.makeGETAPICall(ctx, resource, getParams, &marsh)
+	if hasStatusCode(err, http.StatusForbidden) {
+		return nil, &sentinelError{sentinel: ErrCustomSecurityAttributesPermission, err: err}
+	}
+	if marsh.CustomSecurityAttributes == nil {
+		marsh.CustomSecurityAttributes = CustomSecurityAttributes{}
+	}
+	return marsh.CustomSecurityAttributes, err
+}
+
+// UpdateUserCustomSecurityAttributes sets the given customSecurityAttributes of the user identified by
+// either the given ID or userPrincipalName. Attribute sets and attributes that are not part of the given
+// attributes stay untouched. The names and values are validated before the API-call is performed.
+//
+// Hint: writing custom security attributes requires the CustomSecAttributeAssignment.ReadWrite.All permission
+// and the application must have the Attribute Assignment Administrator role, otherwise
+// ErrCustomSecurityAttributesPermission is returned.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/custom-security-attributes-examples
+func (g *GraphClient) UpdateUserCustomSecurityAttributes(identifier string, attributes CustomSecurityAttributes) error {
+	return g.UpdateUserCustomSecurityAttributesContext(context.Background(), identifier, attributes)
+}
+
+// UpdateUserCustomSecurityAttributesContext is UpdateUserCustomSecurityAttributes with a context.
+func (g *GraphClient) UpdateUserCustomSecurityAttributesContext(ctx context.Context, identifier string, attributes CustomSecurityAttributes) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	if err := attributes.Validate(); err != nil {
+		return err
+	}
+	resource := fmt.Sprintf("/users/%v", identifier)
+	body := struct {
+		CustomSecurityAttributes CustomSecurityAttributes `json:"customSecurityAttributes"`
+	}{CustomSecurityAttributes: attributes}
+
+	err := g.makePATCHAPICall(ctx, resource, body, nil)
+	if hasStatusCode(err, http.StatusForbidden) {
+		return &sentinelError{sentinel: ErrCustomSecurityAttributesPermission, err: err}
+	}
+	return err
+}
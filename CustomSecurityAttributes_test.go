@@ -0,0 +1,121 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCustomSecurityAttributes_UnmarshalJSON(t *testing.T) {
+	data := []byte(`{
+		"Engineering": {
+			"@odata.type": "#Microsoft.DirectoryServices.CustomSecurityAttributeValue",
+			"Project@odata.type": "#Collection(String)",
+			"Project": ["Baker", "Cascade"],
+			"CostCenter@odata.type": "#Collection(Int32)",
+			"CostCenter": [1001, 1002],
+			"Level@odata.type": "#Int32",
+			"Level": 2,
+			"Certification": true,
+			"Sensitivity": "Confidential"
+		}}`)
+	want := CustomSecurityAttributes{"Engineering": AttributeSet{
+		"Project":       []string{"Baker", "Cascade"},
+		"CostCenter":    []int{1001, 1002},
+		"Level":         2,
+		"Certification": true,
+		"Sensitivity":   "Confidential",
+	}}
+
+	var got CustomSecurityAttributes
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, want)
+	}
+}
+
+func TestAttributeSet_MarshalJSON(t *testing.T) {
+	attributes := CustomSecurityAttributes{}
+	attributes.Set("Engineering", "Project", []string{"Baker"})
+	attributes.Set("Engineering", "Level", 2)
+	attributes.Set("Engineering", "Sensitivity", "Confidential")
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := map[string]map[string]interface{}{"Engineering": {
+		"@odata.type":        odataTypeCustomSecurityAttributeValue,
+		"Project@odata.type": "#Collection(String)",
+		"Project":            []interface{}{"Baker"},
+		"Level@odata.type":   "#Int32",
+		"Level":              float64(2),
+		"Sensitivity":        "Confidential",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Marshal() = %v, want %v", got, want)
+	}
+}
+
+func TestCustomSecurityAttributes_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       CustomSecurityAttributes
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			c:       CustomSecurityAttributes{"Engineering": AttributeSet{"Project": "Baker", "Level": 2}},
+			wantErr: false,
+		}, {
+			name:    "invalid attribute set name",
+			c:       CustomSecurityAttributes{"Data Classification": AttributeSet{"Level": "Secret"}},
+			wantErr: true,
+		}, {
+			name:    "invalid attribute name",
+			c:       CustomSecurityAttributes{"Engineering": AttributeSet{"Project-Name": "Baker"}},
+			wantErr: true,
+		}, {
+			name:    "unsupported value type",
+			c:       CustomSecurityAttributes{"Engineering": AttributeSet{"Level": 2.5}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CustomSecurityAttributes.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGraphClient_UpdateUserCustomSecurityAttributes(t *testing.T) {
+	g := newTestGraphClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1.0/users/test@contoso.com" {
+			t.Errorf("unexpected request %v %v: %v", r.Method, r.URL.Path, string(body))
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}}`)
+	}))
+	attributes := CustomSecurityAttributes{"Engineering": AttributeSet{"Project": "Baker"}}
+	err := g.UpdateUserCustomSecurityAttributes("test@contoso.com", attributes)
+	if !errors.Is(err, ErrCustomSecurityAttributesPermission) {
+		t.Errorf("GraphClient.UpdateUserCustomSecurityAttributes() error = %v, want %v", err, ErrCustomSecurityAttributesPermission)
+	}
+	var gerr *GraphError
+	if !errors.Is(err, ErrForbidden) || !errors.As(err, &gerr) || gerr.Code != "Authorization_RequestDenied" {
+		t.Errorf("GraphClient.UpdateUserCustomSecurityAttributes() error = %v, want it to wrap the GraphError", err)
+	}
+}
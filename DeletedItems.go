@@ -0,0 +1,52 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListDeletedUsers returns the users that have been deleted within the last 30 days, e.g. by DeleteUser. They can
+// be restored with RestoreDeletedUser until they are deleted permanently.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/directory-deleteditems-list
+func (g *GraphClient) ListDeletedUsers() (Users, error) {
+	return g.ListDeletedUsersContext(context.Background())
+}
+
+// ListDeletedUsersContext is ListDeletedUsers with a context.
+func (g *GraphClient) ListDeletedUsersContext(ctx context.Context) (Users, error) {
+	var marsh struct {
+		Users Users `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, "/directory/deletedItems/microsoft.graph.user", nil, &marsh)
+	marsh.Users.setGraphClient(g)
+	return marsh.Users, err
+}
+
+// RestoreDeletedUser restores the deleted user identified by the given ID and returns it. The userPrincipalName
+// cannot be used, as it may have been reused by another user meanwhile.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/directory-deleteditems-restore
+func (g *GraphClient) RestoreDeletedUser(id string) (User, error) {
+	return g.RestoreDeletedUserContext(context.Background(), id)
+}
+
+// RestoreDeletedUserContext is RestoreDeletedUser with a context.
+func (g *GraphClient) RestoreDeletedUserContext(ctx context.Context, id string) (User, error) {
+	user := User{graphClient: g}
+	err := g.makePOSTAPICall(ctx, fmt.Sprintf("/directory/deletedItems/%v/restore", id), nil, &user)
+	return user, err
+}
+
+// PermanentlyDeleteUser deletes the deleted user identified by the given ID permanently, it cannot be restored
+// afterwards
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/directory-deleteditems-delete
+func (g *GraphClient) PermanentlyDeleteUser(id string) error {
+	return g.PermanentlyDeleteUserContext(context.Background(), id)
+}
+
+// PermanentlyDeleteUserContext is PermanentlyDeleteUser with a context.
+func (g *GraphClient) PermanentlyDeleteUserContext(ctx context.Context, id string) error {
+	return g.makeDELETEAPICall(ctx, fmt.Sprintf("/directory/deletedItems/%v", id))
+}
@@ -0,0 +1,39 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_DeletedUsers(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/directory/deletedItems/microsoft.graph.user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "u1", "userPrincipalName": "1f2d3c4balice@contoso.com"}]}`)
+	})
+	mux.HandleFunc("/v1.0/directory/deletedItems/u1/restore", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{"id": "u1", "userPrincipalName": "alice@contoso.com"}`)
+	})
+	mux.HandleFunc("/v1.0/directory/deletedItems/u1", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	g := newTestGraphClient(t, mux)
+
+	deleted, err := g.ListDeletedUsers()
+	if err != nil || len(deleted) != 1 || deleted[0].ID != "u1" {
+		t.Fatalf("GraphClient.ListDeletedUsers() = %v, %v, want u1", deleted, err)
+	}
+	user, err := g.RestoreDeletedUser(deleted[0].ID)
+	if err != nil || user.UserPrincipalName != "alice@contoso.com" {
+		t.Errorf("GraphClient.RestoreDeletedUser() = %v, %v, want the restored user", user, err)
+	}
+	if err := g.PermanentlyDeleteUser("u1"); err != nil {
+		t.Errorf("GraphClient.PermanentlyDeleteUser() error = %v", err)
+	}
+	if want := "[POST /v1.0/directory/deletedItems/u1/restore DELETE /v1.0/directory/deletedItems/u1]"; fmt.Sprint(requests) != want {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
@@ -0,0 +1,44 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Device represents a device registered in the directory
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/device
+type Device struct {
+	ID                            string    `json:"id"`
+	DeviceID                      string    `json:"deviceId"` // Unique identifier set by Azure Device Registration Service at the time of registration.
+	DisplayName                   string    `json:"displayName"`
+	OperatingSystem               string    `json:"operatingSystem"`
+	OperatingSystemVersion        string    `json:"operatingSystemVersion"`
+	AccountEnabled                bool      `json:"accountEnabled"`
+	ApproximateLastSignInDateTime time.Time `json:"approximateLastSignInDateTime"`
+	IsCompliant                   bool      `json:"isCompliant"`
+	IsManaged                     bool      `json:"isManaged"`
+	TrustType                     string    `json:"trustType"` // e.g. Workplace, AzureAd or ServerAd
+}
+
+func (d Device) String() string {
+	return fmt.Sprintf("Device(ID: \"%v\", DeviceID: \"%v\", DisplayName: \"%v\", OperatingSystem: \"%v\", OperatingSystemVersion: \"%v\", "+
+		"AccountEnabled: \"%v\", ApproximateLastSignInDateTime: \"%v\", IsCompliant: \"%v\", IsManaged: \"%v\", TrustType: \"%v\")",
+		d.ID, d.DeviceID, d.DisplayName, d.OperatingSystem, d.OperatingSystemVersion, d.AccountEnabled,
+		d.ApproximateLastSignInDateTime, d.IsCompliant, d.IsManaged, d.TrustType)
+}
+
+// GetDevice returns the device with the given ID, which is the object ID of the device and not its DeviceID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/device-get
+func (g *GraphClient) GetDevice(id string) (Device, error) {
+	return g.GetDeviceContext(context.Background(), id)
+}
+
+// GetDeviceContext is GetDevice with a context.
+func (g *GraphClient) GetDeviceContext(ctx context.Context, id string) (Device, error) {
+	var device Device
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/devices/%v", id), nil, &device)
+	return device, err
+}
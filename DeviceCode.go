@@ -0,0 +1,142 @@
+package msgraph
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// deviceCodeGrantType is the grant_type of the token requests that poll for the sign-in of the device code flow
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// deviceCodePollInterval is the delay between the token requests of the device code flow if msgraph returns no
+// interval, the default of RFC 8628
+var deviceCodePollInterval = 5 * time.Second
+
+// deviceCode is the response of the devicecode endpoint, which starts the device code flow
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"` // seconds until the sign-in has to be completed
+	Interval        int    `json:"interval"`   // seconds to wait between the token requests
+}
+
+// NewGraphClientWithDeviceCode creates a new GraphClient instance with a delegated token of the user that signs in
+// with the device code flow, e.g. for the API-calls of GraphClient.Me. The clientID is the application ID of an app
+// registration that allows public client flows, the scopes are the delegated permissions to be requested, e.g.
+// "Mail.Send". promptFn has to show the userCode and the verificationURL to the user, who signs in on any device.
+// NewGraphClientWithDeviceCode returns after the sign-in, or with an error if it has been declined or has expired.
+//
+// The offline_access scope is always requested, its refresh token is used to refresh the token of the GraphClient
+// without another sign-in. The opts are applied as for NewGraphClient.
+//
+// See https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-device-code
+func NewGraphClientWithDeviceCode(tenantID, clientID string, scopes []string, promptFn func(userCode, verificationURL string), opts ...ClientOption) (*GraphClient, error) {
+	g := GraphClient{TenantID: tenantID, ApplicationID: clientID, delegatedScopes: []string{"offline_access"}}
+	for _, scope := range scopes {
+		if scope != "offline_access" {
+			g.delegatedScopes = append(g.delegatedScopes, scope)
+		}
+	}
+	for _, opt := range opts {
+		if err := opt(&g); err != nil {
+			return &g, err
+		}
+	}
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	if err := g.signInWithDeviceCode(context.Background(), promptFn); err != nil {
+		return &g, err
+	}
+	return &g, g.checkRequiredRoles()
+}
+
+// signInWithDeviceCode requests a device code, passes its user code to promptFn and polls the token endpoint until
+// the user has signed in
+func (g *GraphClient) signInWithDeviceCode(ctx context.Context, promptFn func(userCode, verificationURL string)) error {
+	if g.TenantID == "" {
+		return fmt.Errorf("tenant ID is empty")
+	}
+	data := url.Values{}
+	data.Add("client_id", g.ApplicationID)
+	data.Add("scope", strings.Join(g.delegatedScopes, " "))
+	var code deviceCode
+	if err := g.postTokenForm(ctx, fmt.Sprintf("/%v/oauth2/v2.0/devicecode", g.TenantID), data, &code); err != nil {
+		return fmt.Errorf("cannot request device code: %w", err)
+	}
+	promptFn(code.UserCode, code.VerificationURI)
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = deviceCodePollInterval
+	}
+	expires := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	data = url.Values{}
+	data.Add("grant_type", deviceCodeGrantType)
+	data.Add("client_id", g.ApplicationID)
+	data.Add("device_code", code.DeviceCode)
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		var newToken Token
+		err := g.postTokenForm(ctx, fmt.Sprintf("/%v/oauth2/v2.0/token", g.TenantID), data, &newToken)
+		switch {
+		case err == nil:
+			g.token = newToken
+			return nil
+		case strings.Contains(err.Error(), `"authorization_pending"`) && time.Now().Before(expires):
+			// the user has not signed in yet
+		case strings.Contains(err.Error(), `"slow_down"`):
+			interval += 5 * time.Second
+		default: // e.g. authorization_declined or expired_token
+			return fmt.Errorf("error on getting msgraph Token with device code: %w", err)
+		}
+	}
+}
+
+// refreshDelegatedToken acquires a new delegated token with the refresh token of the current one. The refresh
+// token of the new token replaces it, if msgraph returns one.
+func (g *GraphClient) refreshDelegatedToken(ctx context.Context) error {
+	data := url.Values{}
+	data.Add("grant_type", "refresh_token")
+	data.Add("client_id", g.ApplicationID)
+	data.Add("refresh_token", g.token.refreshToken)
+	data.Add("scope", strings.Join(g.delegatedScopes, " "))
+	if g.claims != "" {
+		data.Add("claims", g.claims)
+	}
+	var newToken Token
+	if err := g.postTokenForm(ctx, fmt.Sprintf("/%v/oauth2/v2.0/token", g.TenantID), data, &newToken); err != nil {
+		return fmt.Errorf("error on refreshing msgraph Token: %w", err)
+	}
+	if newToken.refreshToken == "" {
+		newToken.refreshToken = g.token.refreshToken
+	}
+	g.token = newToken
+	g.claims = "" // satisfied by the new token
+	return nil
+}
+
+// postTokenForm posts the form data to the given path of the login endpoint of the cloud of g and json-unmarshals
+// the response into v
+func (g *GraphClient) postTokenForm(ctx context.Context, path string, data url.Values, v interface{}) error {
+	u, err := url.ParseRequestURI(g.endpoints().LoginBaseURL)
+	if err != nil {
+		return fmt.Errorf("unable to parse URI: %v", err)
+	}
+	u.Path = path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("HTTP Request Error: %v", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return g.performRequest(req, v, requestOptions{})
+}
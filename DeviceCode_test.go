@@ -0,0 +1,65 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewGraphClientWithDeviceCode(t *testing.T) {
+	origInterval := deviceCodePollInterval
+	deviceCodePollInterval = time.Millisecond
+	defer func() { deviceCodePollInterval = origInterval }()
+
+	var forms []url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/v2.0/devicecode", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if got := r.PostForm.Get("scope"); got != "offline_access Mail.Send" {
+			t.Errorf("devicecode scope = %q, want offline_access Mail.Send", got)
+		}
+		fmt.Fprint(w, `{"device_code": "dc1", "user_code": "ABCD-EFGH", "verification_uri": "https://microsoft.com/devicelogin", "expires_in": 900}`)
+	})
+	mux.HandleFunc("/tenant/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		forms = append(forms, r.PostForm)
+		if len(forms) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "authorization_pending", "error_description": "AADSTS70016: pending"}`)
+			return
+		}
+		refreshToken := fmt.Sprintf(`, "refresh_token": "rt%v"`, len(forms))
+		if len(forms) == 3 {
+			refreshToken = "" // the refresh token has not been rotated
+		}
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_in": 3600, "access_token": "%v"%v}`, testAppToken, refreshToken)
+	})
+	newTestGraphClient(t, mux)
+
+	var prompted string
+	g, err := NewGraphClientWithDeviceCode("tenant", "public-client", []string{"Mail.Send"}, func(userCode, verificationURL string) {
+		prompted = userCode + " " + verificationURL
+	})
+	if err != nil || len(forms) != 2 {
+		t.Fatalf("NewGraphClientWithDeviceCode() error = %v after %v token requests, want 2", err, len(forms))
+	}
+	if prompted != "ABCD-EFGH https://microsoft.com/devicelogin" {
+		t.Errorf("promptFn() = %q, want the user code and the verification URL", prompted)
+	}
+	if form := forms[1]; form.Get("grant_type") != deviceCodeGrantType || form.Get("device_code") != "dc1" || form.Get("client_secret") != "" {
+		t.Errorf("token request = %v, want the device code of a public client", form)
+	}
+
+	for i, wantRefreshToken := range []string{"rt2", "rt2"} { // the first refresh returns no refresh token, hence rt2 is kept
+		g.token.ExpiresOn = time.Now()
+		g.tokenLock.Lock()
+		err = g.refreshToken(context.Background())
+		g.tokenLock.Unlock()
+		if form := forms[len(forms)-1]; err != nil || form.Get("grant_type") != "refresh_token" || form.Get("refresh_token") != wantRefreshToken {
+			t.Errorf("GraphClient.refreshToken() %v error = %v with %v, want the refresh token %v", i, err, form, wantRefreshToken)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// maxGetByIDs is the maximum amount of IDs that can be resolved with one directoryObjects/getByIds API-call
+const maxGetByIDs = 1000
+
+// DirectoryObject represents the base type of many directory entities like users, groups and
+// service principals. The ODataType tells which entity it actually is, e.g. "#microsoft.graph.user".
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/directoryobject
+type DirectoryObject struct {
+	ODataType   string `json:"@odata.type"`
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+func (d DirectoryObject) String() string {
+	return fmt.Sprintf("DirectoryObject(ODataType: \"%v\", ID: \"%v\", DisplayName: \"%v\")", d.ODataType, d.ID, d.DisplayName)
+}
+
+// GetDirectoryObjectsByIDs returns the directory objects identified by the given IDs. The types
+// restrict the search to e.g. "user", "group" or "servicePrincipal", all types are searched if
+// none is given. IDs that cannot be found are silently skipped by msgraph.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/directoryobject-getbyids
+func (g *GraphClient) GetDirectoryObjectsByIDs(ids []string, types ...string) ([]DirectoryObject, error) {
+	return g.GetDirectoryObjectsByIDsContext(context.Background(), ids, types...)
+}
+
+// GetDirectoryObjectsByIDsContext is GetDirectoryObjectsByIDs with a context.
+func (g *GraphClient) GetDirectoryObjectsByIDsContext(ctx context.Context, ids []string, types ...string) ([]DirectoryObject, error) {
+	var objects []DirectoryObject
+	for start := 0; start < len(ids); start += maxGetByIDs {
+		end := start + maxGetByIDs
+		if end > len(ids) {
+			end = len(ids)
+		}
+		body := struct {
+			IDs   []string `json:"ids"`
+			Types []string `json:"types,omitempty"`
+		}{IDs: ids[start:end], Types: types}
+
+		var marsh struct {
+			Objects []DirectoryObject `json:"value"`
+		}
+		if err := g.makePOSTAPICall(ctx, "/directoryObjects/getByIds", body, &marsh, RetryServerErrors()); err != nil { // a query, safe to repeat
+			return objects, err
+		}
+		objects = append(objects, marsh.Objects...)
+	}
+	return objects, nil
+}
+
+// DirectoryObjects contains a list of directory objects split by their type. Objects of any other
+// type, e.g. devices or contacts, are kept as raw json in Other.
+type DirectoryObjects struct {
+	Groups            Groups
+	Applications      []Application
+	ServicePrincipals []ServicePrincipal
+	Other             []json.RawMessage
+}
+
+func (d DirectoryObjects) String() string {
+	return fmt.Sprintf("DirectoryObjects(Groups: %v, Applications: %v, ServicePrincipals: %v, Other: %v)",
+		len(d.Groups), len(d.Applications), len(d.ServicePrincipals), len(d.Other))
+}
+
+// add unmarshals the given objects by their @odata.type and adds them to d
+func (d *DirectoryObjects) add(objects []json.RawMessage, gC *GraphClient) error {
+	for _, object := range objects {
+		var base DirectoryObject
+		if err := json.Unmarshal(object, &base); err != nil {
+			return err
+		}
+		switch base.ODataType {
+		case "#microsoft.graph.group":
+			group := Group{graphClient: gC}
+			if err := json.Unmarshal(object, &group); err != nil {
+				return err
+			}
+			d.Groups = append(d.Groups, group)
+		case "#microsoft.graph.application":
+			var application Application
+			if err := json.Unmarshal(object, &application); err != nil {
+				return err
+			}
+			d.Applications = append(d.Applications, application)
+		case "#microsoft.graph.servicePrincipal":
+			var servicePrincipal ServicePrincipal
+			if err := json.Unmarshal(object, &servicePrincipal); err != nil {
+				return err
+			}
+			d.ServicePrincipals = append(d.ServicePrincipals, servicePrincipal)
+		default:
+			d.Other = append(d.Other, object)
+		}
+	}
+	return nil
+}
+
+// ListUserOwnedObjects returns the directory objects owned by the user identified by either the given ID or userPrincipalName
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-ownedobjects
+func (g *GraphClient) ListUserOwnedObjects(identifier string) (DirectoryObjects, error) {
+	return g.ListUserOwnedObjectsContext(context.Background(), identifier)
+}
+
+// ListUserOwnedObjectsContext is ListUserOwnedObjects with a context.
+func (g *GraphClient) ListUserOwnedObjectsContext(ctx context.Context, identifier string) (DirectoryObjects, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return DirectoryObjects{}, err
+	}
+	return g.listDirectoryObjects(ctx, fmt.Sprintf("/users/%v/ownedObjects", identifier))
+}
+
+// ListUserCreatedObjects returns the directory objects created by the user identified by either the given ID or userPrincipalName
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-createdobjects
+func (g *GraphClient) ListUserCreatedObjects(identifier string) (DirectoryObjects, error) {
+	return g.ListUserCreatedObjectsContext(context.Background(), identifier)
+}
+
+// ListUserCreatedObjectsContext is ListUserCreatedObjects with a context.
+func (g *GraphClient) ListUserCreatedObjectsContext(ctx context.Context, identifier string) (DirectoryObjects, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return DirectoryObjects{}, err
+	}
+	return g.listDirectoryObjects(ctx, fmt.Sprintf("/users/%v/createdObjects", identifier))
+}
+
+// listDirectoryObjects returns all directory objects of the given resource split by their type, following @odata.nextLink
+func (g *GraphClient) listDirectoryObjects(ctx context.Context, resource string) (DirectoryObjects, error) {
+	var objects DirectoryObjects
+	err := g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+		var page []json.RawMessage
+		if err := json.Unmarshal(value, &page); err != nil {
+			return false, err
+		}
+		return true, objects.add(page, g)
+	})
+	return objects, err
+}
@@ -0,0 +1,39 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+)
+
+// Domain represents a domain associated with the tenant
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/domain
+type Domain struct {
+	ID                 string `json:"id"` // the fully qualified name of the domain, e.g. contoso.com
+	AuthenticationType string `json:"authenticationType"`
+	IsDefault          bool   `json:"isDefault"`
+	IsInitial          bool   `json:"isInitial"` // true for the initial <tenant>.onmicrosoft.com domain
+	IsRoot             bool   `json:"isRoot"`
+	IsVerified         bool   `json:"isVerified"`
+}
+
+func (d Domain) String() string {
+	return fmt.Sprintf("Domain(ID: \"%v\", AuthenticationType: \"%v\", IsDefault: \"%v\", IsInitial: \"%v\", IsRoot: \"%v\", IsVerified: \"%v\")",
+		d.ID, d.AuthenticationType, d.IsDefault, d.IsInitial, d.IsRoot, d.IsVerified)
+}
+
+// ListDomains returns all domains of the tenant, including unverified ones
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/domain-list
+func (g *GraphClient) ListDomains() ([]Domain, error) {
+	return g.ListDomainsContext(context.Background())
+}
+
+// ListDomainsContext is ListDomains with a context.
+func (g *GraphClient) ListDomainsContext(ctx context.Context) ([]Domain, error) {
+	var marsh struct {
+		Domains []Domain `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, "/domains", nil, &marsh)
+	return marsh.Domains, err
+}
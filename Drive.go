@@ -0,0 +1,174 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Drive represents a OneDrive or a SharePoint document library, e.g. the files of a Microsoft 365 group
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/drive
+type Drive struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	DriveType            string    `json:"driveType"` // personal, business or documentLibrary
+	WebURL               string    `json:"webUrl"`
+	CreatedDateTime      time.Time `json:"createdDateTime"`
+	LastModifiedDateTime time.Time `json:"lastModifiedDateTime"`
+
+	AdditionalData AdditionalData `json:"-"` // properties that are not mapped to a field, see CaptureAdditionalData
+}
+
+func (d Drive) String() string {
+	return fmt.Sprintf("Drive(ID: \"%v\", Name: \"%v\", DriveType: \"%v\", WebURL: \"%v\", CreatedDateTime: \"%v\", LastModifiedDateTime: \"%v\")",
+		d.ID, d.Name, d.DriveType, d.WebURL, d.CreatedDateTime, d.LastModifiedDateTime)
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (d *Drive) UnmarshalJSON(data []byte) error {
+	type drive Drive // drive has no UnmarshalJSON, prevents the recursion
+	if err := json.Unmarshal(data, (*drive)(d)); err != nil {
+		return err
+	}
+	var err error
+	d.AdditionalData, err = extractAdditionalData(data, d)
+	return err
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the AdditionalData is included
+func (d Drive) MarshalJSON() ([]byte, error) {
+	type drive Drive // drive has no MarshalJSON, prevents the recursion
+	marshalled, err := json.Marshal(drive(d))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, d.AdditionalData)
+}
+
+// DriveItem represents a file or folder within a Drive
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/driveitem
+type DriveItem struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	Size                 int64     `json:"size"` // size in bytes, the size of all contained files for folders
+	WebURL               string    `json:"webUrl"`
+	CreatedDateTime      time.Time `json:"createdDateTime"`
+	LastModifiedDateTime time.Time `json:"lastModifiedDateTime"`
+	ParentPath           string    // the path of the parent folder, e.g. "/drive/root:/Documents"
+	ChildCount           int       // the number of children, only set for folders
+	MimeType             string    // the MIME type of the file, only set for files
+
+	AdditionalData AdditionalData `json:"-"` // properties that are not mapped to a field, see CaptureAdditionalData
+
+	isFolder bool
+}
+
+func (d DriveItem) String() string {
+	return fmt.Sprintf("DriveItem(ID: \"%v\", Name: \"%v\", Size: \"%v\", WebURL: \"%v\", CreatedDateTime: \"%v\", LastModifiedDateTime: \"%v\", "+
+		"ParentPath: \"%v\", IsFolder: \"%v\", ChildCount: \"%v\", MimeType: \"%v\")",
+		d.ID, d.Name, d.Size, d.WebURL, d.CreatedDateTime, d.LastModifiedDateTime, d.ParentPath, d.isFolder, d.ChildCount, d.MimeType)
+}
+
+// IsFolder returns true if the DriveItem is a folder, its children can be listed with its ID
+func (d DriveItem) IsFolder() bool {
+	return d.isFolder
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (d *DriveItem) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ID                   string    `json:"id"`
+		Name                 string    `json:"name"`
+		Size                 int64     `json:"size"`
+		WebURL               string    `json:"webUrl"`
+		CreatedDateTime      time.Time `json:"createdDateTime"`
+		LastModifiedDateTime time.Time `json:"lastModifiedDateTime"`
+		ParentReference      struct {
+			Path string `json:"path"`
+		} `json:"parentReference"`
+		Folder *struct {
+			ChildCount int `json:"childCount"`
+		} `json:"folder"`
+		File *struct {
+			MimeType string `json:"mimeType"`
+		} `json:"file"`
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	d.ID = tmp.ID
+	d.Name = tmp.Name
+	d.Size = tmp.Size
+	d.WebURL = tmp.WebURL
+	d.CreatedDateTime = tmp.CreatedDateTime
+	d.LastModifiedDateTime = tmp.LastModifiedDateTime
+	d.ParentPath = tmp.ParentReference.Path
+	d.isFolder = tmp.Folder != nil
+	d.ChildCount, d.MimeType = 0, ""
+	if tmp.Folder != nil {
+		d.ChildCount = tmp.Folder.ChildCount
+	}
+	if tmp.File != nil {
+		d.MimeType = tmp.File.MimeType
+	}
+	var err error
+	d.AdditionalData, err = extractAdditionalData(data, &tmp)
+	return err
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the AdditionalData is included
+func (d DriveItem) MarshalJSON() ([]byte, error) {
+	type driveItem DriveItem // driveItem has no MarshalJSON, prevents the recursion
+	marshalled, err := json.Marshal(driveItem(d))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, d.AdditionalData)
+}
+
+// GetGroupDrive returns the document library of the Microsoft 365 group identified by groupID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/drive-get
+func (g *GraphClient) GetGroupDrive(groupID string) (Drive, error) {
+	return g.GetGroupDriveContext(context.Background(), groupID)
+}
+
+// GetGroupDriveContext is GetGroupDrive with a context.
+func (g *GraphClient) GetGroupDriveContext(ctx context.Context, groupID string) (Drive, error) {
+	var drive Drive
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/groups/%v/drive", groupID), nil, &drive)
+	return drive, err
+}
+
+// ListGroupDriveItems returns the files and folders within the folder identified by folderID of the document library of
+// the Microsoft 365 group identified by groupID. An empty folderID lists the root folder. Sub folders can be listed
+// by passing the ID of a DriveItem where IsFolder() is true.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/driveitem-list-children
+func (g *GraphClient) ListGroupDriveItems(groupID, folderID string) ([]DriveItem, error) {
+	return g.ListGroupDriveItemsContext(context.Background(), groupID, folderID)
+}
+
+// ListGroupDriveItemsContext is ListGroupDriveItems with a context.
+func (g *GraphClient) ListGroupDriveItemsContext(ctx context.Context, groupID, folderID string) ([]DriveItem, error) {
+	resource := fmt.Sprintf("/groups/%v/drive/root/children", groupID)
+	if folderID != "" {
+		resource = fmt.Sprintf("/groups/%v/drive/items/%v/children", groupID, folderID)
+	}
+	return g.listDriveItems(ctx, resource)
+}
+
+// listDriveItems returns all drive items of the given resource, following @odata.nextLink
+func (g *GraphClient) listDriveItems(ctx context.Context, resource string) ([]DriveItem, error) {
+	var items []DriveItem
+	err := g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+		var page []DriveItem
+		err := json.Unmarshal(value, &page)
+		items = append(items, page...)
+		return true, err
+	})
+	return items, err
+}
@@ -0,0 +1,45 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_ListGroupDriveItems(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups/g1/drive", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "b!drive", "name": "Documents", "driveType": "documentLibrary", "webUrl": "https://contoso.sharepoint.com/sites/technicians/Shared%20Documents"}`)
+	})
+	mux.HandleFunc("/v1.0/groups/g1/drive/root/children", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$skiptoken") == "" {
+			fmt.Fprint(w, `{"@odata.nextLink": "https://graph.microsoft.com/v1.0/groups/g1/drive/root/children?$skiptoken=page2",
+				"value": [{"id": "f1", "name": "Protocols", "size": 2048, "folder": {"childCount": 1}, "parentReference": {"path": "/drive/root:"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "i1", "name": "readme.txt", "size": 12, "lastModifiedDateTime": "2021-03-04T05:06:07Z", "file": {"mimeType": "text/plain"}}]}`)
+	})
+	mux.HandleFunc("/v1.0/groups/g1/drive/items/f1/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "i2", "name": "2021-03.pdf", "size": 2048, "file": {"mimeType": "application/pdf"}, "parentReference": {"path": "/drive/root:/Protocols"}}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	drive, err := g.GetGroupDrive("g1")
+	if err != nil || drive.DriveType != "documentLibrary" {
+		t.Fatalf("GraphClient.GetGroupDrive() = %v, %v", drive, err)
+	}
+	root, err := g.ListGroupDriveItems("g1", "")
+	if err != nil {
+		t.Fatalf("GraphClient.ListGroupDriveItems() error = %v", err)
+	}
+	if len(root) != 2 || !root[0].IsFolder() || root[0].ChildCount != 1 || root[1].IsFolder() || root[1].MimeType != "text/plain" {
+		t.Fatalf("GraphClient.ListGroupDriveItems() = %v, want a folder and a file", root)
+	}
+	folder, err := g.ListGroupDriveItems("g1", root[0].ID)
+	if err != nil {
+		t.Fatalf("GraphClient.ListGroupDriveItems() error = %v", err)
+	}
+	if len(folder) != 1 || folder[0].Name != "2021-03.pdf" || folder[0].ParentPath != "/drive/root:/Protocols" {
+		t.Errorf("GraphClient.ListGroupDriveItems() = %v, want 2021-03.pdf", folder)
+	}
+}
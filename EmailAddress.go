@@ -1,5 +1,7 @@
 package msgraph
 
+import "context"
+
 // EmailAddress represents an emailAddress instance as microsoft.graph.EmailAddress. This is used at
 // various positions, for example in CalendarEvents for attenees, owners, organizers or in Calendar
 // for the owner.
@@ -27,5 +29,10 @@ func (e *EmailAddress) setGraphClient(graphClient *GraphClient) {
 // identified by the e-mail address of the user. This should normally
 // be the userPrincipalName anyways. Returns an error if any from GraphClient.
 func (e EmailAddress) GetUser() (User, error) {
-	return e.graphClient.GetUser(e.Address)
+	return e.GetUserContext(context.Background())
+}
+
+// GetUserContext is GetUser with a context.
+func (e EmailAddress) GetUserContext(ctx context.Context) (User, error) {
+	return e.graphClient.GetUserContext(ctx, e.Address)
 }
@@ -0,0 +1,212 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Types of an ExternalItemProperty
+const (
+	ExternalPropertyTypeString             = "string"
+	ExternalPropertyTypeInt64              = "int64"
+	ExternalPropertyTypeDouble             = "double"
+	ExternalPropertyTypeDateTime           = "dateTime"
+	ExternalPropertyTypeBoolean            = "boolean"
+	ExternalPropertyTypeStringCollection   = "stringCollection"
+	ExternalPropertyTypeInt64Collection    = "int64Collection"
+	ExternalPropertyTypeDoubleCollection   = "doubleCollection"
+	ExternalPropertyTypeDateTimeCollection = "dateTimeCollection"
+)
+
+// Types of an ExternalACL entry
+const (
+	ExternalACLTypeUser                 = "user"
+	ExternalACLTypeGroup                = "group"
+	ExternalACLTypeEveryone             = "everyone"
+	ExternalACLTypeEveryoneExceptGuests = "everyoneExceptGuests"
+	ExternalACLTypeExternalGroup        = "externalGroup"
+)
+
+// Access types of an ExternalACL entry
+const (
+	ExternalAccessGrant = "grant"
+	ExternalAccessDeny  = "deny"
+)
+
+// Content types of an ExternalItemContent
+const (
+	ExternalContentTypeText = "text"
+	ExternalContentTypeHTML = "html"
+)
+
+// externalItemBaseType is the base type of every ExternalItemSchema
+const externalItemBaseType = "microsoft.graph.externalItem"
+
+// ExternalConnection represents a Graph connector connection, the container of the ExternalItems that are
+// ingested into Microsoft Search.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/externalconnectors-externalconnection
+type ExternalConnection struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	State       string `json:"state,omitempty"` // e.g. draft or ready, set by msgraph
+}
+
+func (c ExternalConnection) String() string {
+	return fmt.Sprintf("ExternalConnection(ID: \"%v\", Name: \"%v\", Description: \"%v\", State: \"%v\")",
+		c.ID, c.Name, c.Description, c.State)
+}
+
+// ExternalItemSchema defines the properties of the ExternalItems of an ExternalConnection
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/externalconnectors-schema
+type ExternalItemSchema struct {
+	Properties []ExternalItemProperty
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the baseType is always microsoft.graph.externalItem
+func (s ExternalItemSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		BaseType   string                 `json:"baseType"`
+		Properties []ExternalItemProperty `json:"properties"`
+	}{externalItemBaseType, s.Properties})
+}
+
+// ExternalItemProperty is a property of an ExternalItemSchema
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/externalconnectors-property
+type ExternalItemProperty struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"` // one of the ExternalPropertyType constants
+	IsSearchable  bool     `json:"isSearchable,omitempty"`
+	IsRetrievable bool     `json:"isRetrievable,omitempty"`
+	IsQueryable   bool     `json:"isQueryable,omitempty"`
+	IsRefinable   bool     `json:"isRefinable,omitempty"`
+	Labels        []string `json:"labels,omitempty"` // semantic labels, e.g. title or url
+	Aliases       []string `json:"aliases,omitempty"`
+}
+
+// ExternalACL is an access control entry of an ExternalItem
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/externalconnectors-acl
+type ExternalACL struct {
+	Type       string `json:"type"`       // one of the ExternalACLType constants
+	Value      string `json:"value"`      // the ID of the user or group, e.g. "everyone" for ExternalACLTypeEveryone
+	AccessType string `json:"accessType"` // ExternalAccessGrant or ExternalAccessDeny
+}
+
+// ExternalItemContent is the full-text indexed content of an ExternalItem
+type ExternalItemContent struct {
+	Type  string `json:"type"` // ExternalContentTypeText or ExternalContentTypeHTML
+	Value string `json:"value"`
+}
+
+// ExternalItem is an item of an ExternalConnection, e.g. a record of an ERP, that is indexed by Microsoft Search.
+// The keys of Properties are the names of the ExternalItemProperties of the registered schema. Collections have
+// to be given as []string, []int64, []float64 or []time.Time, their @odata.type annotation is added when marshalled.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/externalconnectors-externalitem
+type ExternalItem struct {
+	ACL        []ExternalACL
+	Properties map[string]interface{}
+	Content    ExternalItemContent
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library
+func (i ExternalItem) MarshalJSON() ([]byte, error) {
+	properties := make(map[string]interface{}, len(i.Properties))
+	for name, value := range i.Properties {
+		properties[name] = value
+		var collection string
+		switch value.(type) {
+		case []string:
+			collection = "Collection(String)"
+		case []int64, []int:
+			collection = "Collection(Int64)"
+		case []float64:
+			collection = "Collection(Double)"
+		case []time.Time:
+			collection = "Collection(DateTimeOffset)"
+		}
+		if collection != "" {
+			properties[name+"@odata.type"] = collection
+		}
+	}
+	return json.Marshal(struct {
+		ACL        []ExternalACL          `json:"acl"`
+		Properties map[string]interface{} `json:"properties"`
+		Content    ExternalItemContent    `json:"content"`
+	}{i.ACL, properties, i.Content})
+}
+
+// CreateExternalConnection creates a Graph connector connection with the given id, which must be alphanumeric
+// and between 3 and 32 characters long.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/externalconnectors-externalconnection-post
+func (g *GraphClient) CreateExternalConnection(id, name, description string) (ExternalConnection, error) {
+	return g.CreateExternalConnectionContext(context.Background(), id, name, description)
+}
+
+// CreateExternalConnectionContext is CreateExternalConnection with a context.
+func (g *GraphClient) CreateExternalConnectionContext(ctx context.Context, id, name, description string) (ExternalConnection, error) {
+	var connection ExternalConnection
+	err := g.makePOSTAPICall(ctx, "/external/connections", ExternalConnection{ID: id, Name: name, Description: description}, &connection)
+	return connection, err
+}
+
+// RegisterSchema registers the schema of the ExternalItems of the connection. The registration is asynchronous,
+// RegisterSchema polls its state until it has completed, which usually takes several minutes. Returns an error if
+// the registration failed.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/externalconnectors-externalconnection-patch-schema
+func (g *GraphClient) RegisterSchema(connectionID string, schema ExternalItemSchema) error {
+	return g.RegisterSchemaContext(context.Background(), connectionID, schema)
+}
+
+// RegisterSchemaContext is RegisterSchema with a context, polling stops as soon as ctx is done.
+func (g *GraphClient) RegisterSchemaContext(ctx context.Context, connectionID string, schema ExternalItemSchema) error {
+	op, err := g.startOperation(ctx, http.MethodPatch, fmt.Sprintf("/external/connections/%v/schema", connectionID), schema, nil)
+	if err != nil || !op.IsPending() {
+		return err // registered synchronously, if there is no error
+	}
+	if err := g.PollOperationContext(ctx, op, nil); err != nil {
+		return fmt.Errorf("cannot register schema of connection %v: %w", connectionID, err)
+	}
+	return nil
+}
+
+// PutExternalItem creates or replaces the item with the given itemID in the connection
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/externalconnectors-externalconnection-put-items
+func (g *GraphClient) PutExternalItem(connectionID, itemID string, item ExternalItem) error {
+	return g.PutExternalItemContext(context.Background(), connectionID, itemID, item)
+}
+
+// PutExternalItemContext is PutExternalItem with a context.
+func (g *GraphClient) PutExternalItemContext(ctx context.Context, connectionID, itemID string, item ExternalItem) error {
+	return g.makePUTAPICall(ctx, fmt.Sprintf("/external/connections/%v/items/%v", connectionID, itemID), item, nil)
+}
+
+// PutExternalItems puts the given items, keyed by their itemID, into the connection with at most concurrency
+// items at the same time, see PutExternalItem. The items that could not be put are returned with their error,
+// keyed by the itemID, e.g. to retry them later when ingestion is throttled.
+func (g *GraphClient) PutExternalItems(connectionID string, items map[string]ExternalItem, concurrency int, opts ...BulkOption) map[string]error {
+	return g.PutExternalItemsContext(context.Background(), connectionID, items, concurrency, opts...)
+}
+
+// PutExternalItemsContext is PutExternalItems with a context.
+func (g *GraphClient) PutExternalItemsContext(ctx context.Context, connectionID string, items map[string]ExternalItem, concurrency int, opts ...BulkOption) map[string]error {
+	itemIDs := make([]string, 0, len(items))
+	for itemID := range items {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Strings(itemIDs)
+	return forEachIdentifier(itemIDs, concurrency, opts, func(i int, itemID string) error {
+		return g.PutExternalItemContext(ctx, connectionID, itemID, items[itemID])
+	})
+}
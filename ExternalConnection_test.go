@@ -0,0 +1,85 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_ExternalConnection(t *testing.T) {
+	origInterval := operationPollInterval
+	operationPollInterval = time.Millisecond
+	defer func() { operationPollInterval = origInterval }()
+
+	var polls int
+	var mu sync.Mutex
+	items := map[string]map[string]interface{}{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/external/connections", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+	mux.HandleFunc("/v1.0/external/connections/erp/schema", func(w http.ResponseWriter, r *http.Request) {
+		var schema map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&schema)
+		if r.Method != http.MethodPatch || schema["baseType"] != externalItemBaseType {
+			t.Errorf("schema request = %v %v, want PATCH with baseType %v", r.Method, schema, externalItemBaseType)
+		}
+		w.Header().Set("Location", "https://graph.microsoft.com/v1.0/external/connections/erp/operations/op1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1.0/external/connections/erp/operations/op1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "inprogress"
+		if polls == 3 {
+			status = "completed"
+		}
+		fmt.Fprintf(w, `{"id": "op1", "status": "%v"}`, status)
+	})
+	mux.HandleFunc("/v1.0/external/connections/erp/items/", func(w http.ResponseWriter, r *http.Request) {
+		itemID := r.URL.Path[len("/v1.0/external/connections/erp/items/"):]
+		if itemID == "throttled" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var item map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&item)
+		mu.Lock()
+		items[itemID] = item
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	g := newTestGraphClient(t, mux)
+
+	connection, err := g.CreateExternalConnection("erp", "ERP", "ERP records")
+	if err != nil || connection.ID != "erp" || connection.Name != "ERP" {
+		t.Fatalf("GraphClient.CreateExternalConnection() = %v, %v", connection, err)
+	}
+
+	schema := ExternalItemSchema{Properties: []ExternalItemProperty{
+		{Name: "title", Type: ExternalPropertyTypeString, IsSearchable: true, IsRetrievable: true, Labels: []string{"title"}},
+		{Name: "tags", Type: ExternalPropertyTypeStringCollection, IsQueryable: true},
+	}}
+	if err := g.RegisterSchema("erp", schema); err != nil || polls != 3 {
+		t.Fatalf("GraphClient.RegisterSchema() error = %v after %v polls, want nil after 3", err, polls)
+	}
+
+	item := ExternalItem{
+		ACL:        []ExternalACL{{Type: ExternalACLTypeEveryone, Value: "everyone", AccessType: ExternalAccessGrant}},
+		Properties: map[string]interface{}{"title": "Order 4711", "tags": []string{"open", "priority"}},
+		Content:    ExternalItemContent{Type: ExternalContentTypeText, Value: "Order 4711 of Contoso"},
+	}
+	errs := g.PutExternalItems("erp", map[string]ExternalItem{"order4711": item, "throttled": item}, 2)
+	if len(errs) != 1 || !hasStatusCode(errs["throttled"], http.StatusTooManyRequests) {
+		t.Errorf("GraphClient.PutExternalItems() errors = %v, want the throttled item only", errs)
+	}
+	properties, _ := items["order4711"]["properties"].(map[string]interface{})
+	if properties["tags@odata.type"] != "Collection(String)" || properties["title@odata.type"] != nil {
+		t.Errorf("PutExternalItem() properties = %v, want a Collection(String) annotation of tags only", properties)
+	}
+}
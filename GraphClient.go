@@ -1,16 +1,29 @@
 // Package msgraph is a go lang implementation of the Microsoft Graph API
 //
+// Every API-call Foo of a GraphClient has a FooContext variant that takes a context.Context, e.g. to cancel its
+// requests, to set a deadline or to propagate tracing. The context is passed to every request of the call, including
+// the paging and the refresh of the token, and ends the wait for a retry. Foo uses context.Background().
+//
 // See: https://developer.microsoft.com/en-us/graph/docs/concepts/overview
 package msgraph
 
 import (
 	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -20,15 +33,44 @@ import (
 // An instance can also be json-unmarshalled an will immediately be initialized, hence a Token will be
 // grabbed. If grabbing a token fails the JSON-Unmarshal returns an error.
 type GraphClient struct {
-	apiCall sync.Mutex // lock it when performing an API-call to synchronize it
+	tokenLock      contextMutex  // lock it when reading or refreshing the token, API-calls themselves run concurrently
+	httpClientLock sync.RWMutex  // protects httpClient, see SetHTTPClient
+	requests       chan struct{} // holds a value for every running http request, see WithMaxConcurrentRequests
 
 	TenantID      string // See https://docs.microsoft.com/en-us/azure/azure-resource-manager/resource-group-create-service-principal-portal#get-tenant-id
 	ApplicationID string // See https://docs.microsoft.com/en-us/azure/azure-resource-manager/resource-group-create-service-principal-portal#get-application-id-and-authentication-key
 	ClientSecret  string // See https://docs.microsoft.com/en-us/azure/azure-resource-manager/resource-group-create-service-principal-portal#get-application-id-and-authentication-key
 
-	token Token // the current token to be used
+	DefaultUsageLocation string // optional, the usageLocation for new users, e.g. "AT". See GetDefaultUsageLocation
+
+	token              Token             // the current token to be used
+	requiredRoles      []string          // roles the token must contain, see RequireRoles
+	timeout            time.Duration     // timeout of every http request, defaultTimeout if 0. See WithTimeout
+	httpClient         *http.Client      // performs the http requests, defaultHTTPClient if nil. See WithHTTPClient
+	maxRetries         int               // retries of throttled or failed API-calls, see WithMaxRetries
+	apiVersion         string            // msgraph API version of the API-calls, APIVersion if empty. See Beta
+	cloud              CloudEndpoints    // endpoints of the token and the API-calls, CloudPublic if empty. See WithCloud
+	tokenResource      string            // resource of the token, the BaseURL of the cloud if empty. See WithTokenResource
+	tokenScopes        []string          // additional scopes of the token, see WithTokenScopes
+	tokenEndpointV2    bool              // acquire the token from the v2.0 endpoint, see WithTokenEndpointV2
+	claims             string            // claims challenge to be passed on the next token refresh, see claimsChallenge
+	certificate        *x509.Certificate // authenticates the application instead of the ClientSecret, see NewGraphClientWithCertificate
+	privateKey         *rsa.PrivateKey   // the private key of the certificate
+	managedIdentity    bool              // acquire the token from the managed identity of the Azure host, see NewGraphClientWithManagedIdentity
+	delegatedScopes    []string          // scopes of the delegated token of the signed-in user, see NewGraphClientWithDeviceCode
+	tokenProvider      TokenProvider     // acquires the token instead of the built-in flows, see NewGraphClientWithTokenProvider
+	tokenRefreshMargin time.Duration     // refresh the token this long before it expires, see WithTokenRefreshMargin
+	onTokenRefresh     func(Token)       // called with every new token, see OnTokenRefresh
+	stopTokenRefresh   func()            // stops the background refresh of the token, see StartTokenRefresh
 }
 
+// defaultTimeout is the timeout of every http request if none is set with WithTimeout
+const defaultTimeout = time.Second * 10
+
+// defaultHTTPClient performs the http requests of every GraphClient without WithHTTPClient. It is shared to reuse
+// its connections, its Transport is nil, hence http.DefaultTransport is used.
+var defaultHTTPClient = &http.Client{Timeout: defaultTimeout}
+
 func (g *GraphClient) String() string {
 	var firstPart, lastPart string
 	if len(g.ClientSecret) > 4 { // if ClientSecret is not initialized prevent a panic slice out of bounds
@@ -39,18 +81,251 @@ func (g *GraphClient) String() string {
 		g.TenantID, g.ApplicationID, firstPart, lastPart, g.token.NotBefore, g.token.ExpiresOn)
 }
 
-// NewGraphClient creates a new GraphClient instance with the given parameters and grab's a token.
+// NewGraphClient creates a new GraphClient instance with the given parameters and grab's a token. The opts
+// are applied before the token is grabbed, e.g. RequireRoles.
 //
 // Rerturns an error if the token cannot be initialized. This method does not have to be used to create a new GraphClient
-func NewGraphClient(tenantID, applicationID, clientSecret string) (*GraphClient, error) {
+func NewGraphClient(tenantID, applicationID, clientSecret string, opts ...ClientOption) (*GraphClient, error) {
 	g := GraphClient{TenantID: tenantID, ApplicationID: applicationID, ClientSecret: clientSecret}
-	g.apiCall.Lock()         // lock because we will refresh the token
-	defer g.apiCall.Unlock() // unlock after token refresh
-	return &g, g.refreshToken()
+	for _, opt := range opts {
+		if err := opt(&g); err != nil {
+			return &g, err
+		}
+	}
+	if err := g.checkTokenOptions(); err != nil {
+		return &g, err
+	}
+	g.tokenLock.Lock()         // lock because we will refresh the token
+	defer g.tokenLock.Unlock() // unlock after token refresh
+	if err := g.refreshToken(context.Background()); err != nil {
+		return &g, err
+	}
+	return &g, g.checkRequiredRoles()
+}
+
+// Clone returns a new GraphClient with the configuration of g, the opts are applied on top of it, e.g. WithTimeout
+// for a single bulk operation. The clone starts with the current token of g but refreshes it independently of g.
+func (g *GraphClient) Clone(opts ...ClientOption) (*GraphClient, error) {
+	clone := g.clone()
+	for _, opt := range opts {
+		if err := opt(clone); err != nil {
+			return nil, err
+		}
+	}
+	if err := clone.checkTokenOptions(); err != nil {
+		return nil, err
+	}
+	clone.tokenLock.Lock()
+	defer clone.tokenLock.Unlock()
+	if clone.tokenWantsToBeRefreshed() {
+		if err := clone.refreshToken(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	if err := clone.checkRequiredRoles(); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// clone returns a copy of the configuration and the current token of g
+func (g *GraphClient) clone() *GraphClient {
+	g.httpClientLock.RLock()
+	httpClient := g.httpClient
+	g.httpClientLock.RUnlock()
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	return &GraphClient{
+		TenantID:             g.TenantID,
+		ApplicationID:        g.ApplicationID,
+		ClientSecret:         g.ClientSecret,
+		DefaultUsageLocation: g.DefaultUsageLocation,
+		token:                g.token,
+		requiredRoles:        append([]string(nil), g.requiredRoles...),
+		requests:             g.requests,
+		timeout:              g.timeout,
+		httpClient:           httpClient,
+		maxRetries:           g.maxRetries,
+		apiVersion:           g.apiVersion,
+		cloud:                g.cloud,
+		tokenResource:        g.tokenResource,
+		tokenScopes:          append([]string(nil), g.tokenScopes...),
+		tokenEndpointV2:      g.tokenEndpointV2,
+		certificate:          g.certificate,
+		privateKey:           g.privateKey,
+		managedIdentity:      g.managedIdentity,
+		delegatedScopes:      append([]string(nil), g.delegatedScopes...),
+		tokenProvider:        g.tokenProvider,
+		tokenRefreshMargin:   g.tokenRefreshMargin,
+		onTokenRefresh:       g.onTokenRefresh,
+	}
 }
 
-// refreshToken refreshes the current Token. Grab's a new one and saves it within the GraphClient instance
-func (g *GraphClient) refreshToken() error {
+// Beta returns a copy of g whose API-calls go to the beta endpoint of msgraph instead of APIVersion, e.g. for
+// properties that are only returned by beta: g.Beta().GetUser(id). Like Clone, the copy refreshes its token
+// independently of g.
+//
+// Methods that are only available in beta always use it, no matter which client they are called on. Their
+// documentation contains a line starting with "Beta:", as the beta API may change without notice.
+func (g *GraphClient) Beta() *GraphClient {
+	beta := g.clone()
+	beta.apiVersion = betaAPIVersion
+	return beta
+}
+
+// SetHTTPClient makes g perform all further http requests with the given http.Client, e.g. with a custom timeout,
+// Transport or proxy. It is the counterpart of WithHTTPClient for a GraphClient that has been json-unmarshalled.
+// A nil httpClient restores the shared default http.Client with a timeout of 10 seconds.
+func (g *GraphClient) SetHTTPClient(httpClient *http.Client) {
+	g.httpClientLock.Lock()
+	defer g.httpClientLock.Unlock()
+	g.httpClient = httpClient
+}
+
+// TokenRoles returns the application permissions granted to the current token, e.g. "User.Read.All".
+// For a token without roles claim, e.g. of a delegated flow, the scopes are returned instead, see TokenScopes.
+func (g *GraphClient) TokenRoles() []string {
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	if roles := g.token.Roles(); roles != nil {
+		return roles
+	}
+	return g.token.Scopes()
+}
+
+// TokenExpiresOn returns the time when the current token expires, it is refreshed before, see WithTokenRefreshMargin
+func (g *GraphClient) TokenExpiresOn() time.Time {
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	return g.token.ExpiresOn
+}
+
+// GetToken returns a copy of the current token, it is refreshed first if it wants to be refreshed. The token can be
+// passed to other libraries that call msgraph, e.g. as bearer token via Token.GetAccessToken.
+func (g *GraphClient) GetToken() (Token, error) {
+	return g.GetTokenContext(context.Background())
+}
+
+// GetTokenContext is GetToken with a context.
+func (g *GraphClient) GetTokenContext(ctx context.Context) (Token, error) {
+	return g.currentToken(ctx)
+}
+
+// TokenScopes returns the delegated permissions granted to the current token, e.g. "Mail.Send"
+func (g *GraphClient) TokenScopes() []string {
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	return g.token.Scopes()
+}
+
+// checkRequiredRoles returns ErrMissingRoles listing the roles of RequireRoles that are not granted to the current
+// token. Tokens without roles claim are checked against their scopes.
+func (g *GraphClient) checkRequiredRoles() error {
+	granted := g.token.Roles()
+	if granted == nil {
+		granted = g.token.Scopes()
+	}
+	var missing []string
+	for _, required := range g.requiredRoles {
+		var found bool
+		for _, role := range granted {
+			found = found || strings.EqualFold(role, required)
+		}
+		if !found {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %v (granted: %v)", ErrMissingRoles, strings.Join(missing, ", "), strings.Join(granted, ", "))
+	}
+	return nil
+}
+
+// refreshToken refreshes the current Token. Grab's a new one with the flow of the GraphClient and saves it within
+// the GraphClient instance, the OnTokenRefresh callback is called with it.
+func (g *GraphClient) refreshToken(ctx context.Context) error {
+	var err error
+	switch {
+	case g.tokenProvider != nil:
+		err = g.refreshProvidedToken(ctx)
+	case g.managedIdentity:
+		err = g.refreshManagedIdentityToken(ctx)
+	case g.token.refreshToken != "":
+		err = g.refreshDelegatedToken(ctx)
+	default:
+		err = g.refreshClientCredentialsToken(ctx)
+	}
+	if err == nil && g.onTokenRefresh != nil {
+		g.onTokenRefresh(g.token)
+	}
+	return err
+}
+
+// tokenWantsToBeRefreshed returns true if the current token is invalid or expires within the refresh margin, see
+// WithTokenRefreshMargin
+func (g *GraphClient) tokenWantsToBeRefreshed() bool {
+	if g.tokenRefreshMargin > 0 {
+		return g.token.ExpiresWithin(g.tokenRefreshMargin)
+	}
+	return g.token.WantsToBeRefreshed()
+}
+
+// currentToken returns the current token of g, it is refreshed first if it wants to be refreshed. Concurrent
+// API-calls wait for the refresh of the first one and use its token, hence the token is refreshed only once.
+func (g *GraphClient) currentToken(ctx context.Context) (Token, error) {
+	if err := g.tokenLock.LockContext(ctx); err != nil {
+		return Token{}, err
+	}
+	defer g.tokenLock.Unlock()
+	if g.tokenWantsToBeRefreshed() {
+		if err := g.refreshToken(ctx); err != nil {
+			return Token{}, err
+		}
+	}
+	return g.token, nil
+}
+
+// refreshTokenWithClaims refreshes the token of g with the given claims of a claims challenge, see claimsChallenge
+func (g *GraphClient) refreshTokenWithClaims(ctx context.Context, claims string) error {
+	if err := g.tokenLock.LockContext(ctx); err != nil {
+		return err
+	}
+	defer g.tokenLock.Unlock()
+	g.claims = claims
+	return g.refreshToken(ctx)
+}
+
+// acquireRequest waits until g may perform another http request, see WithMaxConcurrentRequests. It returns ctx.Err()
+// if ctx is done before. Every successful acquireRequest has to be followed by releaseRequest.
+func (g *GraphClient) acquireRequest(ctx context.Context) error {
+	if g.requests == nil {
+		return nil
+	}
+	select {
+	case g.requests <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseRequest marks a http request acquired by acquireRequest as finished
+func (g *GraphClient) releaseRequest() {
+	if g.requests != nil {
+		<-g.requests
+	}
+}
+
+// checkTokenOptions returns an error if the ClientOptions of the token request of g contradict each other
+func (g *GraphClient) checkTokenOptions() error {
+	if g.tokenEndpointV2 && len(g.tokenScopes) > 0 {
+		return fmt.Errorf("WithTokenScopes cannot be combined with WithTokenEndpointV2, the client credentials flow of the v2.0 endpoint only allows the .default scope")
+	}
+	return nil
+}
+
+// refreshClientCredentialsToken acquires a new application token with the client secret or the certificate of g
+func (g *GraphClient) refreshClientCredentialsToken(ctx context.Context) error {
 	if g.TenantID == "" {
 		return fmt.Errorf("tenant ID is empty")
 	}
@@ -58,16 +333,44 @@ func (g *GraphClient) refreshToken() error {
 	data := url.Values{}
 	data.Add("grant_type", "client_credentials")
 	data.Add("client_id", g.ApplicationID)
-	data.Add("client_secret", g.ClientSecret)
-	data.Add("resource", BaseURL)
+	tokenResource := g.tokenResource
+	if tokenResource == "" {
+		tokenResource = g.endpoints().BaseURL
+	}
+	scopes := g.tokenScopes
+	if g.tokenEndpointV2 {
+		// the v2.0 endpoint has no resource, the resource is requested by its .default scope instead, which is the
+		// only scope the client credentials flow allows, see checkTokenOptions
+		resource = fmt.Sprintf("/%v/oauth2/v2.0/token", g.TenantID)
+		scopes = []string{strings.TrimSuffix(tokenResource, "/") + "/.default"}
+	} else {
+		data.Add("resource", tokenResource)
+	}
+	if len(scopes) > 0 {
+		data.Add("scope", strings.Join(scopes, " "))
+	}
+	if g.claims != "" {
+		data.Add("claims", g.claims)
+	}
 
-	u, err := url.ParseRequestURI(LoginBaseURL)
+	u, err := url.ParseRequestURI(g.endpoints().LoginBaseURL)
 	if err != nil {
 		return fmt.Errorf("unable to parse URI: %v", err)
 	}
 
 	u.Path = resource
-	req, err := http.NewRequest("POST", u.String(), bytes.NewBufferString(data.Encode()))
+	if g.certificate != nil {
+		// the assertion is short-lived, hence it is signed for every token request
+		assertion, err := g.clientAssertion(u.String())
+		if err != nil {
+			return err
+		}
+		data.Add("client_assertion_type", clientAssertionType)
+		data.Add("client_assertion", assertion)
+	} else {
+		data.Add("client_secret", g.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewBufferString(data.Encode()))
 
 	if err != nil {
 		return fmt.Errorf("HTTP Request Error: %v", err)
@@ -77,186 +380,809 @@ func (g *GraphClient) refreshToken() error {
 	req.Header.Add("Content-Length", strconv.Itoa(len(data.Encode())))
 
 	var newToken Token
-	err = g.performRequest(req, &newToken) // perform the prepared request
+	err = g.performRequest(req, &newToken, requestOptions{}) // perform the prepared request
 	if err != nil {
-		return fmt.Errorf("error on getting msgraph Token: %v", err)
+		return fmt.Errorf("error on getting msgraph Token: %w", err)
 	}
 	g.token = newToken
+	g.claims = "" // satisfied by the new token
 	return err
 }
 
-// makeGETAPICall performs an API-Call to the msgraph API. This func uses sync.Mutex to synchronize all API-calls
-func (g *GraphClient) makeGETAPICall(apicall string, getParams url.Values, v interface{}) error {
-	g.apiCall.Lock()
-	defer g.apiCall.Unlock() // unlock when the func returns
-	// Check token
-	if g.token.WantsToBeRefreshed() { // Token not valid anymore?
-		err := g.refreshToken()
-		if err != nil {
+// odataPagedResponse is a page of a collection returned by msgraph, the @odata.nextLink is empty on the last page
+type odataPagedResponse struct {
+	Value    json.RawMessage `json:"value"`
+	NextLink string          `json:"@odata.nextLink"`
+}
+
+// makeGETAPICall performs a GET-API-Call to the msgraph API and json-unmarshals the response into v. If the
+// response is a collection split into pages, the @odata.nextLink of every page is followed and the "value"-arrays
+// of all pages are concatenated before v is unmarshalled, hence v receives the complete collection.
+// The opts are applied to the request of every page. See makeAPICallURL for the concurrency of API-calls.
+func (g *GraphClient) makeGETAPICall(ctx context.Context, apicall string, getParams url.Values, v interface{}, opts ...RequestOption) error {
+	if getParams == nil { // initialize getParams if it's nil
+		getParams = url.Values{}
+	}
+	if getParams.Get("$top") == "" { // resources with a smaller maximum page size set their own $top
+		getParams.Set("$top", strconv.Itoa(MaxPageSize)) // the largest page size, hence the fewest API-calls
+	}
+
+	var first json.RawMessage
+	if err := g.makeAPICall(ctx, http.MethodGet, apicall, getParams, nil, &first, opts...); err != nil || len(first) == 0 {
+		return err
+	}
+	var page odataPagedResponse
+	if json.Unmarshal(first, &page) != nil || page.NextLink == "" { // not a collection or just a single page
+		return json.Unmarshal(first, v)
+	}
+
+	var values []json.RawMessage
+	for {
+		var pageValues []json.RawMessage
+		if err := json.Unmarshal(page.Value, &pageValues); err != nil {
+			return fmt.Errorf("cannot unmarshal the value of page %v: %v", apicall, err)
+		}
+		values = append(values, pageValues...)
+		if page.NextLink == "" {
+			break
+		}
+		nextLink := page.NextLink
+		page = odataPagedResponse{}
+		if err := g.makeNextLinkAPICall(ctx, nextLink, &page, opts...); err != nil { // the nextLink already contains $top and $skiptoken
 			return err
 		}
 	}
 
-	reqURL, err := url.ParseRequestURI(BaseURL)
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(first, &response); err != nil {
+		return err
+	}
+	delete(response, "@odata.nextLink")
+	var err error
+	if response["value"], err = json.Marshal(values); err != nil {
+		return err
+	}
+	complete, err := json.Marshal(response)
 	if err != nil {
-		return fmt.Errorf("unable to parse URI %v: %v", BaseURL, err)
+		return err
 	}
+	return json.Unmarshal(complete, v)
+}
 
-	// Add Version to API-Call, the leading slash is always added by the calling func
-	reqURL.Path = "/" + APIVersion + apicall
+// makePOSTAPICall performs a POST-API-Call to the msgraph API, the postBody will be json-marshalled.
+func (g *GraphClient) makePOSTAPICall(ctx context.Context, apiCall string, postBody, v interface{}, opts ...RequestOption) error {
+	return g.makeAPICall(ctx, http.MethodPost, apiCall, nil, postBody, v, opts...)
+}
+
+// makePATCHAPICall performs a PATCH-API-Call to the msgraph API, the patchBody will be json-marshalled.
+func (g *GraphClient) makePATCHAPICall(ctx context.Context, apiCall string, patchBody, v interface{}, opts ...RequestOption) error {
+	return g.makeAPICall(ctx, http.MethodPatch, apiCall, nil, patchBody, v, opts...)
+}
 
-	req, err := http.NewRequest("GET", reqURL.String(), nil)
+// makePUTAPICall performs a PUT-API-Call to the msgraph API, the putBody will be json-marshalled.
+func (g *GraphClient) makePUTAPICall(ctx context.Context, apiCall string, putBody, v interface{}, opts ...RequestOption) error {
+	return g.makeAPICall(ctx, http.MethodPut, apiCall, nil, putBody, v, opts...)
+}
+
+// makeDELETEAPICall performs a DELETE-API-Call to the msgraph API.
+func (g *GraphClient) makeDELETEAPICall(ctx context.Context, apiCall string) error {
+	return g.makeAPICall(ctx, http.MethodDelete, apiCall, nil, nil, nil)
+}
+
+// makeAPICall performs an API-Call with the given http method to the msgraph API. The body will be
+// json-marshalled if it's not nil.
+func (g *GraphClient) makeAPICall(ctx context.Context, method, apiCall string, getParams url.Values, body, v interface{}, opts ...RequestOption) error {
+	apiVersion := newRequestOptions(opts).apiVersion
+	if apiVersion == "" {
+		apiVersion = g.apiVersion
+	}
+	if apiVersion == "" {
+		apiVersion = APIVersion
+	}
+	reqURL, err := g.buildAPIURL(apiVersion, apiCall, getParams)
 	if err != nil {
-		return fmt.Errorf("HTTP request error: %v", err)
+		return err
 	}
+	return g.makeAPICallURL(ctx, method, reqURL, body, v, opts...)
+}
+
+// makeBetaAPICall performs an API-Call with the given http method to the beta endpoint of the msgraph
+// API. Only use it for functionality that is not available in APIVersion.
+func (g *GraphClient) makeBetaAPICall(ctx context.Context, method, apiCall string, getParams url.Values, body, v interface{}, opts ...RequestOption) error {
+	return g.makeAPICall(ctx, method, apiCall, getParams, body, v, append(opts, WithAPIVersion(betaAPIVersion))...)
+}
 
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", g.token.GetAccessToken())
+// endpoints returns the endpoints of the cloud of g, see WithCloud
+func (g *GraphClient) endpoints() CloudEndpoints {
+	if g.cloud.BaseURL == "" {
+		return CloudPublic
+	}
+	return g.cloud
+}
 
-	if getParams == nil { // initialize getParams if it's nil
-		getParams = url.Values{}
+// buildAPIURL returns the absolute URL for the given API-Call of the given msgraph API version
+func (g *GraphClient) buildAPIURL(apiVersion, apiCall string, getParams url.Values) (string, error) {
+	baseURL := g.endpoints().BaseURL
+	reqURL, err := url.ParseRequestURI(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse URI %v: %v", baseURL, err)
 	}
 
-	// TODO: Improve performance with using $skip & paging instead of retrieving all results with $top
-	// TODO: MaxPageSize is currently 999, if there are any time more than 999 entries this will make the program unpredictable... hence start to use paging (!)
-	getParams.Add("$top", strconv.Itoa(MaxPageSize))
-	req.URL.RawQuery = getParams.Encode() // set query parameters
+	// Add Version to API-Call, the leading slash is always added by the calling func
+	reqURL.Path = "/" + apiVersion + apiCall
 
-	return g.performRequest(req, v)
+	if getParams != nil {
+		reqURL.RawQuery = getParams.Encode() // set query parameters
+	}
+	return reqURL.String(), nil
 }
 
-func (g *GraphClient) makePostAPICall(apiCall string, postBody, v interface{}) error {
-	g.apiCall.Lock()
-	defer g.apiCall.Unlock() // unlock when the func returns
-	// Check token
-	if g.token.WantsToBeRefreshed() { // Token not valid anymore?
-		err := g.refreshToken()
+// makeAPICallURL performs an API-Call with the given http method against the given absolute URL,
+// e.g. an @odata.nextLink. The body will be json-marshalled if it's not nil, a []byte body is sent as is.
+// The opts are applied to the request. API-calls of g run concurrently, only the refresh of the token is
+// synchronized, see currentToken, and the number of concurrent requests may be limited, see WithMaxConcurrentRequests.
+// Throttled requests and server errors are retried, see WithMaxRetries and RetryServerErrors.
+func (g *GraphClient) makeAPICallURL(ctx context.Context, method, reqURL string, body, v interface{}, opts ...RequestOption) error {
+	var marshalled []byte
+	if raw, ok := body.([]byte); ok { // binary content, e.g. of a $value endpoint, see withContentType
+		marshalled = raw
+	} else if body != nil {
+		var err error
+		marshalled, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshalling request body %v", err)
+		}
+	}
+	options := newRequestOptions(opts)
+	if options.header.Get("client-request-id") == "" {
+		options.header.Set("client-request-id", NewRequestID()) // the same for every attempt, for correlation by Microsoft support
+	}
+	var challenged bool // the claims challenge of msgraph has been answered with a new token
+	for retries := 0; ; {
+		// Check token, it may have expired while waiting for a retry
+		token, err := g.currentToken(ctx)
 		if err != nil {
 			return err
 		}
+
+		var reqBody io.Reader
+		if marshalled != nil {
+			reqBody = bytes.NewReader(marshalled)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+		if err != nil {
+			return fmt.Errorf("HTTP request error: %v", err)
+		}
+
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("Authorization", token.GetAccessToken())
+		options.apply(req)
+		if options.dryRun != nil {
+			options.dryRun(req, marshalled)
+			return nil
+		}
+
+		var header http.Header
+		attempt := options
+		attempt.responseHeader = &header
+		if err := g.acquireRequest(ctx); err != nil {
+			return err
+		}
+		err = g.performRequest(req, v, attempt)
+		g.releaseRequest()
+		if options.responseHeader != nil {
+			*options.responseHeader = header
+		}
+		statusCode := statusCodeOf(err)
+		if statusCode == http.StatusUnauthorized {
+			claims := claimsChallenge(header)
+			if claims == "" {
+				return err
+			}
+			if challenged {
+				return &sentinelError{sentinel: ErrClaimsChallenge, detail: claims, err: err}
+			}
+			// Continuous Access Evaluation revoked the token, retry once with a token that satisfies the claims
+			challenged = true
+			if err := g.refreshTokenWithClaims(ctx, claims); err != nil {
+				return &sentinelError{sentinel: ErrClaimsChallenge, detail: claims, err: err}
+			}
+			continue
+		}
+		if !isRetryableStatusCode(statusCode, header, isIdempotentMethod(method) || options.retryServerErrors) {
+			return err
+		}
+		if retries >= g.maxRetries {
+			if retries > 0 {
+				return fmt.Errorf("%w (gave up after %v retries, last StatusCode %v)", err, retries, statusCode)
+			}
+			return err
+		}
+		select {
+		case <-time.After(retryDelay(header, retries)):
+		case <-ctx.Done():
+			return fmt.Errorf("%w (while waiting to retry after StatusCode %v)", ctx.Err(), statusCode)
+		}
+		retries++
 	}
+}
 
-	reqURL, err := url.ParseRequestURI(BaseURL)
-	if err != nil {
-		return fmt.Errorf("unable to parse URI %v: %v", BaseURL, err)
+// makeNextLinkAPICall performs a GET-API-Call against the given @odata.nextLink or @odata.deltaLink, which is absolute
+// and already contains all query parameters. Returns an error without performing the API-call if the link does
+// not point to the host of the BaseURL of the cloud, hence a manipulated link cannot make the GraphClient send its token elsewhere.
+func (g *GraphClient) makeNextLinkAPICall(ctx context.Context, link string, v interface{}, opts ...RequestOption) error {
+	if err := g.checkLinkHost(link); err != nil {
+		return err
 	}
+	return g.makeAPICallURL(ctx, http.MethodGet, link, nil, v, opts...)
+}
 
-	body, err := json.Marshal(postBody)
+// checkLinkHost returns an error if the absolute link of a response, e.g. an @odata.nextLink or the Location of an
+// Operation, does not point to the scheme and host of the BaseURL of the cloud
+func (g *GraphClient) checkLinkHost(link string) error {
+	linkURL, err := url.Parse(link)
 	if err != nil {
-		return fmt.Errorf("error marshalling request body %v", err)
+		return fmt.Errorf("cannot parse link %v: %v", link, err)
 	}
-
-	// Add Version to API-Call, the leading slash is always added by the calling func
-	reqURL.Path = "/" + APIVersion + apiCall
-
-	req, err := http.NewRequest(http.MethodPost, reqURL.String(), bytes.NewBuffer(body))
+	baseURL, err := url.Parse(g.endpoints().BaseURL)
 	if err != nil {
-		return fmt.Errorf("HTTP request error: %v", err)
+		return fmt.Errorf("unable to parse URI %v: %v", g.endpoints().BaseURL, err)
 	}
+	if linkURL.Scheme != baseURL.Scheme || !strings.EqualFold(linkURL.Host, baseURL.Host) {
+		return fmt.Errorf("link %v points to an unexpected host, want %v", link, baseURL.Host)
+	}
+	return nil
+}
 
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", g.token.GetAccessToken())
+// makePagedGETAPICall performs a GET-API-Call to the msgraph API and follows the @odata.nextLink of
+// every response. The "value"-array of every page is handed over to pageFn, paging stops as soon as
+// pageFn returns false or an error.
+func (g *GraphClient) makePagedGETAPICall(ctx context.Context, apicall string, getParams url.Values, pageFn func(value json.RawMessage) (bool, error)) error {
+	var page odataPagedResponse
+	if getParams == nil {
+		getParams = url.Values{}
+	}
+	if getParams.Get("$top") == "" {
+		getParams.Set("$top", strconv.Itoa(MaxPageSize))
+	}
+	err := g.makeAPICall(ctx, http.MethodGet, apicall, getParams, nil, &page) // not makeGETAPICall, it would load all pages at once
+	for {
+		if err != nil {
+			return err
+		}
+		var goOn bool
+		goOn, err = pageFn(page.Value)
+		if err != nil || !goOn || page.NextLink == "" {
+			return err
+		}
+		nextLink := page.NextLink
+		page.Value, page.NextLink = nil, ""
+		err = g.makeNextLinkAPICall(ctx, nextLink, &page)
+	}
+}
 
-	return g.performRequest(req, v)
+// makeDeltaGETAPICall performs a GET-API-Call of a delta query to the msgraph API and follows the @odata.nextLink
+// of every response, the "value"-array of every page is handed over to pageFn. The apicall is either the delta
+// resource, e.g. /users/{id}/todo/lists/{id}/tasks/delta, or the absolute @odata.deltaLink of a previous delta query.
+// Returns the @odata.deltaLink of the last page, which returns the changes since this call.
+func (g *GraphClient) makeDeltaGETAPICall(ctx context.Context, apicall string, pageFn func(value json.RawMessage) error) (string, error) {
+	var page struct {
+		odataPagedResponse
+		DeltaLink string `json:"@odata.deltaLink"`
+	}
+	var err error
+	if strings.HasPrefix(apicall, "https://") || strings.HasPrefix(apicall, "http://") {
+		err = g.makeNextLinkAPICall(ctx, apicall, &page)
+	} else {
+		err = g.makeAPICall(ctx, http.MethodGet, apicall, nil, nil, &page) // delta queries do not support $top of makeGETAPICall
+	}
+	for {
+		if err != nil {
+			return "", err
+		}
+		if err = pageFn(page.Value); err != nil {
+			return "", err
+		}
+		if page.NextLink == "" {
+			if page.DeltaLink == "" {
+				return "", fmt.Errorf("delta query %v returned neither @odata.nextLink nor @odata.deltaLink", apicall)
+			}
+			return page.DeltaLink, nil
+		}
+		nextLink := page.NextLink
+		page.Value, page.NextLink = nil, ""
+		err = g.makeNextLinkAPICall(ctx, nextLink, &page)
+	}
 }
 
 // performRequest performs a pre-prepared http.Request and does the proper error-handling for it.
 // does a json.Unmarshal into the v interface{} and returns the error of it if everything went well so far.
-func (g *GraphClient) performRequest(req *http.Request, v interface{}) error {
-	httpClient := &http.Client{
-		Timeout: time.Second * 10,
+// The body is stored as is if v is a *[]byte. The header of the response is stored as requested by the options,
+// see ResponseHeader.
+func (g *GraphClient) performRequest(req *http.Request, v interface{}, options requestOptions) error {
+	g.httpClientLock.RLock()
+	httpClient := g.httpClient
+	g.httpClientLock.RUnlock()
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	if g.timeout > 0 && httpClient.Timeout != g.timeout {
+		withTimeout := *httpClient // shares the Transport, hence its connections
+		withTimeout.Timeout = g.timeout
+		httpClient = &withTimeout
 	}
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("HTTP response error: %v of http.Request: %v", err, req.URL)
+		// the url.Error contains the query of the URL, which may contain e.g. a $filter with personal data, hence just
+		// its cause is wrapped, e.g. for errors.Is(err, context.Canceled)
+		if urlErr, ok := err.(*url.Error); ok {
+			if urlErr.Timeout() {
+				return fmt.Errorf("HTTP request timed out: %w of http.Request: %v %v", urlErr.Err, req.Method, redactURL(req.URL))
+			}
+			err = urlErr.Err
+		}
+		return fmt.Errorf("HTTP response error: %w of http.Request: %v %v", err, req.Method, redactURL(req.URL))
 	}
 	defer resp.Body.Close() // close body when func returns
+	if options.responseHeader != nil {
+		*options.responseHeader = resp.Header
+	}
+	if options.statusCode != nil {
+		*options.statusCode = resp.StatusCode
+	}
 
 	body, err := ioutil.ReadAll(resp.Body) // read body first to append it to the error (if any)
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		// Hint: this will mostly be the case if the tenant ID cannot be found, the Application ID cannot be found or the clientSecret is incorrect.
 		// The cause will be described in the body, hence we have to return the body too for proper error-analysis
-		return fmt.Errorf("StatusCode is not OK: %v. Body: %v ", resp.StatusCode, string(body))
+		return newGraphError(resp, body, req.Header.Get("client-request-id"))
 	}
 
 	if err != nil {
-		return fmt.Errorf("HTTP response read error: %v of http.Request: %v", err, req.URL)
+		return fmt.Errorf("HTTP response read error: %v of http.Request: %v %v", err, req.Method, redactURL(req.URL))
 	}
 
-	if resp.ContentLength > 0 {
+	if raw, ok := v.(*[]byte); ok { // binary content, e.g. of a $value endpoint
+		*raw = body
+		return nil
+	}
+	if len(body) > 0 { // ContentLength is -1 for chunked responses
 		return json.Unmarshal(body, &v) // return the error of the json unmarshal
 	}
 
 	return nil
 }
 
-// Send email sends an email using the graph api
-func (g *GraphClient) SendEmail(mail Mail) error {
+// redactURL returns u without its query and fragment, e.g. to include it in an error message
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery, redacted.Fragment, redacted.User = "", "", nil
+	return redacted.String()
+}
+
+// retryBaseDelay is the delay before the first retry of a throttled or failed API-call if msgraph did not send a
+// Retry-After header, it doubles with every further retry
+var retryBaseDelay = time.Second
+
+// statusCodeOf returns the http status code of the GraphError within err, 0 if it has none
+func statusCodeOf(err error) int {
+	var gerr *GraphError
+	if errors.As(err, &gerr) {
+		return gerr.StatusCode
+	}
+	return 0
+}
+
+// isRetryableStatusCode returns true if an API-call that failed with the given status code may succeed when retried,
+// i.e. it has been throttled or the server failed. The server may have failed after it performed a non-idempotent
+// request, hence it is only retried if msgraph did not process it: if it has been throttled or is unavailable with
+// a Retry-After header.
+func isRetryableStatusCode(statusCode int, header http.Header, idempotent bool) bool {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode == http.StatusServiceUnavailable && header.Get("Retry-After") != "":
+		return true
+	}
+	return idempotent && statusCode >= 500
+}
+
+// isIdempotentMethod returns true if performing a request with the given http method twice has the same effect as
+// performing it once. PATCH updates the given properties of an entity, actions of msgraph are POST.
+func isIdempotentMethod(method string) bool {
+	return method != http.MethodPost
+}
+
+// retryDelay returns how long to wait before the next retry, as requested by the Retry-After header of the
+// failed response, in seconds or as http date. Without the header it is an exponential backoff with jitter.
+func retryDelay(header http.Header, retries int) time.Duration {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			if delay := time.Until(date); delay > 0 {
+				return delay
+			}
+			return 0
+		}
+	}
+	backoff := retryBaseDelay << uint(retries)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// claimsChallengePattern matches the claims of a WWW-Authenticate header
+var claimsChallengePattern = regexp.MustCompile(`claims="([^"]*)"`)
+
+// claimsChallenge returns the claims requested by the WWW-Authenticate header of a 401 response, e.g. by
+// Continuous Access Evaluation, or an empty string if there is no claims challenge. The claims are base64-encoded
+// JSON in the header and are returned decoded, as expected by the claims parameter of the token request.
+//
+// See https://docs.microsoft.com/en-us/azure/active-directory/develop/claims-challenge
+func claimsChallenge(header http.Header) string {
+	for _, authenticate := range header.Values("WWW-Authenticate") {
+		if !strings.Contains(authenticate, "insufficient_claims") {
+			continue
+		}
+		match := claimsChallengePattern.FindStringSubmatch(authenticate)
+		if match == nil || match[1] == "" {
+			continue
+		}
+		if claims, err := base64.StdEncoding.DecodeString(match[1]); err == nil {
+			return string(claims)
+		}
+		if claims, err := base64.RawStdEncoding.DecodeString(match[1]); err == nil {
+			return string(claims)
+		}
+		return match[1]
+	}
+	return ""
+}
+
+// hasStatusCode returns true if the given error has been returned by performRequest because the
+// msgraph API responded with the given http status code
+func hasStatusCode(err error, statusCode int) bool {
+	return statusCodeOf(err) == statusCode
+}
+
+// odataErrorMessage returns the message of the OData error in the body of an error returned by performRequest, e.g.
+// the violated password policy. Returns an empty string if the error contains no OData error.
+func odataErrorMessage(err error) string {
+	var gerr *GraphError
+	if !errors.As(err, &gerr) {
+		return ""
+	}
+	return gerr.Message
+}
+
+// Send email sends an email using the graph api. The opts are applied to the API-call, e.g. IdempotencyKey or DryRun.
+func (g *GraphClient) SendEmail(mail Mail, opts ...RequestOption) error {
+	return g.SendEmailContext(context.Background(), mail, opts...)
+}
+
+// SendEmailContext is SendEmail with a context.
+func (g *GraphClient) SendEmailContext(ctx context.Context, mail Mail, opts ...RequestOption) error {
 	resource := fmt.Sprintf("/users/%s/sendMail", mail.Message.From.EmailAddress.Address)
 
 	var response interface{}
-	err := g.makePostAPICall(resource, mail, &response)
+	err := g.makePOSTAPICall(ctx, resource, mail, &response, opts...)
 
 	return err
 }
 
-// ListUsers returns a list of all users
+// ListUsers returns a list of all users. The opts are applied to the API-call of every page, e.g. Select to
+// reduce the size of large collections or OrderBy.
 //
 // Reference: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/user_list
-func (g *GraphClient) ListUsers() (Users, error) {
+func (g *GraphClient) ListUsers(opts ...RequestOption) (Users, error) {
+	return g.ListUsersContext(context.Background(), opts...)
+}
+
+// ListUsersContext is ListUsers with a context.
+func (g *GraphClient) ListUsersContext(ctx context.Context, opts ...RequestOption) (Users, error) {
+	return g.ListUsersWithFilterContext(ctx, "", opts...)
+}
+
+// ListUsersWithFilter returns all users that match the given OData $filter, e.g. "accountEnabled eq true" or
+// "startswith(displayName,'A')", all users are returned if the filter is empty. Advanced queries, e.g. endsWith
+// on mail, require the opt AdvancedQuery.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list#optional-query-parameters
+func (g *GraphClient) ListUsersWithFilter(filter string, opts ...RequestOption) (Users, error) {
+	return g.ListUsersWithFilterContext(context.Background(), filter, opts...)
+}
+
+// ListUsersWithFilterContext is ListUsersWithFilter with a context.
+func (g *GraphClient) ListUsersWithFilterContext(ctx context.Context, filter string, opts ...RequestOption) (Users, error) {
 	resource := "/users"
+	getParams := url.Values{}
+	if filter != "" {
+		getParams.Add("$filter", filter)
+	}
 	var marsh struct {
 		Users Users `json:"value"`
 	}
-	err := g.makeGETAPICall(resource, nil, &marsh)
+	err := g.makeGETAPICall(ctx, resource, getParams, &marsh, opts...)
 	marsh.Users.setGraphClient(g)
 	return marsh.Users, err
 }
 
-// ListGroups returns a list of all groups
+// SearchUsers returns the users that match the given free text query of the form "property:text", e.g.
+// "displayName:john", see Search. The opts are applied to the API-call of every page, e.g. OrderBy.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/search-query-parameter#using-search-on-directory-object-collections
+func (g *GraphClient) SearchUsers(query string, opts ...RequestOption) (Users, error) {
+	return g.SearchUsersContext(context.Background(), query, opts...)
+}
+
+// SearchUsersContext is SearchUsers with a context.
+func (g *GraphClient) SearchUsersContext(ctx context.Context, query string, opts ...RequestOption) (Users, error) {
+	return g.ListUsersContext(ctx, append([]RequestOption{Search(query)}, opts...)...)
+}
+
+// ListUsersUntil pages through all users and returns the first user the predicate returns true for. Paging
+// stops as soon as the user has been found, hence use it to find a user by a condition that cannot be
+// expressed as $filter. Returns ErrFindUser if no user matches.
+//
+// Reference: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/user_list
+func (g *GraphClient) ListUsersUntil(predicate func(User) bool) (User, error) {
+	return g.ListUsersUntilContext(context.Background(), predicate)
+}
+
+// ListUsersUntilContext is ListUsersUntil with a context.
+func (g *GraphClient) ListUsersUntilContext(ctx context.Context, predicate func(User) bool) (User, error) {
+	var match User
+	var found bool
+	err := g.makePagedGETAPICall(ctx, "/users", nil, func(value json.RawMessage) (bool, error) {
+		var page Users
+		if err := json.Unmarshal(value, &page); err != nil {
+			return false, err
+		}
+		for _, user := range page {
+			if predicate(user) {
+				match, found = user, true
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return User{}, err
+	}
+	if !found {
+		return User{}, ErrFindUser
+	}
+	match.setGraphClient(g)
+	return match, nil
+}
+
+// ListGroups returns a list of all groups. The opts are applied to the API-call of every page, e.g. OrderBy.
 //
 // Reference: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/group_list
-func (g *GraphClient) ListGroups() (Groups, error) {
+func (g *GraphClient) ListGroups(opts ...RequestOption) (Groups, error) {
+	return g.ListGroupsContext(context.Background(), opts...)
+}
+
+// ListGroupsContext is ListGroups with a context.
+func (g *GraphClient) ListGroupsContext(ctx context.Context, opts ...RequestOption) (Groups, error) {
+	return g.ListGroupsWithFilterContext(ctx, "", opts...)
+}
+
+// ListGroupsWithFilter returns all groups that match the given OData $filter, e.g. "securityEnabled eq true" or
+// "groupTypes/any(c:c eq 'Unified')", all groups are returned if the filter is empty. Advanced queries require the
+// opt AdvancedQuery.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-list#optional-query-parameters
+func (g *GraphClient) ListGroupsWithFilter(filter string, opts ...RequestOption) (Groups, error) {
+	return g.ListGroupsWithFilterContext(context.Background(), filter, opts...)
+}
+
+// ListGroupsWithFilterContext is ListGroupsWithFilter with a context.
+func (g *GraphClient) ListGroupsWithFilterContext(ctx context.Context, filter string, opts ...RequestOption) (Groups, error) {
 	resource := "/groups"
+	getParams := url.Values{}
+	if filter != "" {
+		getParams.Add("$filter", filter)
+	}
 
 	var marsh struct {
 		Groups Groups `json:"value"`
 	}
-	err := g.makeGETAPICall(resource, nil, &marsh)
+	err := g.makeGETAPICall(ctx, resource, getParams, &marsh, opts...)
 	marsh.Groups.setGraphClient(g)
 	return marsh.Groups, err
 }
 
+// SearchGroups returns the groups that match the given free text query of the form "property:text", e.g.
+// "displayName:sales", see Search. The opts are applied to the API-call of every page, e.g. OrderBy.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/search-query-parameter#using-search-on-directory-object-collections
+func (g *GraphClient) SearchGroups(query string, opts ...RequestOption) (Groups, error) {
+	return g.SearchGroupsContext(context.Background(), query, opts...)
+}
+
+// SearchGroupsContext is SearchGroups with a context.
+func (g *GraphClient) SearchGroupsContext(ctx context.Context, query string, opts ...RequestOption) (Groups, error) {
+	return g.ListGroupsContext(ctx, append([]RequestOption{Search(query)}, opts...)...)
+}
+
 // GetUser returns the user object associated to the given user identified by either
-// the given ID or userPrincipalName
+// the given ID or userPrincipalName. The opts are applied to the API-call, e.g. Select.
+// The error matches ErrNotFound with errors.Is if there is no such user.
 //
 // Reference: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/user_get
-func (g *GraphClient) GetUser(identifier string) (User, error) {
+func (g *GraphClient) GetUser(identifier string, opts ...RequestOption) (User, error) {
+	return g.GetUserContext(context.Background(), identifier, opts...)
+}
+
+// GetUserContext is GetUser with a context.
+func (g *GraphClient) GetUserContext(ctx context.Context, identifier string, opts ...RequestOption) (User, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return User{}, err
+	}
 	resource := fmt.Sprintf("/users/%v", identifier)
 	user := User{graphClient: g}
-	err := g.makeGETAPICall(resource, nil, &user)
+	err := g.makeGETAPICall(ctx, resource, nil, &user, opts...)
 	return user, err
 }
 
+// UpdateUser updates the properties of the user identified by either the given ID or userPrincipalName that are
+// set in update, e.g. the PasswordPolicies of a service account. The update is validated before the API-call is performed.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-update
+func (g *GraphClient) UpdateUser(identifier string, update UserUpdate) error {
+	return g.UpdateUserContext(context.Background(), identifier, update)
+}
+
+// UpdateUserContext is UpdateUser with a context.
+func (g *GraphClient) UpdateUserContext(ctx context.Context, identifier string, update UserUpdate) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	resource := fmt.Sprintf("/users/%v", identifier)
+	return g.makePATCHAPICall(ctx, resource, update, nil)
+}
+
+// ResetUserPassword sets the password of the user identified by either the given ID or userPrincipalName to the
+// given temporary password. With forceChange the user has to change it at the next sign-in. Returns an error without
+// performing the API-call if the password is shorter than MinPasswordLength, and an error including the message of
+// msgraph if the password violates the password policy of the tenant.
+//
+// Resetting the password of another user requires the User-PasswordProfile.ReadWrite.All permission, of an
+// administrator a suitable administrator role.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-update
+func (g *GraphClient) ResetUserPassword(identifier, temporaryPassword string, forceChange bool) error {
+	return g.ResetUserPasswordContext(context.Background(), identifier, temporaryPassword, forceChange)
+}
+
+// ResetUserPasswordContext is ResetUserPassword with a context.
+func (g *GraphClient) ResetUserPasswordContext(ctx context.Context, identifier, temporaryPassword string, forceChange bool) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	switch {
+	case temporaryPassword == "":
+		return fmt.Errorf("password of user %v must not be empty", identifier)
+	case len([]rune(temporaryPassword)) < MinPasswordLength:
+		return fmt.Errorf("password of user %v must have at least %v characters", identifier, MinPasswordLength)
+	}
+	body := struct {
+		PasswordProfile PasswordProfile `json:"passwordProfile"`
+	}{PasswordProfile{Password: temporaryPassword, ForceChangePasswordNextSignIn: forceChange}}
+	err := g.makePATCHAPICall(ctx, fmt.Sprintf("/users/%v", identifier), body, nil)
+	if message := odataErrorMessage(err); hasStatusCode(err, http.StatusBadRequest) && message != "" {
+		return fmt.Errorf("cannot reset password of user %v: %v: %w", identifier, message, err)
+	}
+	return err
+}
+
+// CreateUser creates the given user and returns it as created by msgraph, e.g. with its ID. The user is validated
+// before the API-call is performed.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-post-users
+func (g *GraphClient) CreateUser(user UserCreate) (User, error) {
+	return g.CreateUserContext(context.Background(), user)
+}
+
+// CreateUserContext is CreateUser with a context.
+func (g *GraphClient) CreateUserContext(ctx context.Context, user UserCreate) (User, error) {
+	if err := user.Validate(); err != nil {
+		return User{}, err
+	}
+	if user.UsageLocation == "" {
+		user.UsageLocation = g.DefaultUsageLocation
+	}
+	created := User{graphClient: g}
+	err := g.makePOSTAPICall(ctx, "/users", user, &created)
+	return created, err
+}
+
+// DeleteUser deletes the user identified by either the given ID or userPrincipalName. Deleted users are kept
+// in the deleted items of the directory for 30 days and can be restored within that time with RestoreDeletedUser,
+// GetUser returns a 404 for them meanwhile.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-delete
+func (g *GraphClient) DeleteUser(identifier string) error {
+	return g.DeleteUserContext(context.Background(), identifier)
+}
+
+// DeleteUserContext is DeleteUser with a context.
+func (g *GraphClient) DeleteUserContext(ctx context.Context, identifier string) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	return g.makeDELETEAPICall(ctx, fmt.Sprintf("/users/%v", identifier))
+}
+
+// RevokeUserSignInSessions invalidates the refresh tokens and session cookies of the user identified by either the
+// given ID or userPrincipalName, e.g. of a compromised account, hence the user has to sign in again. Access tokens
+// that have already been issued stay valid until they expire, unless the application supports Continuous Access
+// Evaluation.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-revokesigninsessions
+func (g *GraphClient) RevokeUserSignInSessions(identifier string) error {
+	return g.RevokeUserSignInSessionsContext(context.Background(), identifier)
+}
+
+// RevokeUserSignInSessionsContext is RevokeUserSignInSessions with a context.
+func (g *GraphClient) RevokeUserSignInSessionsContext(ctx context.Context, identifier string) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	var result struct {
+		Value bool `json:"value"`
+	}
+	if err := g.makePOSTAPICall(ctx, fmt.Sprintf("/users/%v/revokeSignInSessions", identifier), nil, &result); err != nil {
+		return err
+	}
+	if !result.Value {
+		return fmt.Errorf("msgraph did not revoke the sign-in sessions of user %v", identifier)
+	}
+	return nil
+}
+
 // GetGroup returns the group object identified by the given groupID.
 //
 // Reference: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/group_get
 func (g *GraphClient) GetGroup(groupID string) (Group, error) {
+	return g.GetGroupContext(context.Background(), groupID)
+}
+
+// GetGroupContext is GetGroup with a context.
+func (g *GraphClient) GetGroupContext(ctx context.Context, groupID string) (Group, error) {
 	resource := fmt.Sprintf("/groups/%v", groupID)
 	group := Group{graphClient: g}
-	err := g.makeGETAPICall(resource, nil, &group)
+	err := g.makeGETAPICall(ctx, resource, nil, &group)
 	return group, err
 }
 
+// DeleteGroup deletes the group identified by the given groupID. Deleted Microsoft 365 groups are kept for
+// 30 days and can be restored within that time, security groups are deleted permanently.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-delete
+func (g *GraphClient) DeleteGroup(groupID string) error {
+	return g.DeleteGroupContext(context.Background(), groupID)
+}
+
+// DeleteGroupContext is DeleteGroup with a context.
+func (g *GraphClient) DeleteGroupContext(ctx context.Context, groupID string) error {
+	return g.makeDELETEAPICall(ctx, fmt.Sprintf("/groups/%v", groupID))
+}
+
 // UnmarshalJSON implements the json unmarshal to be used by the json-library.
 // This method additionally to loading the TenantID, ApplicationID and ClientSecret
 // immediately gets a Token from msgraph (hence initialize this GraphAPI instance)
 // and returns an error if any of the data provided is incorrect or the token cannot be acquired
 func (g *GraphClient) UnmarshalJSON(data []byte) error {
 	tmp := struct {
-		TenantID      string
-		ApplicationID string
-		ClientSecret  string
+		TenantID             string
+		ApplicationID        string
+		ClientSecret         string
+		DefaultUsageLocation string
 	}{}
 
 	err := json.Unmarshal(data, &tmp)
@@ -276,11 +1202,12 @@ func (g *GraphClient) UnmarshalJSON(data []byte) error {
 	if g.ClientSecret == "" {
 		return fmt.Errorf("ClientSecret is empty")
 	}
+	g.DefaultUsageLocation = tmp.DefaultUsageLocation
 
 	// get a token and return the error (if any)
-	err = g.refreshToken()
+	err = g.refreshToken(context.Background())
 	if err != nil {
-		return fmt.Errorf("can't get Token: %v", err)
+		return fmt.Errorf("can't get Token: %w", err)
 	}
 	return nil
 }
@@ -1,10 +1,22 @@
 package msgraph
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // get graph client config from environment
@@ -26,6 +38,35 @@ var (
 // the graphclient used to perform all tests
 var graphClient, _ = NewGraphClient(msGraphTenantID, msGraphApplicationID, msGraphClientSecret)
 
+// rewriteTransport redirects all requests to the target server, regardless of the requested host
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (r rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	return r.next.RoundTrip(req)
+}
+
+// newTestGraphClient returns a GraphClient with a valid dummy token. All requests of the GraphClient
+// are served by the given handler instead of the real msgraph API, hence no credentials are needed.
+func newTestGraphClient(t testing.TB, handler http.Handler) *GraphClient {
+	server := httptest.NewServer(handler)
+	target, _ := url.Parse(server.URL)
+	origTransport := http.DefaultTransport
+	http.DefaultTransport = rewriteTransport{target: target, next: origTransport}
+	t.Cleanup(func() {
+		http.DefaultTransport = origTransport
+		server.Close()
+	})
+	return &GraphClient{
+		TenantID: "test-tenant", ApplicationID: "test-application", ClientSecret: "test-secret",
+		token: Token{TokenType: "Bearer", AccessToken: "test-token", NotBefore: time.Now().Add(-time.Minute), ExpiresOn: time.Now().Add(time.Hour)},
+	}
+}
+
 func TestEnvironmentVariablesPresent(t *testing.T) {
 	if msGraphTenantID == "" {
 		t.Fatal("Environment Variable for Tenant ID named <MSGraphTenantID> is mising!")
@@ -293,3 +334,759 @@ func TestGraphClient_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphClient_ListUsersUntil(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Query().Get("$skiptoken") {
+		case "":
+			fmt.Fprint(w, `{"@odata.nextLink": "https://graph.microsoft.com/v1.0/users?$skiptoken=page2",
+				"value": [{"id": "u1", "userPrincipalName": "alice@contoso.com"}, {"id": "u2", "userPrincipalName": "bob@contoso.com", "mobilePhone": "+43 1"}]}`)
+		case "page2":
+			fmt.Fprint(w, `{"@odata.nextLink": "https://graph.microsoft.com/v1.0/users?$skiptoken=page3",
+				"value": [{"id": "u3", "userPrincipalName": "carol@contoso.com", "mobilePhone": "+49 1"}]}`)
+		default:
+			fmt.Fprint(w, `{"value": []}`)
+		}
+	})
+	g := newTestGraphClient(t, mux)
+
+	tests := []struct {
+		name         string
+		predicate    func(User) bool
+		wantID       string
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "first page", predicate: func(u User) bool { return strings.HasPrefix(u.MobilePhone, "+43") }, wantID: "u2", wantRequests: 1},
+		{name: "second page", predicate: func(u User) bool { return strings.HasPrefix(u.MobilePhone, "+49") }, wantID: "u3", wantRequests: 2},
+		{name: "no match", predicate: func(u User) bool { return false }, wantRequests: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			got, err := g.ListUsersUntil(tt.predicate)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GraphClient.ListUsersUntil() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.ID != tt.wantID || requests != tt.wantRequests {
+				t.Errorf("GraphClient.ListUsersUntil() = %v after %v requests, want %v after %v requests", got.ID, requests, tt.wantID, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestGraphClient_Clone(t *testing.T) {
+	mux := http.NewServeMux()
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"id": "slow"}`)
+	}
+	mux.HandleFunc("/v1.0/users/slow", slow)
+	mux.HandleFunc("/v1.0/users", slow)
+	g := newTestGraphClient(t, mux)
+	g.DefaultUsageLocation = "AT"
+
+	clone, err := g.Clone(WithTimeout(50 * time.Millisecond))
+	if err != nil {
+		t.Fatalf("GraphClient.Clone() error = %v", err)
+	}
+	if clone.TenantID != g.TenantID || clone.DefaultUsageLocation != "AT" || clone.token.AccessToken != g.token.AccessToken {
+		t.Errorf("GraphClient.Clone() = %v, want the configuration of %v", clone, g)
+	}
+	if _, err := clone.GetUser("slow"); err == nil {
+		t.Errorf("GraphClient.GetUser() of the clone error = nil, want a timeout")
+	}
+	if _, err := clone.ListUsersWithFilter("displayName eq 'Secret'"); err == nil || !strings.Contains(err.Error(), "timed out") ||
+		!strings.Contains(err.Error(), "/v1.0/users") || strings.Contains(err.Error(), "Secret") {
+		t.Errorf("GraphClient.ListUsersWithFilter() of the clone error = %v, want a timeout with the URL without its query", err)
+	}
+	if _, err := g.GetUser("slow"); err != nil {
+		t.Errorf("GraphClient.GetUser() error = %v, the timeout of the clone must not apply", err)
+	}
+	broken, err := g.Clone(WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset by peer")
+	})}))
+	if err != nil {
+		t.Fatalf("GraphClient.Clone() error = %v", err)
+	}
+	if _, err := broken.ListUsersWithFilter("displayName eq 'Secret'"); err == nil || !strings.Contains(err.Error(), "connection reset by peer") ||
+		!strings.Contains(err.Error(), "/v1.0/users") || strings.Contains(err.Error(), "Secret") {
+		t.Errorf("GraphClient.ListUsersWithFilter() with a broken connection error = %v, want the URL without its query", err)
+	}
+
+	clone.token.AccessToken = "other"
+	if g.token.AccessToken == "other" {
+		t.Errorf("GraphClient.Clone() shares the token state")
+	}
+	if _, err := g.Clone(WithTimeout(0)); err == nil {
+		t.Errorf("GraphClient.Clone(WithTimeout(0)) error = nil, want an error")
+	}
+}
+
+func TestGraphClient_UpdateUser(t *testing.T) {
+	var gotBody string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/svc-backup@contoso.com", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	g := newTestGraphClient(t, mux)
+
+	err := g.UpdateUser("svc-backup@contoso.com", UserUpdate{PasswordPolicies: PasswordPolicies{PasswordPolicyDisablePasswordExpiration}})
+	if err != nil {
+		t.Fatalf("GraphClient.UpdateUser() error = %v", err)
+	}
+	if want := `{"passwordPolicies":"DisablePasswordExpiration"}`; gotBody != want {
+		t.Errorf("GraphClient.UpdateUser() body = %v, want %v", gotBody, want)
+	}
+
+	jobTitle, empty := "Engineer", ""
+	err = g.UpdateUser("svc-backup@contoso.com", UserUpdate{JobTitle: &jobTitle, OfficeLocation: &empty})
+	if err != nil {
+		t.Fatalf("GraphClient.UpdateUser() error = %v", err)
+	}
+	// nil properties, e.g. the department, are not sent, hence not overwritten on the server
+	if want := `{"jobTitle":"Engineer","officeLocation":""}`; gotBody != want {
+		t.Errorf("GraphClient.UpdateUser() body = %v, want %v", gotBody, want)
+	}
+
+	err = g.UpdateUser("svc-backup@contoso.com", UserUpdate{PasswordPolicies: PasswordPolicies{"NeverExpire"}})
+	if err == nil {
+		t.Errorf("GraphClient.UpdateUser() with an invalid PasswordPolicy error = nil, want an error")
+	}
+}
+
+func TestGraphClient_ResetUserPassword(t *testing.T) {
+	var bodies []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if strings.Contains(string(body), "password123") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": "Request_BadRequest", "message": "The specified password does not comply with password complexity requirements."}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	g := newTestGraphClient(t, mux)
+
+	if err := g.ResetUserPassword("u1", "Xk3!pQ9#vL", true); err != nil {
+		t.Errorf("GraphClient.ResetUserPassword() error = %v", err)
+	}
+	want := `{"passwordProfile":{"forceChangePasswordNextSignIn":true,"forceChangePasswordNextSignInWithMfa":false,"password":"Xk3!pQ9#vL"}}`
+	if len(bodies) != 1 || bodies[0] != want {
+		t.Errorf("request bodies = %v, want %v", bodies, want)
+	}
+	for _, password := range []string{"", "short"} {
+		if err := g.ResetUserPassword("u1", password, true); err == nil || len(bodies) != 1 {
+			t.Errorf("GraphClient.ResetUserPassword(%q) error = %v after %v requests, want an error without request", password, err, len(bodies))
+		}
+	}
+	err := g.ResetUserPassword("u1", "password123", false)
+	if !hasStatusCode(err, http.StatusBadRequest) || !strings.Contains(err.Error(), "cannot reset password of user u1: The specified password does not comply") {
+		t.Errorf("GraphClient.ResetUserPassword() of a weak password error = %v, want the message of msgraph", err)
+	}
+}
+
+func TestGraphClient_Beta(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Path)
+		fmt.Fprint(w, `{"id": "u1", "value": []}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	if _, err := g.Beta().GetUser("u1"); err != nil {
+		t.Fatalf("GraphClient.Beta().GetUser() error = %v", err)
+	}
+	if _, err := g.GetUser("u1"); err != nil {
+		t.Fatalf("GraphClient.GetUser() error = %v", err)
+	}
+	if _, err := (User{ID: "u1", graphClient: g}).ListSensitivityLabels(); err != nil {
+		t.Fatalf("User.ListSensitivityLabels() error = %v", err)
+	}
+	want := "[/beta/users/u1 /v1.0/users/u1 /beta/users/u1/informationProtection/policy/labels]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("request paths = %v, want %v", got, want)
+	}
+}
+
+func TestGraphClient_ListUsersContext(t *testing.T) {
+	release := make(chan struct{})
+	block := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", block)
+	mux.HandleFunc("/test-tenant/oauth2/token", block)
+	g := newTestGraphClient(t, mux)
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := g.ListUsersContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GraphClient.ListUsersContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// waiting for a running API-call is abandoned as well
+	g.tokenLock.Lock()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.GetUserContext(canceled, "u1"); err != context.Canceled {
+		t.Errorf("GraphClient.GetUserContext() while locked error = %v, want %v", err, context.Canceled)
+	}
+	g.tokenLock.Unlock()
+
+	// the token refresh is canceled by the same context
+	g.token.ExpiresOn = time.Now()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := g.GetUserContext(ctx, "u1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GraphClient.GetUserContext() refreshing the token error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGraphClient_ListUsers_paging(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$skiptoken") == "page2" {
+			fmt.Fprint(w, `{"value": [{"id": "u3"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#users", "value": [{"id": "u1"}, {"id": "u2"}],
+			"@odata.nextLink": "https://graph.microsoft.com/v1.0/users?$top=999&$skiptoken=page2"}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	users, err := g.ListUsers()
+	if err != nil {
+		t.Fatalf("GraphClient.ListUsers() error = %v", err)
+	}
+	var ids []string
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	if fmt.Sprint(ids) != "[u1 u2 u3]" {
+		t.Errorf("GraphClient.ListUsers() IDs = %v, want [u1 u2 u3]", ids)
+	}
+}
+
+func TestGraphClient_ListWithFilter(t *testing.T) {
+	var rawQueries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		rawQueries = append(rawQueries, r.URL.RawQuery)
+		if r.Header.Get("ConsistencyLevel") != "eventual" {
+			t.Errorf("ConsistencyLevel = %q, want eventual", r.Header.Get("ConsistencyLevel"))
+		}
+		fmt.Fprint(w, `{"value": [{"id": "u1", "displayName": "Alice"}]}`)
+	})
+	mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		rawQueries = append(rawQueries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"value": [{"id": "g1"}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	users, err := g.ListUsersWithFilter("startswith(displayName,'A')", ConsistencyLevelEventual())
+	if err != nil || len(users) != 1 || users[0].graphClient != g {
+		t.Errorf("GraphClient.ListUsersWithFilter() = %v, %v, want u1 with its GraphClient", users, err)
+	}
+	if groups, err := g.ListGroupsWithFilter("securityEnabled eq true"); err != nil || len(groups) != 1 {
+		t.Errorf("GraphClient.ListGroupsWithFilter() = %v, %v, want g1", groups, err)
+	}
+	want := []string{"%24filter=startswith%28displayName%2C%27A%27%29&%24top=999", "%24filter=securityEnabled+eq+true&%24top=999"}
+	if !reflect.DeepEqual(rawQueries, want) {
+		t.Errorf("raw queries = %q, want %q", rawQueries, want)
+	}
+}
+
+func TestGraphClient_makeGETAPICall_paging(t *testing.T) {
+	page := func(from, to int, nextLink string) string {
+		var groups []string
+		for i := from; i < to; i++ {
+			groups = append(groups, fmt.Sprintf(`{"id": "g%v", "displayName": "Group %v"}`, i, i))
+		}
+		if nextLink != "" {
+			nextLink = fmt.Sprintf(`, "@odata.nextLink": "https://graph.microsoft.com/v1.0/groups?$top=10&$skiptoken=%v"`, nextLink)
+		}
+		return fmt.Sprintf(`{"value": [%v]%v}`, strings.Join(groups, ", "), nextLink)
+	}
+	paged := http.NewServeMux()
+	paged.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Query()["$top"]) != 1 {
+			t.Errorf("request %v has %v $top parameters, want 1", r.URL, len(r.URL.Query()["$top"]))
+		}
+		switch r.URL.Query().Get("$skiptoken") {
+		case "":
+			fmt.Fprint(w, page(0, 10, "p2"))
+		case "p2":
+			fmt.Fprint(w, page(10, 20, "p3"))
+		case "p3":
+			fmt.Fprint(w, page(20, 30, ""))
+		}
+	})
+	single := http.NewServeMux()
+	single.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page(0, 30, ""))
+	})
+
+	pagedGroups, err := newTestGraphClient(t, paged).ListGroups()
+	if err != nil {
+		t.Fatalf("GraphClient.ListGroups() of three pages error = %v", err)
+	}
+	singleGroups, err := newTestGraphClient(t, single).ListGroups()
+	if err != nil {
+		t.Fatalf("GraphClient.ListGroups() of a single page error = %v", err)
+	}
+	if len(pagedGroups) != 30 || fmt.Sprint(pagedGroups) != fmt.Sprint(singleGroups) {
+		t.Errorf("GraphClient.ListGroups() of three pages = %v, want %v", pagedGroups, singleGroups)
+	}
+}
+
+func TestGraphClient_makePOSTAPICall(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("request = %v %v %v, want an authorized json POST", r.Method, r.Header.Get("Content-Type"), r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": "g1", "displayName": %v}`, strings.TrimPrefix(strings.TrimSuffix(string(body), "}"), `{"displayName":`))
+	})
+	g := newTestGraphClient(t, mux)
+
+	var created struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	}
+	err := g.makePOSTAPICall(context.Background(), "/groups", map[string]string{"displayName": "Marketing"}, &created)
+	if err != nil || created.ID != "g1" || created.DisplayName != "Marketing" {
+		t.Errorf("GraphClient.makePOSTAPICall() = %+v, %v, want the created group g1", created, err)
+	}
+}
+
+// roundTripperFunc serves the requests of an http.Client without network access
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewGraphClient_WithHTTPClient(t *testing.T) {
+	var requests []string
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Host+req.URL.Path)
+		body := `{"value": [{"id": "u1"}]}`
+		if strings.HasSuffix(req.URL.Path, "/oauth2/token") {
+			body = fmt.Sprintf(`{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "access_token": "%v"}`,
+				time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix(), testAppToken)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+	})}
+
+	g, err := NewGraphClient("tenant", "app", "secret", WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("NewGraphClient() error = %v", err)
+	}
+	if _, err := g.ListUsers(); err != nil {
+		t.Fatalf("GraphClient.ListUsers() error = %v", err)
+	}
+	want := "[login.microsoftonline.com/tenant/oauth2/token graph.microsoft.com/v1.0/users]"
+	if fmt.Sprint(requests) != want {
+		t.Errorf("requests of the http.Client = %v, want %v", requests, want)
+	}
+
+	if _, err := NewGraphClient("tenant", "app", "secret", WithHTTPClient(nil)); err == nil {
+		t.Errorf("NewGraphClient(WithHTTPClient(nil)) error = nil, want an error")
+	}
+}
+
+func TestGraphClient_SetHTTPClient(t *testing.T) {
+	g := newTestGraphClient(t, http.NewServeMux())
+	var requested bool
+	g.SetHTTPClient(&http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requested = true
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{"id": "u1"}`)), Request: req}, nil
+	})})
+	if user, err := g.GetUser("u1"); err != nil || user.ID != "u1" || !requested {
+		t.Errorf("GraphClient.GetUser() with SetHTTPClient = %v, %v, requested = %v", user.ID, err, requested)
+	}
+
+	g.SetHTTPClient(nil) // the default http.Client reaches the test server, which does not know the user
+	if _, err := g.GetUser("u1"); !hasStatusCode(err, http.StatusNotFound) {
+		t.Errorf("GraphClient.GetUser() with the default http.Client error = %v, want StatusCode 404", err)
+	}
+
+	// SetHTTPClient may be called while g is cloned, e.g. by Beta, see go test -race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			g.SetHTTPClient(&http.Client{})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		g.Beta()
+	}
+	<-done
+}
+
+func TestGraphClient_makePATCHAPICall(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v1.0/me/messages/m1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "m1", "isRead": true}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	var group Group
+	if err := g.makePATCHAPICall(context.Background(), "/groups/g1", map[string]string{"description": "Sales"}, &group); err != nil {
+		t.Errorf("GraphClient.makePATCHAPICall() with 204 No Content error = %v, want nil", err)
+	}
+	var message struct {
+		IsRead bool `json:"isRead"`
+	}
+	if err := g.makePATCHAPICall(context.Background(), "/me/messages/m1", map[string]bool{"isRead": true}, &message); err != nil || !message.IsRead {
+		t.Errorf("GraphClient.makePATCHAPICall() with 200 OK = %+v, %v, want the updated message", message, err)
+	}
+}
+
+func TestGraphClient_WithMaxRetries(t *testing.T) {
+	origDelay := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = origDelay }()
+
+	calls := map[string]int{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/throttled", func(w http.ResponseWriter, r *http.Request) {
+		if calls[r.URL.Path]++; calls[r.URL.Path] == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"id": "throttled"}`)
+	})
+	mux.HandleFunc("/v1.0/users/unavailable", func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/v1.0/users/failing/sendMail", func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/v1.0/users/unavailable/sendMail", func(w http.ResponseWriter, r *http.Request) {
+		if calls[r.URL.Path]++; calls[r.URL.Path] == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	g := newTestGraphClient(t, mux)
+	g, err := g.Clone(WithMaxRetries(2))
+	if err != nil {
+		t.Fatalf("GraphClient.Clone() error = %v", err)
+	}
+
+	if user, err := g.GetUser("throttled"); err != nil || user.ID != "throttled" || calls["/v1.0/users/throttled"] != 2 {
+		t.Errorf("GraphClient.GetUser() of a throttled user = %v, %v after %v calls, want the user after 2", user.ID, err, calls["/v1.0/users/throttled"])
+	}
+	_, err = g.GetUser("unavailable")
+	if !hasStatusCode(err, http.StatusServiceUnavailable) || !strings.Contains(err.Error(), "gave up after 2 retries") || calls["/v1.0/users/unavailable"] != 3 {
+		t.Errorf("GraphClient.GetUser() of an unavailable user error = %v after %v calls, want StatusCode 503 after 3", err, calls["/v1.0/users/unavailable"])
+	}
+	if _, err := g.GetUser("unknown"); !hasStatusCode(err, http.StatusNotFound) || calls["/v1.0/users/unknown"] != 1 {
+		t.Errorf("GraphClient.GetUser() of an unknown user error = %v after %v calls, want StatusCode 404 without retries", err, calls["/v1.0/users/unknown"])
+	}
+
+	if err := g.makePOSTAPICall(context.Background(), "/users/failing/sendMail", MakeMail(), nil); !hasStatusCode(err, http.StatusInternalServerError) || calls["/v1.0/users/failing/sendMail"] != 1 {
+		t.Errorf("POST with StatusCode 500 error = %v after %v calls, want StatusCode 500 without retries", err, calls["/v1.0/users/failing/sendMail"])
+	}
+	if err := g.makePOSTAPICall(context.Background(), "/users/failing/sendMail", MakeMail(), nil, RetryServerErrors()); !hasStatusCode(err, http.StatusInternalServerError) || calls["/v1.0/users/failing/sendMail"] != 4 {
+		t.Errorf("POST with RetryServerErrors and StatusCode 500 error = %v after %v calls, want StatusCode 500 after 4", err, calls["/v1.0/users/failing/sendMail"])
+	}
+	if err := g.makePOSTAPICall(context.Background(), "/users/unavailable/sendMail", MakeMail(), nil); err != nil || calls["/v1.0/users/unavailable/sendMail"] != 2 {
+		t.Errorf("POST with StatusCode 503 and Retry-After error = %v after %v calls, want nil after 2", err, calls["/v1.0/users/unavailable/sendMail"])
+	}
+
+	if _, err := NewGraphClient("tenant", "app", "secret", WithMaxRetries(-1)); err == nil {
+		t.Errorf("NewGraphClient(WithMaxRetries(-1)) error = nil, want an error")
+	}
+}
+
+func BenchmarkGraphClient_GetUser(b *testing.B) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "u1", "displayName": "Alice"}`)
+	})
+	g := newTestGraphClient(b, mux)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.GetUser("u1"); err != nil {
+			b.Fatalf("GraphClient.GetUser() error = %v", err)
+		}
+	}
+}
+
+func TestGraphClient_claimsChallenge(t *testing.T) {
+	const claims = `{"access_token":{"nbf":{"essential":true,"value":"1604106651"}}}`
+	challenge := fmt.Sprintf(`Bearer realm="", authorization_uri="https://login.microsoftonline.com/common/oauth2/authorize", error="insufficient_claims", claims="%v"`,
+		base64.StdEncoding.EncodeToString([]byte(claims)))
+	var tokenRequests []url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/test-tenant/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		tokenRequests = append(tokenRequests, r.PostForm)
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "access_token": "refreshed-token"}`,
+			time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix())
+	})
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer refreshed-token" {
+			w.Header().Set("WWW-Authenticate", challenge)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	mux.HandleFunc("/v1.0/users/revoked", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", challenge)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	g := newTestGraphClient(t, mux)
+	g.tokenScopes = []string{"openid"}
+
+	if user, err := g.GetUser("u1"); err != nil || user.ID != "u1" {
+		t.Fatalf("GraphClient.GetUser() after a claims challenge = %v, %v, want the user", user.ID, err)
+	}
+	if len(tokenRequests) != 1 || tokenRequests[0].Get("claims") != claims || tokenRequests[0].Get("scope") != "openid" {
+		t.Errorf("token requests = %v, want one with the claims %v and scope openid", tokenRequests, claims)
+	}
+
+	_, err := g.GetUser("revoked")
+	if !errors.Is(err, ErrClaimsChallenge) || !strings.Contains(err.Error(), claims) || len(tokenRequests) != 2 {
+		t.Errorf("GraphClient.GetUser() of a repeated claims challenge error = %v after %v token requests, want %v with the claims after 2",
+			err, len(tokenRequests), ErrClaimsChallenge)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GraphClient.GetUser() of a repeated claims challenge error = %v, want it to wrap %v", err, ErrUnauthorized)
+	}
+	if tokenRequests[1].Get("resource") != BaseURL {
+		t.Errorf("token request resource = %v, want %v", tokenRequests[1].Get("resource"), BaseURL)
+	}
+}
+
+func TestGraphClient_Delete(t *testing.T) {
+	var deleted []string
+	mux := http.NewServeMux()
+	for _, path := range []string{"/v1.0/users/u1", "/v1.0/groups/g1", "/v1.0/users/u1/events/e1"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodDelete {
+				t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+			}
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+	g := newTestGraphClient(t, mux)
+
+	if err := g.DeleteUser("u1"); err != nil {
+		t.Errorf("GraphClient.DeleteUser() error = %v", err)
+	}
+	if err := g.DeleteGroup("g1"); err != nil {
+		t.Errorf("GraphClient.DeleteGroup() error = %v", err)
+	}
+	if err := g.DeleteCalendarEvent("u1", "e1"); err != nil {
+		t.Errorf("GraphClient.DeleteCalendarEvent() error = %v", err)
+	}
+	if want := "[/v1.0/users/u1 /v1.0/groups/g1 /v1.0/users/u1/events/e1]"; fmt.Sprint(deleted) != want {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+	if err := g.DeleteUser("unknown"); !hasStatusCode(err, http.StatusNotFound) {
+		t.Errorf("GraphClient.DeleteUser() of an unknown user error = %v, want StatusCode 404", err)
+	}
+}
+
+func TestGraphClient_RevokeUserSignInSessions(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		value := strings.HasPrefix(r.URL.Path, "/v1.0/users/alice@contoso.com/")
+		fmt.Fprintf(w, `{"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#Edm.Boolean", "value": %v}`, value)
+	})
+	g := newTestGraphClient(t, mux)
+
+	if err := g.RevokeUserSignInSessions("alice@contoso.com"); err != nil {
+		t.Errorf("GraphClient.RevokeUserSignInSessions() error = %v", err)
+	}
+	if err := g.RevokeUserSignInSessions("bob@contoso.com"); err == nil {
+		t.Errorf("GraphClient.RevokeUserSignInSessions() with value false error = nil, want an error")
+	}
+	if want := "[POST /v1.0/users/alice@contoso.com/revokeSignInSessions POST /v1.0/users/bob@contoso.com/revokeSignInSessions]"; fmt.Sprint(requests) != want {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestGraphClient_clientRequestID(t *testing.T) {
+	origDelay := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = origDelay }()
+
+	var mu sync.Mutex
+	requestIDs := map[string][]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestIDs[r.URL.Path] = append(requestIDs[r.URL.Path], r.Header.Get("client-request-id"))
+		attempt := len(requestIDs[r.URL.Path])
+		mu.Unlock()
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	g := newTestGraphClient(t, mux)
+	g.maxRetries = 1
+
+	forEachConcurrently(5, 5, func(i int) {
+		if _, err := g.GetUser(fmt.Sprintf("u%v", i)); err != nil {
+			t.Errorf("GraphClient.GetUser() error = %v", err)
+		}
+	})
+	seen := map[string]bool{}
+	for path, ids := range requestIDs {
+		if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+			t.Errorf("client-request-ids of %v = %v, want the same for both attempts", path, ids)
+		}
+		if seen[ids[0]] {
+			t.Errorf("client-request-id %v of %v has been used for another API-call", ids[0], path)
+		}
+		seen[ids[0]] = true
+	}
+}
+
+func TestGraphClient_concurrentAPICalls(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		opts          []ClientOption
+		maxConcurrent int32
+	}{
+		{name: "unlimited", maxConcurrent: 50},
+		{name: "WithMaxConcurrentRequests", opts: []ClientOption{WithMaxConcurrentRequests(3)}, maxConcurrent: 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var refreshes, running, overlapping int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/test-tenant/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&refreshes, 1)
+				time.Sleep(20 * time.Millisecond) // the other API-calls wait for the refresh meanwhile
+				fmt.Fprintf(w, `{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "access_token": "%v"}`,
+					time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix(), testAppToken)
+			})
+			mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+				now := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for max := atomic.LoadInt32(&overlapping); now > max && !atomic.CompareAndSwapInt32(&overlapping, max, now); {
+					max = atomic.LoadInt32(&overlapping)
+				}
+				time.Sleep(20 * time.Millisecond)
+				fmt.Fprint(w, `{"value": [{"id": "u1"}]}`)
+			})
+			g := newTestGraphClient(t, mux)
+			for _, opt := range tt.opts {
+				if err := opt(g); err != nil {
+					t.Fatalf("ClientOption error = %v", err)
+				}
+			}
+			g.token.ExpiresOn = time.Now() // wants to be refreshed by the first API-call
+
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := g.ListUsers(); err != nil {
+						t.Errorf("GraphClient.ListUsers() error = %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if refreshes != 1 {
+				t.Errorf("token refreshes = %v, want 1", refreshes)
+			}
+			if overlapping < 2 || overlapping > tt.maxConcurrent {
+				t.Errorf("overlapping API-calls = %v, want 2 to %v", overlapping, tt.maxConcurrent)
+			}
+		})
+	}
+}
+
+func TestGraphClient_CreateUser(t *testing.T) {
+	domain := msGraphExistingUserPrincipalInGroup[strings.LastIndex(msGraphExistingUserPrincipalInGroup, "@")+1:]
+	mailNickname := "go-msgraph-test-" + NewRequestID()[:8]
+	user, err := graphClient.CreateUser(UserCreate{
+		DisplayName:       "go-msgraph test user",
+		MailNickname:      mailNickname,
+		UserPrincipalName: mailNickname + "@" + domain,
+		PasswordProfile:   PasswordProfile{ForceChangePasswordNextSignIn: true, Password: "Go-msgraph-" + NewRequestID()},
+	})
+	if err != nil {
+		t.Fatalf("GraphClient.CreateUser() error = %v", err)
+	}
+	if err := graphClient.DeleteUser(user.ID); err != nil {
+		t.Errorf("GraphClient.DeleteUser() of the created user %v error = %v", user.UserPrincipalName, err)
+	}
+	if _, err := graphClient.GetUser(user.ID); !hasStatusCode(err, http.StatusNotFound) {
+		t.Errorf("GraphClient.GetUser() of the deleted user %v error = %v, want StatusCode 404", user.UserPrincipalName, err)
+	}
+	if user.ID == "" || !strings.EqualFold(user.UserPrincipalName, mailNickname+"@"+domain) {
+		t.Errorf("GraphClient.CreateUser() = %v, want the created user %v@%v", user, mailNickname, domain)
+	}
+
+	if _, err := graphClient.CreateUser(UserCreate{DisplayName: "missing password"}); err == nil {
+		t.Errorf("GraphClient.CreateUser() of an invalid user error = nil, want an error")
+	}
+}
+
+func TestGraphClient_CreateUser_request(t *testing.T) {
+	var created map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": "u1", "userPrincipalName": "%v"}`, created["userPrincipalName"])
+	})
+	g := newTestGraphClient(t, mux)
+	g.DefaultUsageLocation = "AT"
+
+	user, err := g.CreateUser(UserCreate{AccountEnabled: true, DisplayName: "Alice", MailNickname: "alice", UserPrincipalName: "alice@contoso.com",
+		PasswordProfile: PasswordProfile{ForceChangePasswordNextSignIn: true, Password: "secret"}})
+	if err != nil || user.ID != "u1" || user.UserPrincipalName != "alice@contoso.com" {
+		t.Fatalf("GraphClient.CreateUser() = %v, %v, want the created user u1", user, err)
+	}
+	passwordProfile, _ := created["passwordProfile"].(map[string]interface{})
+	if created["usageLocation"] != "AT" || created["accountEnabled"] != true || passwordProfile["forceChangePasswordNextSignIn"] != true {
+		t.Errorf("GraphClient.CreateUser() body = %v, want the DefaultUsageLocation and the passwordProfile", created)
+	}
+}
@@ -0,0 +1,113 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GraphError is returned by the API-calls of a GraphClient if msgraph answers with a status code other than 2xx. The
+// OData error of the body is parsed, use errors.As to e.g. check its Code:
+//
+//	var gerr *GraphError
+//	if errors.As(err, &gerr) && gerr.Code == "Request_ResourceNotFound" {
+//
+// If the body is no OData error, Code and Message are empty. Common status codes match a sentinel error with
+// errors.Is, e.g. ErrNotFound, see GraphError.Is.
+//
+// See https://docs.microsoft.com/en-us/graph/errors
+type GraphError struct {
+	StatusCode      int       // the http status code of the response
+	Code            string    // the OData error code, e.g. "Request_ResourceNotFound"
+	Message         string    // the human-readable error message
+	RequestID       string    // the request-id of the innerError or the response header, to be passed to Microsoft support
+	ClientRequestID string    // the client-request-id header of the request, see IdempotencyKey
+	Date            time.Time // the date of the innerError or the response header, zero if it cannot be parsed
+	Body            string    // the raw body of the response
+}
+
+// newGraphError returns the GraphError of the given response to a request with the given client-request-id
+func newGraphError(resp *http.Response, body []byte, clientRequestID string) *GraphError {
+	gerr := &GraphError{StatusCode: resp.StatusCode, Body: string(body), ClientRequestID: clientRequestID,
+		RequestID: resp.Header.Get("request-id")}
+	gerr.Date, _ = http.ParseTime(resp.Header.Get("Date"))
+	var envelope struct {
+		Error struct {
+			Code       string `json:"code"`
+			Message    string `json:"message"`
+			InnerError struct {
+				RequestID string `json:"request-id"`
+				Date      string `json:"date"`
+			} `json:"innerError"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &envelope) != nil {
+		return gerr
+	}
+	gerr.Code = envelope.Error.Code
+	gerr.Message = envelope.Error.Message
+	if envelope.Error.InnerError.RequestID != "" {
+		gerr.RequestID = envelope.Error.InnerError.RequestID
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05"} { // msgraph omits the timezone, it is UTC
+		if date, err := time.Parse(layout, envelope.Error.InnerError.Date); err == nil {
+			gerr.Date = date
+			break
+		}
+	}
+	return gerr
+}
+
+// Error returns the status code and the raw body of the response, followed by the request-id and client-request-id
+func (e *GraphError) Error() string {
+	msg := fmt.Sprintf("StatusCode is not OK: %v. Body: %v ", e.StatusCode, e.Body)
+	if e.RequestID != "" || e.ClientRequestID != "" {
+		msg += fmt.Sprintf("(request-id: %v, client-request-id: %v)", e.RequestID, e.ClientRequestID)
+	}
+	return msg
+}
+
+// Is returns true if target is the sentinel error of the status code of e, i.e. ErrUnauthorized, ErrForbidden,
+// ErrNotFound, ErrConflict or ErrThrottled. It is used by errors.Is.
+func (e *GraphError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrThrottled:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// sentinelError wraps the error of an API-call behind a more specific sentinel error of the package, e.g.
+// ErrNamedLocationInUse. It matches the sentinel with errors.Is and unwraps to the wrapped error, hence errors.As
+// still finds the GraphError and e.g. errors.Is(err, ErrForbidden) works too.
+type sentinelError struct {
+	sentinel error
+	detail   string // optional, printed after the sentinel
+	err      error
+}
+
+func (e *sentinelError) Error() string {
+	if e.detail != "" {
+		return fmt.Sprintf("%v %v: %v", e.sentinel, e.detail, e.err)
+	}
+	return fmt.Sprintf("%v: %v", e.sentinel, e.err)
+}
+
+// Is returns true if target is the sentinel of e, it is used by errors.Is
+func (e *sentinelError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+// Unwrap returns the wrapped error of e
+func (e *sentinelError) Unwrap() error {
+	return e.err
+}
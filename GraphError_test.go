@@ -0,0 +1,70 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want GraphError
+	}{
+		{"odata", `{"error": {"code": "Request_ResourceNotFound", "message": "Resource 'u1' does not exist.",
+			"innerError": {"date": "2021-06-01T12:30:00", "request-id": "c5b9f1a4"}}}`,
+			GraphError{StatusCode: 404, Code: "Request_ResourceNotFound", Message: "Resource 'u1' does not exist.", RequestID: "c5b9f1a4",
+				Date: time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)}},
+		{"no JSON", `<html>Bad Gateway</html>`, GraphError{StatusCode: 404, RequestID: "from-header",
+			Date: time.Date(2021, 6, 2, 8, 0, 0, 0, time.UTC)}},
+		{"empty", ``, GraphError{StatusCode: 404, RequestID: "from-header", Date: time.Date(2021, 6, 2, 8, 0, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var clientRequestID string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+				clientRequestID = r.Header.Get("client-request-id")
+				w.Header().Set("request-id", "from-header")
+				w.Header().Set("Date", "Wed, 02 Jun 2021 08:00:00 GMT")
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, tt.body)
+			})
+			g := newTestGraphClient(t, mux)
+
+			_, err := g.GetUser("u1")
+			var gerr *GraphError
+			if !errors.As(err, &gerr) {
+				t.Fatalf("GraphClient.GetUser() error = %v, want a *GraphError", err)
+			}
+			tt.want.Body = tt.body
+			tt.want.ClientRequestID = clientRequestID
+			if *gerr != tt.want || clientRequestID == "" {
+				t.Errorf("GraphClient.GetUser() error = %#v, want %#v", *gerr, tt.want)
+			}
+			if !strings.HasSuffix(err.Error(), fmt.Sprintf("(request-id: %v, client-request-id: %v)", tt.want.RequestID, clientRequestID)) {
+				t.Errorf("GraphClient.GetUser() error = %v, want the request-id and client-request-id", err)
+			}
+			if !hasStatusCode(err, http.StatusNotFound) {
+				t.Errorf("hasStatusCode(%v, 404) = false, want true", err)
+			}
+			if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) {
+				t.Errorf("errors.Is(%v, ErrNotFound) = false or matches ErrForbidden, want only ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func Test_statusCodeOf(t *testing.T) {
+	gerr := &GraphError{StatusCode: http.StatusTooManyRequests}
+	if got := statusCodeOf(fmt.Errorf("can't get Token: %w", gerr)); got != http.StatusTooManyRequests {
+		t.Errorf("statusCodeOf() of a wrapped GraphError = %v, want %v", got, http.StatusTooManyRequests)
+	}
+	if got := statusCodeOf(errors.New("StatusCode is not OK: 503. Body: ")); got != 0 {
+		t.Errorf("statusCodeOf() of an error message = %v, want 0", got)
+	}
+}
@@ -1,36 +1,90 @@
 package msgraph
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 )
 
+// GroupType is an entry of Group.GroupTypes
+type GroupType string
+
+// Group types as returned by msgraph in groupTypes. A group without GroupTypeUnified is a security or distribution group.
+const (
+	GroupTypeUnified           GroupType = "Unified"           // Microsoft 365 group
+	GroupTypeDynamicMembership GroupType = "DynamicMembership" // members are determined by a membership rule
+)
+
+// IsValid returns true if t is one of the GroupType constants
+func (t GroupType) IsValid() bool {
+	return t == GroupTypeUnified || t == GroupTypeDynamicMembership
+}
+
+// GroupVisibility is the visibility of a Microsoft 365 group
+type GroupVisibility string
+
+// Group visibilities as returned by msgraph in visibility
+const (
+	GroupVisibilityPublic           GroupVisibility = "Public"
+	GroupVisibilityPrivate          GroupVisibility = "Private"
+	GroupVisibilityHiddenMembership GroupVisibility = "HiddenMembership"
+)
+
+// IsValid returns true if v is one of the GroupVisibility constants
+func (v GroupVisibility) IsValid() bool {
+	return v == GroupVisibilityPublic || v == GroupVisibilityPrivate || v == GroupVisibilityHiddenMembership
+}
+
 // Group represents one group of ms graph
 //
 // See: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/group_get
 type Group struct {
-	ID                           string
-	Description                  string
-	DisplayName                  string
-	CreatedDateTime              time.Time
-	GroupTypes                   []string
-	Mail                         string
-	MailEnabled                  bool
-	MailNickname                 string
-	OnPremisesLastSyncDateTime   time.Time // defaults to 0001-01-01 00:00:00 +0000 UTC if there's none
-	OnPremisesSecurityIdentifier string
-	OnPremisesSyncEnabled        bool
-	ProxyAddresses               []string
-	SecurityEnabled              bool
-	Visibility                   string
+	ID                           string                        `json:"id"`
+	Description                  string                        `json:"description"`
+	DisplayName                  string                        `json:"displayName"`
+	CreatedDateTime              time.Time                     `json:"createdDateTime"`
+	ExpirationDateTime           time.Time                     `json:"expirationDateTime"` // zero if the group does not expire, see ListGroupLifecyclePolicies
+	RenewedDateTime              time.Time                     `json:"renewedDateTime"`    // time the group was last renewed, initially the CreatedDateTime
+	GroupTypes                   []GroupType                   `json:"groupTypes"`
+	Mail                         string                        `json:"mail"`
+	MailEnabled                  bool                          `json:"mailEnabled"`
+	MailNickname                 string                        `json:"mailNickname"`
+	OnPremisesLastSyncDateTime   time.Time                     `json:"onPremisesLastSyncDateTime"` // defaults to 0001-01-01 00:00:00 +0000 UTC if there's none
+	OnPremisesSecurityIdentifier string                        `json:"onPremisesSecurityIdentifier"`
+	OnPremisesSyncEnabled        bool                          `json:"onPremisesSyncEnabled"`
+	OnPremisesProvisioningErrors []OnPremisesProvisioningError `json:"onPremisesProvisioningErrors"` // errors of the synchronization, only returned by msgraph if selected
+	ProxyAddresses               []string                      `json:"proxyAddresses"`
+	SecurityEnabled              bool                          `json:"securityEnabled"`
+	Visibility                   GroupVisibility               `json:"visibility"` // empty for groups that are not Microsoft 365 groups
+	AdditionalData               AdditionalData                `json:"-"`          // properties that are not mapped to a field, see CaptureAdditionalData
 
 	graphClient *GraphClient // the graphClient that called the group
 }
 
 func (g Group) String() string {
-	return fmt.Sprintf("Group(ID: \"%v\", Description: \"%v\" DisplayName: \"%v\", CreatedDateTime: \"%v\", GroupTypes: \"%v\", Mail: \"%v\", MailEnabled: \"%v\", MailNickname: \"%v\", OnPremisesLastSyncDateTime: \"%v\", OnPremisesSecurityIdentifier: \"%v\", OnPremisesSyncEnabled: \"%v\", ProxyAddresses: \"%v\", SecurityEnabled \"%v\", Visibility: \"%v\", DirectAPIConnection: %v)",
-		g.ID, g.Description, g.DisplayName, g.CreatedDateTime, g.GroupTypes, g.Mail, g.MailEnabled, g.MailNickname, g.OnPremisesLastSyncDateTime, g.OnPremisesSecurityIdentifier, g.OnPremisesSyncEnabled, g.ProxyAddresses, g.SecurityEnabled, g.Visibility, g.graphClient != nil)
+	return fmt.Sprintf("Group(ID: \"%v\", Description: \"%v\" DisplayName: \"%v\", CreatedDateTime: \"%v\", ExpirationDateTime: \"%v\", GroupTypes: \"%v\", Mail: \"%v\", MailEnabled: \"%v\", MailNickname: \"%v\", OnPremisesLastSyncDateTime: \"%v\", OnPremisesSecurityIdentifier: \"%v\", OnPremisesSyncEnabled: \"%v\", ProxyAddresses: \"%v\", SecurityEnabled \"%v\", Visibility: \"%v\", DirectAPIConnection: %v)",
+		g.ID, g.Description, g.DisplayName, g.CreatedDateTime, g.ExpirationDateTime, g.GroupTypes, g.Mail, g.MailEnabled, g.MailNickname, g.OnPremisesLastSyncDateTime, g.OnPremisesSecurityIdentifier, g.OnPremisesSyncEnabled, g.ProxyAddresses, g.SecurityEnabled, g.Visibility, g.graphClient != nil)
+}
+
+// Validate returns an error if an enum field of the group, e.g. GroupTypes or Visibility, has a value that is
+// unknown to msgraph. An empty Visibility is valid.
+func (g Group) Validate() error {
+	for _, groupType := range g.GroupTypes {
+		if !groupType.IsValid() {
+			return fmt.Errorf("invalid GroupType %q", groupType)
+		}
+	}
+	if g.Visibility != "" && !g.Visibility.IsValid() {
+		return fmt.Errorf("invalid Visibility %q", g.Visibility)
+	}
+	return nil
+}
+
+// ExpiresWithin returns true if the group expires within d from now, e.g. to find groups that need to be renewed.
+// Groups that do not expire return false, groups that have already expired return true.
+func (g Group) ExpiresWithin(d time.Duration) bool {
+	return !g.ExpirationDateTime.IsZero() && time.Until(g.ExpirationDateTime) <= d
 }
 
 // setGraphClient sets the graphClient instance in this instance and all child-instances (if any)
@@ -40,10 +94,16 @@ func (g *Group) setGraphClient(gC *GraphClient) {
 
 // ListMembers - Get a list of the group's direct members. A group can have users,
 // contacts, and other groups as members. This operation is not transitive. This
-// method will currently ONLY return User-instances of members
+// method will currently ONLY return User-instances of members. The opts are applied
+// to the API-call of every page, e.g. OrderBy.
 //
 // See https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/group_list_members
-func (g Group) ListMembers() (Users, error) {
+func (g Group) ListMembers(opts ...RequestOption) (Users, error) {
+	return g.ListMembersContext(context.Background(), opts...)
+}
+
+// ListMembersContext is ListMembers with a context.
+func (g Group) ListMembersContext(ctx context.Context, opts ...RequestOption) (Users, error) {
 	if g.graphClient == nil {
 		return nil, ErrNotGraphClientSourced
 	}
@@ -52,27 +112,31 @@ func (g Group) ListMembers() (Users, error) {
 	var marsh struct {
 		Users Users `json:"value"`
 	}
+	err := g.graphClient.makeGETAPICall(ctx, resource, nil, &marsh, opts...)
 	marsh.Users.setGraphClient(g.graphClient)
-	return marsh.Users, g.graphClient.makeGETAPICall(resource, nil, &marsh)
+	return marsh.Users, err
 }
 
 // UnmarshalJSON implements the json unmarshal to be used by the json-library
 func (g *Group) UnmarshalJSON(data []byte) error {
 	tmp := struct {
-		ID                           string   `json:"id"`
-		Description                  string   `json:"description"`
-		DisplayName                  string   `json:"displayName"`
-		CreatedDateTime              string   `json:"createdDateTime"`
-		GroupTypes                   []string `json:"groupTypes"`
-		Mail                         string   `json:"mail"`
-		MailEnabled                  bool     `json:"mailEnabled"`
-		MailNickname                 string   `json:"mailNickname"`
-		OnPremisesLastSyncDateTime   string   `json:"onPremisesLastSyncDateTime"`
-		OnPremisesSecurityIdentifier string   `json:"onPremisesSecurityIdentifier"`
-		OnPremisesSyncEnabled        bool     `json:"onPremisesSyncEnabled"`
-		ProxyAddresses               []string `json:"proxyAddresses"`
-		SecurityEnabled              bool     `json:"securityEnabled"`
-		Visibility                   string   `json:"visibility"`
+		ID                           string                        `json:"id"`
+		Description                  string                        `json:"description"`
+		DisplayName                  string                        `json:"displayName"`
+		CreatedDateTime              string                        `json:"createdDateTime"`
+		ExpirationDateTime           string                        `json:"expirationDateTime"`
+		RenewedDateTime              string                        `json:"renewedDateTime"`
+		GroupTypes                   []GroupType                   `json:"groupTypes"`
+		Mail                         string                        `json:"mail"`
+		MailEnabled                  bool                          `json:"mailEnabled"`
+		MailNickname                 string                        `json:"mailNickname"`
+		OnPremisesLastSyncDateTime   string                        `json:"onPremisesLastSyncDateTime"`
+		OnPremisesSecurityIdentifier string                        `json:"onPremisesSecurityIdentifier"`
+		OnPremisesSyncEnabled        bool                          `json:"onPremisesSyncEnabled"`
+		OnPremisesProvisioningErrors []OnPremisesProvisioningError `json:"onPremisesProvisioningErrors"`
+		ProxyAddresses               []string                      `json:"proxyAddresses"`
+		SecurityEnabled              bool                          `json:"securityEnabled"`
+		Visibility                   GroupVisibility               `json:"visibility"`
 	}{}
 
 	err := json.Unmarshal(data, &tmp)
@@ -87,6 +151,14 @@ func (g *Group) UnmarshalJSON(data []byte) error {
 	if err != nil && tmp.CreatedDateTime != "" {
 		return fmt.Errorf("cannot parse CreatedDateTime %v with RFC3339: %v", tmp.CreatedDateTime, err)
 	}
+	g.ExpirationDateTime, err = time.Parse(time.RFC3339, tmp.ExpirationDateTime)
+	if err != nil && tmp.ExpirationDateTime != "" {
+		return fmt.Errorf("cannot parse ExpirationDateTime %v with RFC3339: %v", tmp.ExpirationDateTime, err)
+	}
+	g.RenewedDateTime, err = time.Parse(time.RFC3339, tmp.RenewedDateTime)
+	if err != nil && tmp.RenewedDateTime != "" {
+		return fmt.Errorf("cannot parse RenewedDateTime %v with RFC3339: %v", tmp.RenewedDateTime, err)
+	}
 	g.GroupTypes = tmp.GroupTypes
 	g.Mail = tmp.Mail
 	g.MailEnabled = tmp.MailEnabled
@@ -97,9 +169,21 @@ func (g *Group) UnmarshalJSON(data []byte) error {
 	}
 	g.OnPremisesSecurityIdentifier = tmp.OnPremisesSecurityIdentifier
 	g.OnPremisesSyncEnabled = tmp.OnPremisesSyncEnabled
+	g.OnPremisesProvisioningErrors = tmp.OnPremisesProvisioningErrors
 	g.ProxyAddresses = tmp.ProxyAddresses
 	g.SecurityEnabled = tmp.SecurityEnabled
 	g.Visibility = tmp.Visibility
+	g.AdditionalData, err = extractAdditionalData(data, &tmp)
 
-	return nil
+	return err
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the AdditionalData is included
+func (g Group) MarshalJSON() ([]byte, error) {
+	type group Group // group has no MarshalJSON, prevents the recursion
+	marshalled, err := json.Marshal(group(g))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, g.AdditionalData)
 }
@@ -0,0 +1,91 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ManagedGroupTypes are the Microsoft 365 groups a GroupLifecyclePolicy applies to
+type ManagedGroupTypes string
+
+// Managed group types as returned by msgraph in managedGroupTypes
+const (
+	ManagedGroupTypesAll      ManagedGroupTypes = "All"      // all Microsoft 365 groups expire
+	ManagedGroupTypesSelected ManagedGroupTypes = "Selected" // only the groups added to the policy expire
+	ManagedGroupTypesNone     ManagedGroupTypes = "None"     // no group expires
+)
+
+// IsValid returns true if t is one of the ManagedGroupTypes constants
+func (t ManagedGroupTypes) IsValid() bool {
+	return t == ManagedGroupTypesAll || t == ManagedGroupTypesSelected || t == ManagedGroupTypesNone
+}
+
+// GroupLifecyclePolicy is an expiration policy of Microsoft 365 groups. A group that is not renewed within
+// GroupLifetimeInDays expires, its Group.ExpirationDateTime is set accordingly.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/grouplifecyclepolicy
+type GroupLifecyclePolicy struct {
+	ID                          string
+	GroupLifetimeInDays         int
+	ManagedGroupTypes           ManagedGroupTypes
+	AlternateNotificationEmails []string // notified about groups without owners that are about to expire
+}
+
+func (p GroupLifecyclePolicy) String() string {
+	return fmt.Sprintf("GroupLifecyclePolicy(ID: \"%v\", GroupLifetimeInDays: \"%v\", ManagedGroupTypes: \"%v\", AlternateNotificationEmails: \"%v\")",
+		p.ID, p.GroupLifetimeInDays, p.ManagedGroupTypes, p.AlternateNotificationEmails)
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (p *GroupLifecyclePolicy) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ID                          string            `json:"id"`
+		GroupLifetimeInDays         int               `json:"groupLifetimeInDays"`
+		ManagedGroupTypes           ManagedGroupTypes `json:"managedGroupTypes"`
+		AlternateNotificationEmails string            `json:"alternateNotificationEmails"` // separated by ;
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("cannot UnmarshalJSON: %v | Data: %v", err, string(data))
+	}
+	p.ID = tmp.ID
+	p.GroupLifetimeInDays = tmp.GroupLifetimeInDays
+	p.ManagedGroupTypes = tmp.ManagedGroupTypes
+	p.AlternateNotificationEmails = nil
+	for _, email := range strings.Split(tmp.AlternateNotificationEmails, ";") {
+		if email = strings.TrimSpace(email); email != "" {
+			p.AlternateNotificationEmails = append(p.AlternateNotificationEmails, email)
+		}
+	}
+	return nil
+}
+
+// ListGroupLifecyclePolicies returns the expiration policies of Microsoft 365 groups of the tenant
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/grouplifecyclepolicy-list
+func (g *GraphClient) ListGroupLifecyclePolicies() ([]GroupLifecyclePolicy, error) {
+	return g.ListGroupLifecyclePoliciesContext(context.Background())
+}
+
+// ListGroupLifecyclePoliciesContext is ListGroupLifecyclePolicies with a context.
+func (g *GraphClient) ListGroupLifecyclePoliciesContext(ctx context.Context) ([]GroupLifecyclePolicy, error) {
+	var marsh struct {
+		Policies []GroupLifecyclePolicy `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, "/groupLifecyclePolicies", nil, &marsh)
+	return marsh.Policies, err
+}
+
+// RenewGroup renews the Microsoft 365 group identified by groupID, its ExpirationDateTime is extended by the
+// GroupLifetimeInDays of the GroupLifecyclePolicy.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-renew
+func (g *GraphClient) RenewGroup(groupID string) error {
+	return g.RenewGroupContext(context.Background(), groupID)
+}
+
+// RenewGroupContext is RenewGroup with a context.
+func (g *GraphClient) RenewGroupContext(ctx context.Context, groupID string) error {
+	return g.makePOSTAPICall(ctx, fmt.Sprintf("/groups/%v/renew", groupID), nil, nil)
+}
@@ -0,0 +1,52 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_ListGroupLifecyclePolicies(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groupLifecyclePolicies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "ffffffff-ffff-ffff-ffff-ffffffffffff", "groupLifetimeInDays": 180,
+			"managedGroupTypes": "Selected", "alternateNotificationEmails": "admin@contoso.com;governance@contoso.com"}]}`)
+	})
+	var renewed bool
+	mux.HandleFunc("/v1.0/groups/g1/renew", func(w http.ResponseWriter, r *http.Request) {
+		renewed = r.Method == http.MethodPost
+		w.WriteHeader(http.StatusNoContent)
+	})
+	g := newTestGraphClient(t, mux)
+
+	policies, err := g.ListGroupLifecyclePolicies()
+	if err != nil {
+		t.Fatalf("GraphClient.ListGroupLifecyclePolicies() error = %v", err)
+	}
+	want := []GroupLifecyclePolicy{{ID: "ffffffff-ffff-ffff-ffff-ffffffffffff", GroupLifetimeInDays: 180, ManagedGroupTypes: ManagedGroupTypesSelected,
+		AlternateNotificationEmails: []string{"admin@contoso.com", "governance@contoso.com"}}}
+	if !reflect.DeepEqual(policies, want) {
+		t.Errorf("GraphClient.ListGroupLifecyclePolicies() = %v, want %v", policies, want)
+	}
+
+	if err := g.RenewGroup("g1"); err != nil || !renewed {
+		t.Errorf("GraphClient.RenewGroup() error = %v, renewed = %v", err, renewed)
+	}
+}
+
+func TestGroup_ExpiresWithin(t *testing.T) {
+	var group Group
+	expiration := time.Now().Add(20 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	if err := json.Unmarshal([]byte(`{"id": "g1", "expirationDateTime": "`+expiration+`"}`), &group); err != nil {
+		t.Fatalf("Group.UnmarshalJSON() error = %v", err)
+	}
+	if !group.ExpiresWithin(30*24*time.Hour) || group.ExpiresWithin(10*24*time.Hour) {
+		t.Errorf("Group.ExpiresWithin() of a group expiring at %v is wrong", group.ExpirationDateTime)
+	}
+	if (Group{}).ExpiresWithin(30 * 24 * time.Hour) {
+		t.Errorf("Group.ExpiresWithin() of a group without expiration = true, want false")
+	}
+}
@@ -0,0 +1,47 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListGroupTransitiveMembers returns the users that are members of the group identified by groupID, either directly
+// or as member of a nested group. Members that are no users, e.g. devices or the nested groups themselves, are not
+// returned. The opts are applied to the API-call of every page, e.g. Select.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-list-transitivemembers
+func (g *GraphClient) ListGroupTransitiveMembers(groupID string, opts ...RequestOption) (Users, error) {
+	return g.ListGroupTransitiveMembersContext(context.Background(), groupID, opts...)
+}
+
+// ListGroupTransitiveMembersContext is ListGroupTransitiveMembers with a context.
+func (g *GraphClient) ListGroupTransitiveMembersContext(ctx context.Context, groupID string, opts ...RequestOption) (Users, error) {
+	var marsh struct {
+		Users Users `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/groups/%v/transitiveMembers/microsoft.graph.user", groupID), nil, &marsh, opts...)
+	marsh.Users.setGraphClient(g)
+	return marsh.Users, err
+}
+
+// ListUserTransitiveMemberOf returns the groups the user identified by userID is a member of, either directly or
+// through a nested group. Directory roles and administrative units are not returned. The opts are applied to the
+// API-call of every page, e.g. Select.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-transitivememberof
+func (g *GraphClient) ListUserTransitiveMemberOf(userID string, opts ...RequestOption) (Groups, error) {
+	return g.ListUserTransitiveMemberOfContext(context.Background(), userID, opts...)
+}
+
+// ListUserTransitiveMemberOfContext is ListUserTransitiveMemberOf with a context.
+func (g *GraphClient) ListUserTransitiveMemberOfContext(ctx context.Context, userID string, opts ...RequestOption) (Groups, error) {
+	if err := g.checkUserIdentifier(userID); err != nil {
+		return nil, err
+	}
+	var marsh struct {
+		Groups Groups `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v/transitiveMemberOf/microsoft.graph.group", userID), nil, &marsh, opts...)
+	marsh.Groups.setGraphClient(g)
+	return marsh.Groups, err
+}
@@ -0,0 +1,32 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_ListGroupTransitiveMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups/g1/transitiveMembers/microsoft.graph.user", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$skiptoken") == "page2" {
+			fmt.Fprint(w, `{"value": [{"id": "u2", "displayName": "Nested Member"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "u1", "displayName": "Direct Member"}],
+			"@odata.nextLink": "https://graph.microsoft.com/v1.0/groups/g1/transitiveMembers/microsoft.graph.user?$skiptoken=page2"}`)
+	})
+	mux.HandleFunc("/v1.0/users/u2/transitiveMemberOf/microsoft.graph.group", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "g2", "displayName": "Nested"}, {"id": "g1", "displayName": "Parent"}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	users, err := g.ListGroupTransitiveMembers("g1")
+	if err != nil || len(users) != 2 || users[1].ID != "u2" || users[1].graphClient != g {
+		t.Errorf("GraphClient.ListGroupTransitiveMembers() = %v, %v, want u1 and u2 of both pages", users, err)
+	}
+	groups, err := g.ListUserTransitiveMemberOf("u2")
+	if err != nil || len(groups) != 2 || groups[1].ID != "g1" || groups[1].graphClient != g {
+		t.Errorf("GraphClient.ListUserTransitiveMemberOf() = %v, %v, want g2 and g1", groups, err)
+	}
+}
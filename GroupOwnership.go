@@ -0,0 +1,125 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ListGroupOwners returns the owners of the group identified by groupID. Owners are mostly users but
+// may also be service principals.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-list-owners
+func (g *GraphClient) ListGroupOwners(groupID string) ([]DirectoryObject, error) {
+	return g.ListGroupOwnersContext(context.Background(), groupID)
+}
+
+// ListGroupOwnersContext is ListGroupOwners with a context.
+func (g *GraphClient) ListGroupOwnersContext(ctx context.Context, groupID string) ([]DirectoryObject, error) {
+	var owners []DirectoryObject
+	err := g.makePagedGETAPICall(ctx, fmt.Sprintf("/groups/%v/owners", groupID), nil, func(value json.RawMessage) (bool, error) {
+		var page []DirectoryObject
+		err := json.Unmarshal(value, &page)
+		owners = append(owners, page...)
+		return true, err
+	})
+	return owners, err
+}
+
+// AddGroupOwner adds the user or service principal identified by ownerID as owner of the group identified
+// by groupID. Adding an owner that already is an owner of the group is not an error.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-post-owners
+func (g *GraphClient) AddGroupOwner(groupID, ownerID string) error {
+	return g.AddGroupOwnerContext(context.Background(), groupID, ownerID)
+}
+
+// AddGroupOwnerContext is AddGroupOwner with a context.
+func (g *GraphClient) AddGroupOwnerContext(ctx context.Context, groupID, ownerID string) error {
+	body := map[string]string{
+		"@odata.id": fmt.Sprintf("%v/%v/directoryObjects/%v", g.endpoints().BaseURL, APIVersion, ownerID),
+	}
+	err := g.makePOSTAPICall(ctx, fmt.Sprintf("/groups/%v/owners/$ref", groupID), body, nil)
+	if hasStatusCode(err, http.StatusBadRequest) && strings.Contains(err.Error(), "already exist") {
+		return nil
+	}
+	return err
+}
+
+// RemoveGroupOwner removes the user or service principal identified by ownerID from the owners of the group
+// identified by groupID. msgraph refuses to remove the last owner of a Microsoft 365 group, the returned error then
+// contains the message of msgraph.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-delete-owners
+func (g *GraphClient) RemoveGroupOwner(groupID, ownerID string) error {
+	return g.RemoveGroupOwnerContext(context.Background(), groupID, ownerID)
+}
+
+// RemoveGroupOwnerContext is RemoveGroupOwner with a context.
+func (g *GraphClient) RemoveGroupOwnerContext(ctx context.Context, groupID, ownerID string) error {
+	err := g.makeDELETEAPICall(ctx, fmt.Sprintf("/groups/%v/owners/%v/$ref", groupID, ownerID))
+	if message := odataErrorMessage(err); hasStatusCode(err, http.StatusBadRequest) && message != "" {
+		return fmt.Errorf("cannot remove owner %v of group %v: %v: %w", ownerID, groupID, message, err)
+	}
+	return err
+}
+
+// GroupOwnershipTransfer is the result of TransferGroupOwnership
+type GroupOwnershipTransfer struct {
+	Transferred Groups           // groups the new owner has been added to and the previous owner has been removed from
+	Skipped     Groups           // groups the previous owner is not an owner of anymore
+	Errors      map[string]error // errors of groups that could not be transferred, keyed by the group ID
+}
+
+func (t GroupOwnershipTransfer) String() string {
+	return fmt.Sprintf("GroupOwnershipTransfer(Transferred: %v, Skipped: %v, Errors: %v)", len(t.Transferred), len(t.Skipped), len(t.Errors))
+}
+
+// TransferGroupOwnership makes the user identified by toUserID the owner of all groups owned by the user identified by
+// fromUserID and then removes fromUserID from the owners, e.g. when fromUserID is offboarded. Groups where fromUserID
+// is not an owner anymore are skipped. A group that fails does not abort the transfer of the other groups, its error
+// is collected in GroupOwnershipTransfer.Errors.
+//
+// Both users must be given by their ID, as the owners of a group are compared by ID. The returned error is
+// only non-nil if the owned groups of fromUserID cannot be listed.
+func (g *GraphClient) TransferGroupOwnership(fromUserID, toUserID string) (GroupOwnershipTransfer, error) {
+	return g.TransferGroupOwnershipContext(context.Background(), fromUserID, toUserID)
+}
+
+// TransferGroupOwnershipContext is TransferGroupOwnership with a context.
+func (g *GraphClient) TransferGroupOwnershipContext(ctx context.Context, fromUserID, toUserID string) (GroupOwnershipTransfer, error) {
+	transfer := GroupOwnershipTransfer{Errors: make(map[string]error)}
+	groups, err := g.listGroupsPaged(ctx, fmt.Sprintf("/users/%v/ownedObjects/microsoft.graph.group", fromUserID))
+	if err != nil {
+		return transfer, fmt.Errorf("cannot list the groups owned by %v: %w", fromUserID, err)
+	}
+
+	for _, group := range groups {
+		owners, err := g.ListGroupOwnersContext(ctx, group.ID)
+		if err != nil {
+			transfer.Errors[group.ID] = fmt.Errorf("cannot list owners: %w", err)
+			continue
+		}
+		var isOwner bool
+		for _, owner := range owners {
+			isOwner = isOwner || owner.ID == fromUserID
+		}
+		if !isOwner {
+			transfer.Skipped = append(transfer.Skipped, group)
+			continue
+		}
+		// add the new owner first, msgraph refuses to remove the last owner of a group
+		if err := g.AddGroupOwnerContext(ctx, group.ID, toUserID); err != nil {
+			transfer.Errors[group.ID] = fmt.Errorf("cannot add owner %v: %w", toUserID, err)
+			continue
+		}
+		if err := g.RemoveGroupOwnerContext(ctx, group.ID, fromUserID); err != nil {
+			transfer.Errors[group.ID] = fmt.Errorf("cannot remove owner %v: %w", fromUserID, err)
+			continue
+		}
+		transfer.Transferred = append(transfer.Transferred, group)
+	}
+	return transfer, nil
+}
@@ -0,0 +1,102 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGraphClient_TransferGroupOwnership(t *testing.T) {
+	var added, removed []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1/ownedObjects/microsoft.graph.group", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "g1", "displayName": "Technicians"}, {"id": "g2", "displayName": "Sales"}, {"id": "g3", "displayName": "HR"}]}`)
+	})
+	mux.HandleFunc("/v1.0/groups/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1.0/groups/"), "/")
+		groupID := parts[0]
+		switch {
+		case r.Method == http.MethodGet && len(parts) == 2 && parts[1] == "owners":
+			if groupID == "g2" { // u1 has been removed in the meantime
+				fmt.Fprint(w, `{"value": [{"@odata.type": "#microsoft.graph.user", "id": "u3"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"value": [{"@odata.type": "#microsoft.graph.user", "id": "u1"}]}`)
+		case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "$ref":
+			body, _ := ioutil.ReadAll(r.Body)
+			if !strings.Contains(string(body), "/v1.0/directoryObjects/u2") {
+				t.Errorf("POST owners/$ref body = %s", body)
+			}
+			added = append(added, groupID)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && len(parts) == 4 && parts[2] == "u1" && parts[3] == "$ref":
+			if groupID == "g3" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"error": {"code": "Authorization_RequestDenied", "message": ""}}`)
+				return
+			}
+			removed = append(removed, groupID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	g := newTestGraphClient(t, mux)
+
+	got, err := g.TransferGroupOwnership("u1", "u2")
+	if err != nil {
+		t.Fatalf("GraphClient.TransferGroupOwnership() error = %v", err)
+	}
+	if len(got.Transferred) != 1 || got.Transferred[0].ID != "g1" {
+		t.Errorf("GraphClient.TransferGroupOwnership() Transferred = %v, want g1", got.Transferred)
+	}
+	if len(got.Skipped) != 1 || got.Skipped[0].ID != "g2" {
+		t.Errorf("GraphClient.TransferGroupOwnership() Skipped = %v, want g2", got.Skipped)
+	}
+	if len(got.Errors) != 1 || got.Errors["g3"] == nil {
+		t.Errorf("GraphClient.TransferGroupOwnership() Errors = %v, want an error for g3", got.Errors)
+	}
+	if strings.Join(added, ",") != "g1,g3" || strings.Join(removed, ",") != "g1" {
+		t.Errorf("GraphClient.TransferGroupOwnership() added owner to %v and removed owner from %v", added, removed)
+	}
+}
+
+func TestGraphClient_RemoveGroupOwner(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups/g1/owners/u1/$ref", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"code": "Request_BadRequest", "message": "The group must have at least one owner, hence this owner cannot be removed."}}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	err := g.RemoveGroupOwner("g1", "u1")
+	var gerr *GraphError
+	if !errors.As(err, &gerr) || !strings.HasPrefix(err.Error(), "cannot remove owner u1 of group g1: The group must have at least one owner") {
+		t.Errorf("GraphClient.RemoveGroupOwner() of the last owner error = %v, want the message of msgraph", err)
+	}
+}
+
+func TestGraphClient_ListUserOwnedObjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1/ownedObjects", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"@odata.type": "#microsoft.graph.group", "id": "g1", "displayName": "Technicians"},
+			{"@odata.type": "#microsoft.graph.application", "id": "a1", "appId": "app1", "displayName": "Contoso HR"},
+			{"@odata.type": "#microsoft.graph.servicePrincipal", "id": "sp1", "appId": "app1", "displayName": "Contoso HR"},
+			{"@odata.type": "#microsoft.graph.device", "id": "d1", "displayName": "LAPTOP-01"}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	got, err := g.ListUserOwnedObjects("u1")
+	if err != nil {
+		t.Fatalf("GraphClient.ListUserOwnedObjects() error = %v", err)
+	}
+	if len(got.Groups) != 1 || got.Groups[0].graphClient != g || len(got.Applications) != 1 || got.Applications[0].AppID != "app1" ||
+		len(got.ServicePrincipals) != 1 || len(got.Other) != 1 {
+		t.Errorf("GraphClient.ListUserOwnedObjects() = %v, want one object of every type", got)
+	}
+}
@@ -0,0 +1,138 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GroupProperties are the properties of a group that is created by CreateGroup or EnsureGroup. A Microsoft 365 group
+// has GroupTypes GroupTypeUnified and is mail enabled, a security group has no GroupTypes and is security enabled.
+//
+// See https://docs.microsoft.com/en-us/graph/api/group-post-groups
+type GroupProperties struct {
+	DisplayName     string          `json:"displayName"`
+	Description     string          `json:"description,omitempty"`
+	MailNickname    string          `json:"mailNickname"`
+	MailEnabled     bool            `json:"mailEnabled"`
+	SecurityEnabled bool            `json:"securityEnabled"`
+	GroupTypes      []GroupType     `json:"groupTypes"`
+	Visibility      GroupVisibility `json:"visibility,omitempty"`
+}
+
+// Validate returns an error if a required property is missing or an enum property has a value that is unknown to msgraph
+func (p GroupProperties) Validate() error {
+	if p.DisplayName == "" || p.MailNickname == "" {
+		return fmt.Errorf("DisplayName and MailNickname are required")
+	}
+	return Group{GroupTypes: p.GroupTypes, Visibility: p.Visibility}.Validate()
+}
+
+// CreateGroup creates a group with the given properties
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-post-groups
+func (g *GraphClient) CreateGroup(properties GroupProperties) (Group, error) {
+	return g.CreateGroupContext(context.Background(), properties)
+}
+
+// CreateGroupContext is CreateGroup with a context.
+func (g *GraphClient) CreateGroupContext(ctx context.Context, properties GroupProperties) (Group, error) {
+	if err := properties.Validate(); err != nil {
+		return Group{}, err
+	}
+	if properties.GroupTypes == nil {
+		properties.GroupTypes = []GroupType{} // msgraph requires the property
+	}
+	group := Group{graphClient: g}
+	err := g.makePOSTAPICall(ctx, "/groups", properties, &group)
+	return group, err
+}
+
+// GroupUpdate contains the properties of a group that are changed by GraphClient.UpdateGroup. Only properties that
+// are not nil are sent to msgraph, a pointer to an empty string clears the property.
+type GroupUpdate struct {
+	DisplayName     *string          `json:"displayName,omitempty"`
+	Description     *string          `json:"description,omitempty"`
+	MailNickname    *string          `json:"mailNickname,omitempty"`
+	SecurityEnabled *bool            `json:"securityEnabled,omitempty"`
+	Visibility      *GroupVisibility `json:"visibility,omitempty"` // can only be changed for Microsoft 365 groups
+}
+
+// Validate returns an error if a property of the update has a value that is unknown to msgraph
+func (u GroupUpdate) Validate() error {
+	if u.Visibility != nil && !u.Visibility.IsValid() {
+		return fmt.Errorf("invalid Visibility %q", *u.Visibility)
+	}
+	return nil
+}
+
+// UpdateGroup updates the properties of the group identified by the given groupID that are set in update.
+// The update is validated before the API-call is performed.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/group-update
+func (g *GraphClient) UpdateGroup(groupID string, update GroupUpdate) error {
+	return g.UpdateGroupContext(context.Background(), groupID, update)
+}
+
+// UpdateGroupContext is UpdateGroup with a context.
+func (g *GraphClient) UpdateGroupContext(ctx context.Context, groupID string, update GroupUpdate) error {
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	return g.makePATCHAPICall(ctx, fmt.Sprintf("/groups/%v", groupID), update, nil)
+}
+
+// GetGroupByMailNickname returns the group with the given mailNickname. Returns ErrFindGroup if there is no such
+// group and an error if there are several, which is possible for security groups.
+func (g *GraphClient) GetGroupByMailNickname(mailNickname string) (Group, error) {
+	return g.GetGroupByMailNicknameContext(context.Background(), mailNickname)
+}
+
+// GetGroupByMailNicknameContext is GetGroupByMailNickname with a context.
+func (g *GraphClient) GetGroupByMailNicknameContext(ctx context.Context, mailNickname string) (Group, error) {
+	getParams := url.Values{}
+	getParams.Add("$filter", fmt.Sprintf("mailNickname eq '%v'", strings.ReplaceAll(mailNickname, "'", "''")))
+
+	var marsh struct {
+		Groups Groups `json:"value"`
+	}
+	if err := g.makeGETAPICall(ctx, "/groups", getParams, &marsh); err != nil {
+		return Group{}, err
+	}
+	switch len(marsh.Groups) {
+	case 0:
+		return Group{}, ErrFindGroup
+	case 1:
+		marsh.Groups.setGraphClient(g)
+		return marsh.Groups[0], nil
+	default:
+		return Group{}, fmt.Errorf("%v groups have the mailNickname %v", len(marsh.Groups), mailNickname)
+	}
+}
+
+// EnsureGroup returns the group with the mailNickname of the given properties, it is created if it does not exist
+// yet. The returned bool is true if the group has been created. The properties of an existing group are not changed.
+//
+// If the group is created concurrently, e.g. by another provisioning run, msgraph refuses to create a second group
+// with the same mailNickname and the group of the other run is returned. Mind that msgraph only enforces unique
+// mailNicknames for mail enabled groups, concurrent runs may create duplicate security groups.
+func (g *GraphClient) EnsureGroup(properties GroupProperties) (Group, bool, error) {
+	return g.EnsureGroupContext(context.Background(), properties)
+}
+
+// EnsureGroupContext is EnsureGroup with a context.
+func (g *GraphClient) EnsureGroupContext(ctx context.Context, properties GroupProperties) (Group, bool, error) {
+	group, err := g.GetGroupByMailNicknameContext(ctx, properties.MailNickname)
+	if err != ErrFindGroup {
+		return group, false, err
+	}
+
+	group, err = g.CreateGroupContext(ctx, properties)
+	if hasStatusCode(err, http.StatusBadRequest) && strings.Contains(err.Error(), "already exists") {
+		group, err = g.GetGroupByMailNicknameContext(ctx, properties.MailNickname)
+		return group, false, err
+	}
+	return group, err == nil, err
+}
@@ -0,0 +1,103 @@
+package msgraph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGraphClient_EnsureGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    bool   // the group exists before EnsureGroup
+		createdBy   string // "us" if the POST succeeds, "other" if another run created the group in the meantime
+		wantCreated bool
+		wantErr     bool
+	}{
+		{name: "exists", existing: true},
+		{name: "created", createdBy: "us", wantCreated: true},
+		{name: "created concurrently", createdBy: "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists := tt.existing
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					if got := r.URL.Query().Get("$filter"); got != "mailNickname eq 'o''brien-team'" {
+						t.Errorf("$filter = %v", got)
+					}
+					if exists {
+						fmt.Fprint(w, `{"value": [{"id": "g1", "displayName": "O'Brien Team", "mailNickname": "o'brien-team"}]}`)
+						return
+					}
+					fmt.Fprint(w, `{"value": []}`)
+				case http.MethodPost:
+					body, _ := ioutil.ReadAll(r.Body)
+					if !strings.Contains(string(body), `"groupTypes":["Unified"]`) {
+						t.Errorf("POST /groups body = %s", body)
+					}
+					if tt.createdBy == "other" {
+						exists = true
+						w.WriteHeader(http.StatusBadRequest)
+						fmt.Fprint(w, `{"error": {"code": "Request_BadRequest", "message": "Another object with the same value for property mailNickname already exists."}}`)
+						return
+					}
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"id": "g2", "displayName": "O'Brien Team", "mailNickname": "o'brien-team"}`)
+				}
+			})
+			g := newTestGraphClient(t, mux)
+
+			group, created, err := g.EnsureGroup(GroupProperties{DisplayName: "O'Brien Team", MailNickname: "o'brien-team",
+				MailEnabled: true, GroupTypes: []GroupType{GroupTypeUnified}, Visibility: GroupVisibilityPrivate})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GraphClient.EnsureGroup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreated || group.MailNickname != "o'brien-team" || group.graphClient == nil {
+				t.Errorf("GraphClient.EnsureGroup() = %v, %v, want created = %v", group, created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestGraphClient_CreateAndUpdateGroup(t *testing.T) {
+	var bodies []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+string(body))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "g1", "displayName": "Admins", "securityEnabled": true}`)
+	})
+	mux.HandleFunc("/v1.0/groups/g1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+string(body))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	g := newTestGraphClient(t, mux)
+
+	group, err := g.CreateGroup(GroupProperties{DisplayName: "Admins", MailNickname: "admins", SecurityEnabled: true})
+	if err != nil || group.ID != "g1" || group.graphClient == nil {
+		t.Fatalf("GraphClient.CreateGroup() = %v, %v", group, err)
+	}
+	description := ""
+	if err := g.UpdateGroup("g1", GroupUpdate{Description: &description}); err != nil {
+		t.Fatalf("GraphClient.UpdateGroup() error = %v", err)
+	}
+	want := []string{
+		`POST {"displayName":"Admins","mailNickname":"admins","mailEnabled":false,"securityEnabled":true,"groupTypes":[]}`,
+		`PATCH {"description":""}`,
+	}
+	if strings.Join(bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("GraphClient.CreateGroup() and UpdateGroup() requests = %q, want %q", bodies, want)
+	}
+
+	invalid := GroupVisibility("secret")
+	if err := g.UpdateGroup("g1", GroupUpdate{Visibility: &invalid}); err == nil || len(bodies) != 2 {
+		t.Errorf("GraphClient.UpdateGroup() with an invalid visibility error = %v, want an error without an API-call", err)
+	}
+}
@@ -2,6 +2,8 @@ package msgraph
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -70,8 +72,8 @@ func TestGroup_String(t *testing.T) {
 		{
 			name: "Test All Groups",
 			g:    testGroup,
-			want: fmt.Sprintf("Group(ID: \"%v\", Description: \"%v\" DisplayName: \"%v\", CreatedDateTime: \"%v\", GroupTypes: \"%v\", Mail: \"%v\", MailEnabled: \"%v\", MailNickname: \"%v\", OnPremisesLastSyncDateTime: \"%v\", OnPremisesSecurityIdentifier: \"%v\", OnPremisesSyncEnabled: \"%v\", ProxyAddresses: \"%v\", SecurityEnabled \"%v\", Visibility: \"%v\", DirectAPIConnection: %v)",
-				testGroup.ID, testGroup.Description, testGroup.DisplayName, testGroup.CreatedDateTime, testGroup.GroupTypes, testGroup.Mail, testGroup.MailEnabled, testGroup.MailNickname, testGroup.OnPremisesLastSyncDateTime, testGroup.OnPremisesSecurityIdentifier, testGroup.OnPremisesSyncEnabled, testGroup.ProxyAddresses, testGroup.SecurityEnabled, testGroup.Visibility, testGroup.graphClient != nil),
+			want: fmt.Sprintf("Group(ID: \"%v\", Description: \"%v\" DisplayName: \"%v\", CreatedDateTime: \"%v\", ExpirationDateTime: \"%v\", GroupTypes: \"%v\", Mail: \"%v\", MailEnabled: \"%v\", MailNickname: \"%v\", OnPremisesLastSyncDateTime: \"%v\", OnPremisesSecurityIdentifier: \"%v\", OnPremisesSyncEnabled: \"%v\", ProxyAddresses: \"%v\", SecurityEnabled \"%v\", Visibility: \"%v\", DirectAPIConnection: %v)",
+				testGroup.ID, testGroup.Description, testGroup.DisplayName, testGroup.CreatedDateTime, testGroup.ExpirationDateTime, testGroup.GroupTypes, testGroup.Mail, testGroup.MailEnabled, testGroup.MailNickname, testGroup.OnPremisesLastSyncDateTime, testGroup.OnPremisesSecurityIdentifier, testGroup.OnPremisesSyncEnabled, testGroup.ProxyAddresses, testGroup.SecurityEnabled, testGroup.Visibility, testGroup.graphClient != nil),
 		},
 	}
 	for _, tt := range tests {
@@ -82,3 +84,60 @@ func TestGroup_String(t *testing.T) {
 		})
 	}
 }
+
+func TestGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		g       Group
+		wantErr bool
+	}{
+		{name: "security group", g: Group{SecurityEnabled: true}, wantErr: false},
+		{name: "Microsoft 365 group", g: Group{GroupTypes: []GroupType{GroupTypeUnified, GroupTypeDynamicMembership}, Visibility: GroupVisibilityPrivate}, wantErr: false},
+		{name: "invalid group type", g: Group{GroupTypes: []GroupType{"unified"}}, wantErr: true},
+		{name: "invalid visibility", g: Group{GroupTypes: []GroupType{GroupTypeUnified}, Visibility: "Hidden"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.g.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Group.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroup_ListMembers_paging(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups/g1/members", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("$skiptoken") {
+		case "":
+			fmt.Fprint(w, `{"value": [{"id": "u1"}], "@odata.nextLink": "https://graph.microsoft.com/v1.0/groups/g1/members?$skiptoken=2"}`)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"id": "u2"}], "@odata.nextLink": "https://graph.microsoft.com/v1.0/groups/g1/members?$skiptoken=3"}`)
+		case "3":
+			fmt.Fprint(w, `{"value": [{"id": "u3"}]}`)
+		}
+	})
+	mux.HandleFunc("/v1.0/groups/g2/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "u1"}], "@odata.nextLink": "https://graph.example.com/v1.0/groups/g2/members?$skiptoken=2"}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	members, err := Group{ID: "g1", graphClient: g}.ListMembers()
+	if err != nil {
+		t.Fatalf("Group.ListMembers() error = %v", err)
+	}
+	var ids []string
+	for _, member := range members {
+		ids = append(ids, member.ID)
+		if member.graphClient != g {
+			t.Errorf("Group.ListMembers() member %v is not GraphClient sourced", member.ID)
+		}
+	}
+	if fmt.Sprint(ids) != "[u1 u2 u3]" {
+		t.Errorf("Group.ListMembers() IDs = %v, want [u1 u2 u3]", ids)
+	}
+
+	if _, err := (Group{ID: "g2", graphClient: g}).ListMembers(); err == nil || !strings.Contains(err.Error(), "unexpected host") {
+		t.Errorf("Group.ListMembers() with a nextLink to another host error = %v, want unexpected host", err)
+	}
+}
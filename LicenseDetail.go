@@ -0,0 +1,132 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ServicePlanProvisioningStatus is the provisioning status of a ServicePlanInfo
+type ServicePlanProvisioningStatus string
+
+// Provisioning statuses of a service plan as returned by msgraph in provisioningStatus
+const (
+	ServicePlanProvisioningSuccess             ServicePlanProvisioningStatus = "Success"             // the service is provisioned and usable
+	ServicePlanProvisioningDisabled            ServicePlanProvisioningStatus = "Disabled"            // the service plan is disabled in the license assignment
+	ServicePlanProvisioningError               ServicePlanProvisioningStatus = "Error"               // provisioning failed
+	ServicePlanProvisioningPendingInput        ServicePlanProvisioningStatus = "PendingInput"        // waiting for input, e.g. a missing usageLocation
+	ServicePlanProvisioningPendingActivation   ServicePlanProvisioningStatus = "PendingActivation"   // waiting for the activation of the service
+	ServicePlanProvisioningPendingProvisioning ServicePlanProvisioningStatus = "PendingProvisioning" // the service is still being provisioned
+)
+
+// ServicePlanInfo represents a service plan of a LicenseDetail, e.g. Exchange Online of an Office 365 license
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/serviceplaninfo
+type ServicePlanInfo struct {
+	ServicePlanID      string                        `json:"servicePlanId"`      // Unique identifier (GUID) of the service plan
+	ServicePlanName    string                        `json:"servicePlanName"`    // e.g. "EXCHANGE_S_ENTERPRISE"
+	ProvisioningStatus ServicePlanProvisioningStatus `json:"provisioningStatus"` // e.g. ServicePlanProvisioningSuccess
+	AppliesTo          string                        `json:"appliesTo"`          // "User" or "Company"
+}
+
+func (s ServicePlanInfo) String() string {
+	return fmt.Sprintf("ServicePlanInfo(ServicePlanID: \"%v\", ServicePlanName: \"%v\", ProvisioningStatus: \"%v\", AppliesTo: \"%v\")",
+		s.ServicePlanID, s.ServicePlanName, s.ProvisioningStatus, s.AppliesTo)
+}
+
+// IsProvisioned returns true if the service of the plan is provisioned and can be used, e.g. the mailbox of
+// EXCHANGE_S_ENTERPRISE exists.
+func (s ServicePlanInfo) IsProvisioned() bool {
+	return s.ProvisioningStatus == ServicePlanProvisioningSuccess
+}
+
+// LicenseDetail represents a license (SKU) that is assigned to a user
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/licensedetails
+type LicenseDetail struct {
+	ID            string            `json:"id"`
+	SkuID         string            `json:"skuId"`         // Unique identifier (GUID) for the service SKU.
+	SkuPartNumber string            `json:"skuPartNumber"` // Unique SKU display name, e.g. "ENTERPRISEPACK"
+	ServicePlans  []ServicePlanInfo `json:"servicePlans"`  // the service plans of the SKU and their provisioning status for the user
+}
+
+func (l LicenseDetail) String() string {
+	return fmt.Sprintf("LicenseDetail(ID: \"%v\", SkuID: \"%v\", SkuPartNumber: \"%v\", ServicePlans: %v)", l.ID, l.SkuID, l.SkuPartNumber, len(l.ServicePlans))
+}
+
+// GetServicePlanByName returns the service plan of the license with the given servicePlanName, e.g. "EXCHANGE_S_ENTERPRISE".
+// Returns ErrFindServicePlan if the license has no such service plan.
+func (l LicenseDetail) GetServicePlanByName(servicePlanName string) (ServicePlanInfo, error) {
+	for _, plan := range l.ServicePlans {
+		if plan.ServicePlanName == servicePlanName {
+			return plan, nil
+		}
+	}
+	return ServicePlanInfo{}, ErrFindServicePlan
+}
+
+// ListUserLicenseDetails returns the licenses assigned to the user identified by either the given ID or userPrincipalName,
+// including the provisioning status of every service plan. Unlike assignedPlans of the user, the provisioning status
+// reliably tells whether a service can be used yet, e.g. whether the mailbox of EXCHANGE_S_ENTERPRISE has been created.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-licensedetails
+func (g *GraphClient) ListUserLicenseDetails(identifier string) ([]LicenseDetail, error) {
+	return g.ListUserLicenseDetailsContext(context.Background(), identifier)
+}
+
+// ListUserLicenseDetailsContext is ListUserLicenseDetails with a context.
+func (g *GraphClient) ListUserLicenseDetailsContext(ctx context.Context, identifier string) ([]LicenseDetail, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	var licenses []LicenseDetail
+	err := g.makePagedGETAPICall(ctx, fmt.Sprintf("/users/%v/licenseDetails", identifier), nil, func(value json.RawMessage) (bool, error) {
+		var page []LicenseDetail
+		err := json.Unmarshal(value, &page)
+		licenses = append(licenses, page...)
+		return true, err
+	})
+	return licenses, err
+}
+
+// assignedLicense is a license to be added by assignLicense
+type assignedLicense struct {
+	SkuID         string   `json:"skuId"`
+	DisabledPlans []string `json:"disabledPlans"`
+}
+
+// assignLicenseBody is the request body of assignLicense, msgraph requires both arrays even if one of them is empty
+type assignLicenseBody struct {
+	AddLicenses    []assignedLicense `json:"addLicenses"`
+	RemoveLicenses []string          `json:"removeLicenses"`
+}
+
+// AssignLicense assigns the licenses identified by the given skuIDs with all their service plans to the user
+// identified by userID. The user must have a usageLocation, see GraphClient.DefaultUsageLocation.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-assignlicense
+func (g *GraphClient) AssignLicense(userID string, skuIDs []string) error {
+	return g.AssignLicenseContext(context.Background(), userID, skuIDs)
+}
+
+// AssignLicenseContext is AssignLicense with a context.
+func (g *GraphClient) AssignLicenseContext(ctx context.Context, userID string, skuIDs []string) error {
+	body := assignLicenseBody{AddLicenses: []assignedLicense{}, RemoveLicenses: []string{}}
+	for _, skuID := range skuIDs {
+		body.AddLicenses = append(body.AddLicenses, assignedLicense{SkuID: skuID, DisabledPlans: []string{}})
+	}
+	return g.makePOSTAPICall(ctx, fmt.Sprintf("/users/%v/assignLicense", userID), body, nil)
+}
+
+// RemoveLicense removes the licenses identified by the given skuIDs from the user identified by userID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-assignlicense
+func (g *GraphClient) RemoveLicense(userID string, skuIDs []string) error {
+	return g.RemoveLicenseContext(context.Background(), userID, skuIDs)
+}
+
+// RemoveLicenseContext is RemoveLicense with a context.
+func (g *GraphClient) RemoveLicenseContext(ctx context.Context, userID string, skuIDs []string) error {
+	body := assignLicenseBody{AddLicenses: []assignedLicense{}, RemoveLicenses: append([]string{}, skuIDs...)}
+	return g.makePOSTAPICall(ctx, fmt.Sprintf("/users/%v/assignLicense", userID), body, nil)
+}
@@ -0,0 +1,64 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_ListUserLicenseDetails(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/alice@contoso.com/licenseDetails", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "l1", "skuId": "6fd2c87f-b296-42f0-b197-1e91e994b900", "skuPartNumber": "ENTERPRISEPACK",
+			"servicePlans": [
+				{"servicePlanId": "efb87545-963c-4e0d-99df-69c6916d9eb0", "servicePlanName": "EXCHANGE_S_ENTERPRISE", "provisioningStatus": "PendingProvisioning", "appliesTo": "User"},
+				{"servicePlanId": "5dbe027f-2339-4123-9542-606e4d348a72", "servicePlanName": "SHAREPOINTENTERPRISE", "provisioningStatus": "Success", "appliesTo": "User"}]}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	licenses, err := g.ListUserLicenseDetails("alice@contoso.com")
+	if err != nil {
+		t.Fatalf("GraphClient.ListUserLicenseDetails() error = %v", err)
+	}
+	if len(licenses) != 1 || len(licenses[0].ServicePlans) != 2 {
+		t.Fatalf("GraphClient.ListUserLicenseDetails() = %v, want 1 license with 2 service plans", licenses)
+	}
+
+	exchange, err := licenses[0].GetServicePlanByName("EXCHANGE_S_ENTERPRISE")
+	if err != nil || exchange.ProvisioningStatus != ServicePlanProvisioningPendingProvisioning || exchange.IsProvisioned() {
+		t.Errorf("LicenseDetail.GetServicePlanByName(EXCHANGE_S_ENTERPRISE) = %v, %v, want a pending plan", exchange, err)
+	}
+	if sharePoint, _ := licenses[0].GetServicePlanByName("SHAREPOINTENTERPRISE"); !sharePoint.IsProvisioned() {
+		t.Errorf("ServicePlanInfo.IsProvisioned() of %v = false, want true", sharePoint)
+	}
+	if _, err := licenses[0].GetServicePlanByName("YAMMER_ENTERPRISE"); !errors.Is(err, ErrFindServicePlan) {
+		t.Errorf("LicenseDetail.GetServicePlanByName(YAMMER_ENTERPRISE) error = %v, want %v", err, ErrFindServicePlan)
+	}
+}
+
+func TestGraphClient_AssignLicense(t *testing.T) {
+	var bodies []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1/assignLicense", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	if err := g.AssignLicense("u1", []string{"sku1"}); err != nil {
+		t.Errorf("GraphClient.AssignLicense() error = %v", err)
+	}
+	if err := g.RemoveLicense("u1", []string{"sku2"}); err != nil {
+		t.Errorf("GraphClient.RemoveLicense() error = %v", err)
+	}
+	want := []string{
+		`{"addLicenses":[{"skuId":"sku1","disabledPlans":[]}],"removeLicenses":[]}`,
+		`{"addLicenses":[],"removeLicenses":["sku2"]}`,
+	}
+	if fmt.Sprint(bodies) != fmt.Sprint(want) {
+		t.Errorf("request bodies = %v, want %v", bodies, want)
+	}
+}
@@ -0,0 +1,210 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Forwarding actions of a ForwardingRule
+const (
+	ForwardingActionForwardTo             = "forwardTo"
+	ForwardingActionForwardAsAttachmentTo = "forwardAsAttachmentTo"
+	ForwardingActionRedirectTo            = "redirectTo"
+)
+
+// ForwardingReport lists the inbox rules of a mailbox that forward or redirect messages, see GetMailboxForwarding
+type ForwardingReport struct {
+	UserIdentifier string
+	Rules          []ForwardingRule
+	// DelegateMeetingMessageDeliveryOptions of the mailboxSettings, i.e. whether meeting messages are delivered to
+	// the delegates of the mailbox, e.g. sendToDelegateOnly
+	DelegateMeetingMessageDeliveryOptions string
+}
+
+func (r ForwardingReport) String() string {
+	return fmt.Sprintf("ForwardingReport(UserIdentifier: \"%v\", Rules: \"%v\", DelegateMeetingMessageDeliveryOptions: \"%v\")",
+		r.UserIdentifier, r.Rules, r.DelegateMeetingMessageDeliveryOptions)
+}
+
+// ExternalRules returns the enabled rules that forward or redirect to at least one external address
+func (r ForwardingReport) ExternalRules() []ForwardingRule {
+	var external []ForwardingRule
+	for _, rule := range r.Rules {
+		if rule.IsEnabled && rule.IsExternal() {
+			external = append(external, rule)
+		}
+	}
+	return external
+}
+
+// ForwardingRule is a forwarding action of an inbox rule, a rule with several forwarding actions results in one
+// ForwardingRule per action
+type ForwardingRule struct {
+	RuleID    string
+	RuleName  string
+	IsEnabled bool
+	Action    string // one of the ForwardingAction constants
+	Targets   []ForwardingTarget
+}
+
+func (r ForwardingRule) String() string {
+	return fmt.Sprintf("ForwardingRule(RuleID: \"%v\", RuleName: \"%v\", IsEnabled: \"%v\", Action: \"%v\", Targets: \"%v\")",
+		r.RuleID, r.RuleName, r.IsEnabled, r.Action, r.Targets)
+}
+
+// IsExternal returns true if any target of the rule is external
+func (r ForwardingRule) IsExternal() bool {
+	for _, target := range r.Targets {
+		if target.External {
+			return true
+		}
+	}
+	return false
+}
+
+// ForwardingTarget is an address a ForwardingRule forwards to. It is internal if its domain is a verified domain of
+// the tenant.
+type ForwardingTarget struct {
+	Address  string
+	External bool
+}
+
+func (t ForwardingTarget) String() string {
+	return fmt.Sprintf("ForwardingTarget(Address: \"%v\", External: \"%v\")", t.Address, t.External)
+}
+
+// isInternalAddress returns true if the domain of the address is one of the given domains, case-insensitive. A
+// subdomain of a verified domain is only internal if it is verified itself, as it may be hosted outside of the tenant.
+// Addresses without a domain are considered external, as their target cannot be verified.
+func isInternalAddress(address string, domains []string) bool {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(address[at+1:])), ".")
+	if domain == "" {
+		return false
+	}
+	for _, verified := range domains {
+		verified = strings.TrimSuffix(strings.ToLower(verified), ".")
+		if verified != "" && domain == verified {
+			return true
+		}
+	}
+	return false
+}
+
+// verifiedDomainNames returns the names of the verified domains of the tenant
+func (g *GraphClient) verifiedDomainNames(ctx context.Context) ([]string, error) {
+	domains, err := g.ListDomainsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list domains: %w", err)
+	}
+	var names []string
+	for _, domain := range domains {
+		if domain.IsVerified {
+			names = append(names, domain.ID)
+		}
+	}
+	return names, nil
+}
+
+// GetMailboxForwarding returns the inbox rules of the mailbox of the user identified by either the given ID or
+// userPrincipalName that forward or redirect messages. Their targets are classified as internal or external
+// against the verified domains of the tenant, see ForwardingReport.ExternalRules. Use DisableMessageRule to
+// remediate an external forwarding.
+//
+// Forwarding configured by an administrator in Exchange (forwardingSmtpAddress) is not exposed by msgraph, hence
+// it is not part of the report.
+func (g *GraphClient) GetMailboxForwarding(identifier string) (ForwardingReport, error) {
+	return g.GetMailboxForwardingContext(context.Background(), identifier)
+}
+
+// GetMailboxForwardingContext is GetMailboxForwarding with a context.
+func (g *GraphClient) GetMailboxForwardingContext(ctx context.Context, identifier string) (ForwardingReport, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return ForwardingReport{}, err
+	}
+	domains, err := g.verifiedDomainNames(ctx)
+	if err != nil {
+		return ForwardingReport{}, err
+	}
+	return g.getMailboxForwarding(ctx, identifier, domains)
+}
+
+// getMailboxForwarding returns the ForwardingReport of the mailbox, classified against the given verified domains
+func (g *GraphClient) getMailboxForwarding(ctx context.Context, identifier string, domains []string) (ForwardingReport, error) {
+	report := ForwardingReport{UserIdentifier: identifier}
+	var mailboxSettings struct {
+		DelegateMeetingMessageDeliveryOptions string `json:"delegateMeetingMessageDeliveryOptions"`
+	}
+	if err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v/mailboxSettings", identifier), nil, &mailboxSettings); err != nil {
+		return report, fmt.Errorf("cannot get mailboxSettings: %w", err)
+	}
+	report.DelegateMeetingMessageDeliveryOptions = mailboxSettings.DelegateMeetingMessageDeliveryOptions
+
+	rules, err := g.ListMessageRulesContext(ctx, identifier)
+	if err != nil {
+		return report, fmt.Errorf("cannot list message rules: %w", err)
+	}
+	for _, rule := range rules {
+		for _, action := range []struct {
+			name       string
+			recipients []Recipient
+		}{
+			{ForwardingActionForwardTo, rule.Actions.ForwardTo},
+			{ForwardingActionForwardAsAttachmentTo, rule.Actions.ForwardAsAttachmentTo},
+			{ForwardingActionRedirectTo, rule.Actions.RedirectTo},
+		} {
+			if len(action.recipients) == 0 {
+				continue
+			}
+			forwarding := ForwardingRule{RuleID: rule.ID, RuleName: rule.DisplayName, IsEnabled: rule.IsEnabled, Action: action.name}
+			for _, recipient := range action.recipients {
+				address := recipient.EmailAddress.Address
+				forwarding.Targets = append(forwarding.Targets, ForwardingTarget{Address: address, External: !isInternalAddress(address, domains)})
+			}
+			report.Rules = append(report.Rules, forwarding)
+		}
+	}
+	return report, nil
+}
+
+// AuditForwardingAcrossUsers returns the ForwardingReport of every user of the tenant, keyed by the
+// userPrincipalName, see GetMailboxForwarding. At most concurrency mailboxes are audited at the same time. Users
+// whose mailbox could not be audited, e.g. because they have none, are missing and their error is returned in the
+// second map instead.
+func (g *GraphClient) AuditForwardingAcrossUsers(concurrency int, opts ...BulkOption) (map[string]ForwardingReport, map[string]error, error) {
+	return g.AuditForwardingAcrossUsersContext(context.Background(), concurrency, opts...)
+}
+
+// AuditForwardingAcrossUsersContext is AuditForwardingAcrossUsers with a context.
+func (g *GraphClient) AuditForwardingAcrossUsersContext(ctx context.Context, concurrency int, opts ...BulkOption) (map[string]ForwardingReport, map[string]error, error) {
+	domains, err := g.verifiedDomainNames(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	users, err := g.ListUsersContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list users: %w", err)
+	}
+	identifiers := make([]string, len(users))
+	for i, user := range users {
+		identifiers[i] = user.UserPrincipalName
+	}
+	reports := make([]ForwardingReport, len(identifiers))
+	errs := forEachIdentifier(identifiers, concurrency, opts, func(i int, identifier string) error {
+		var err error
+		reports[i], err = g.getMailboxForwarding(ctx, identifier, domains)
+		return err
+	})
+
+	result := make(map[string]ForwardingReport, len(identifiers))
+	for i, identifier := range identifiers {
+		if errs[identifier] == nil {
+			result[identifier] = reports[i]
+		}
+	}
+	return result, errs, nil
+}
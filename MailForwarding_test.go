@@ -0,0 +1,83 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func Test_isInternalAddress(t *testing.T) {
+	domains := []string{"contoso.com", "Contoso.onmicrosoft.com", "fabrikam.de."}
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"alice@contoso.com", true},
+		{"Alice@CONTOSO.COM", true},
+		{"alice@mail.contoso.com", false},
+		{"alice@contoso.com.", true},
+		{"alice@contoso.onmicrosoft.com", true},
+		{"bob@fabrikam.de", true},
+		{"eve@evilcontoso.com", false},
+		{"eve@contoso.com.evil.com", false},
+		{"eve@evil.onmicrosoft.com", false},
+		{"eve@onmicrosoft.com", false},
+		{"eve@gmail.com", false},
+		{"contoso.com", false},
+		{"alice@", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isInternalAddress(tt.address, domains); got != tt.want {
+			t.Errorf("isInternalAddress(%q) = %v, want %v", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestGraphClient_AuditForwardingAcrossUsers(t *testing.T) {
+	var disabled map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "contoso.com", "isVerified": true}, {"id": "contoso.onmicrosoft.com", "isVerified": true, "isInitial": true},
+			{"id": "sub.contoso.com", "isVerified": true}, {"id": "unverified.com", "isVerified": false}]}`)
+	})
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "u1", "userPrincipalName": "alice@contoso.com"}, {"id": "u2", "userPrincipalName": "bob@contoso.com"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice@contoso.com/mailboxSettings", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"delegateMeetingMessageDeliveryOptions": "sendToDelegateOnly"}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice@contoso.com/mailFolders/inbox/messageRules", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"id": "r1", "displayName": "to team", "isEnabled": true, "actions": {"forwardTo": [{"emailAddress": {"address": "team@sub.contoso.com"}}]}},
+			{"id": "r2", "displayName": "to home", "isEnabled": true, "actions": {"redirectTo": [{"emailAddress": {"address": "alice@unverified.com"}}],
+				"forwardAsAttachmentTo": [{"emailAddress": {"address": "archive@contoso.onmicrosoft.com"}}]}},
+			{"id": "r3", "displayName": "move", "isEnabled": true, "actions": {}}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice@contoso.com/mailFolders/inbox/messageRules/r2", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&disabled)
+		fmt.Fprint(w, `{"id": "r2", "isEnabled": false}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	reports, errs, err := g.AuditForwardingAcrossUsers(2)
+	if err != nil {
+		t.Fatalf("GraphClient.AuditForwardingAcrossUsers() error = %v", err)
+	}
+	if len(errs) != 1 || !hasStatusCode(errs["bob@contoso.com"], http.StatusNotFound) {
+		t.Errorf("GraphClient.AuditForwardingAcrossUsers() errors = %v, want a 404 of bob only", errs)
+	}
+	report := reports["alice@contoso.com"]
+	if len(report.Rules) != 3 || report.DelegateMeetingMessageDeliveryOptions != "sendToDelegateOnly" {
+		t.Fatalf("GraphClient.AuditForwardingAcrossUsers() report of alice = %v, want 3 forwarding rules", report)
+	}
+	external := report.ExternalRules()
+	if len(external) != 1 || external[0].RuleID != "r2" || external[0].Action != ForwardingActionRedirectTo {
+		t.Fatalf("ForwardingReport.ExternalRules() = %v, want the redirect of r2", external)
+	}
+
+	if err := g.DisableMessageRule("alice@contoso.com", external[0].RuleID); err != nil || disabled["isEnabled"] != false {
+		t.Errorf("GraphClient.DisableMessageRule() error = %v with body %v, want isEnabled false", err, disabled)
+	}
+}
@@ -0,0 +1,210 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// mailTipsChunkSize is the maximum number of recipients requested per getMailTips API-call, larger recipient
+// lists are split into several API-calls
+const mailTipsChunkSize = 20
+
+// MailTipsType is a type of mail tip that is requested by GetMailTips
+type MailTipsType string
+
+// Mail tip types accepted by msgraph in MailTipsOptions
+const (
+	MailTipsTypeAutomaticReplies     MailTipsType = "automaticReplies"
+	MailTipsTypeMailboxFullStatus    MailTipsType = "mailboxFullStatus"
+	MailTipsTypeCustomMailTip        MailTipsType = "customMailTip"
+	MailTipsTypeExternalMemberCount  MailTipsType = "externalMemberCount"
+	MailTipsTypeTotalMemberCount     MailTipsType = "totalMemberCount"
+	MailTipsTypeMaxMessageSize       MailTipsType = "maxMessageSize"
+	MailTipsTypeDeliveryRestriction  MailTipsType = "deliveryRestriction"
+	MailTipsTypeModerationStatus     MailTipsType = "moderationStatus"
+	MailTipsTypeRecipientScope       MailTipsType = "recipientScope"
+	MailTipsTypeRecipientSuggestions MailTipsType = "recipientSuggestions"
+)
+
+// MailTips are the mail tips of a recipient, only the fields of the requested MailTipsType are set
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/mailtips
+type MailTips struct {
+	EmailAddress        string
+	AutomaticReplies    AutomaticRepliesMailTips // set if the recipient has automatic replies, e.g. is out of office
+	MailboxFull         bool
+	CustomMailTip       string
+	ExternalMemberCount int // of a distribution list
+	TotalMemberCount    int // of a distribution list
+	MaxMessageSize      int // in bytes
+	DeliveryRestricted  bool
+	IsModerated         bool
+	RecipientScope      string // e.g. "internal" or "external"
+	Error               error  // the error msgraph returned for this recipient, e.g. if it cannot be resolved
+}
+
+func (m MailTips) String() string {
+	return fmt.Sprintf("MailTips(EmailAddress: \"%v\", AutomaticReplies: %v, MailboxFull: \"%v\", CustomMailTip: \"%v\", "+
+		"ExternalMemberCount: \"%v\", TotalMemberCount: \"%v\", MaxMessageSize: \"%v\", DeliveryRestricted: \"%v\", "+
+		"IsModerated: \"%v\", RecipientScope: \"%v\", Error: \"%v\")",
+		m.EmailAddress, m.AutomaticReplies, m.MailboxFull, m.CustomMailTip, m.ExternalMemberCount, m.TotalMemberCount,
+		m.MaxMessageSize, m.DeliveryRestricted, m.IsModerated, m.RecipientScope, m.Error)
+}
+
+// HasAutomaticReplies returns true if the recipient has automatic replies turned on, e.g. is out of office
+func (m MailTips) HasAutomaticReplies() bool {
+	return m.AutomaticReplies.Message != ""
+}
+
+// AutomaticRepliesMailTips is the automatic reply of a recipient, the scheduled times are zero if
+// the automatic replies are not scheduled
+type AutomaticRepliesMailTips struct {
+	Message            string
+	MessageLanguage    string
+	ScheduledStartTime time.Time
+	ScheduledEndTime   time.Time
+}
+
+func (a AutomaticRepliesMailTips) String() string {
+	return fmt.Sprintf("AutomaticRepliesMailTips(Message: \"%v\", MessageLanguage: \"%v\", ScheduledStartTime: \"%v\", ScheduledEndTime: \"%v\")",
+		a.Message, a.MessageLanguage, a.ScheduledStartTime, a.ScheduledEndTime)
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (m *MailTips) UnmarshalJSON(data []byte) error {
+	type dateTimeTimeZone struct {
+		DateTime string `json:"dateTime"`
+		TimeZone string `json:"timeZone"`
+	}
+	tmp := struct {
+		EmailAddress struct {
+			Address string `json:"address"`
+		} `json:"emailAddress"`
+		AutomaticReplies struct {
+			Message         string `json:"message"`
+			MessageLanguage struct {
+				Locale string `json:"locale"`
+			} `json:"messageLanguage"`
+			ScheduledStartTime dateTimeTimeZone `json:"scheduledStartTime"`
+			ScheduledEndTime   dateTimeTimeZone `json:"scheduledEndTime"`
+		} `json:"automaticReplies"`
+		MailboxFull         bool   `json:"mailboxFull"`
+		CustomMailTip       string `json:"customMailTip"`
+		ExternalMemberCount int    `json:"externalMemberCount"`
+		TotalMemberCount    int    `json:"totalMemberCount"`
+		MaxMessageSize      int    `json:"maxMessageSize"`
+		DeliveryRestricted  bool   `json:"deliveryRestricted"`
+		IsModerated         bool   `json:"isModerated"`
+		RecipientScope      string `json:"recipientScope"`
+		Error               *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("cannot UnmarshalJSON: %v | Data: %v", err, string(data))
+	}
+
+	m.EmailAddress = tmp.EmailAddress.Address
+	m.AutomaticReplies = AutomaticRepliesMailTips{
+		Message:         tmp.AutomaticReplies.Message,
+		MessageLanguage: tmp.AutomaticReplies.MessageLanguage.Locale,
+	}
+	var err error
+	if start := tmp.AutomaticReplies.ScheduledStartTime; start.DateTime != "" {
+		m.AutomaticReplies.ScheduledStartTime, err = parseTimeAndLocation(start.DateTime, start.TimeZone)
+		if err != nil {
+			return fmt.Errorf("cannot parse scheduledStartTime %v AND timeZone %v: %v", start.DateTime, start.TimeZone, err)
+		}
+	}
+	if end := tmp.AutomaticReplies.ScheduledEndTime; end.DateTime != "" {
+		m.AutomaticReplies.ScheduledEndTime, err = parseTimeAndLocation(end.DateTime, end.TimeZone)
+		if err != nil {
+			return fmt.Errorf("cannot parse scheduledEndTime %v AND timeZone %v: %v", end.DateTime, end.TimeZone, err)
+		}
+	}
+	m.MailboxFull = tmp.MailboxFull
+	m.CustomMailTip = tmp.CustomMailTip
+	m.ExternalMemberCount = tmp.ExternalMemberCount
+	m.TotalMemberCount = tmp.TotalMemberCount
+	m.MaxMessageSize = tmp.MaxMessageSize
+	m.DeliveryRestricted = tmp.DeliveryRestricted
+	m.IsModerated = tmp.IsModerated
+	m.RecipientScope = tmp.RecipientScope
+	m.Error = nil
+	if tmp.Error != nil {
+		m.Error = fmt.Errorf("%v: %v", tmp.Error.Code, tmp.Error.Message)
+	}
+	return nil
+}
+
+// GetMailTips returns the mail tips of the given types for the recipients with the given addresses, as seen by the
+// user, e.g. to skip recipients that are out of office before sending bulk mail. The mail tips are returned in the
+// order of the addresses, large recipient lists are split into several API-calls.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-getmailtips
+func (u User) GetMailTips(addresses []string, tipsTypes []MailTipsType) ([]MailTips, error) {
+	return u.GetMailTipsContext(context.Background(), addresses, tipsTypes)
+}
+
+// GetMailTipsContext is GetMailTips with a context.
+func (u User) GetMailTipsContext(ctx context.Context, addresses []string, tipsTypes []MailTipsType) ([]MailTips, error) {
+	if u.graphClient == nil {
+		return nil, ErrNotGraphClientSourced
+	}
+	if err := u.graphClient.checkUserIdentifier(u.ID); err != nil {
+		return nil, err
+	}
+	return u.graphClient.getMailTips(ctx, fmt.Sprintf("/users/%v", u.ID), addresses, tipsTypes)
+}
+
+// GetMailTips returns the mail tips of the given types for the recipients with the given addresses, as seen by the
+// signed-in user, see User.GetMailTips
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-getmailtips
+func (m SignedInUser) GetMailTips(addresses []string, tipsTypes []MailTipsType) ([]MailTips, error) {
+	return m.GetMailTipsContext(context.Background(), addresses, tipsTypes)
+}
+
+// GetMailTipsContext is GetMailTips with a context.
+func (m SignedInUser) GetMailTipsContext(ctx context.Context, addresses []string, tipsTypes []MailTipsType) ([]MailTips, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	return m.graphClient.getMailTips(ctx, meResource, addresses, tipsTypes)
+}
+
+// getMailTips returns the mail tips of the addresses as seen by the user resource, e.g. /users/{id} or /me
+func (g *GraphClient) getMailTips(ctx context.Context, userResource string, addresses []string, tipsTypes []MailTipsType) ([]MailTips, error) {
+	if len(tipsTypes) == 0 {
+		return nil, fmt.Errorf("no mail tips types given")
+	}
+	options := make([]string, len(tipsTypes))
+	for i, tipsType := range tipsTypes {
+		options[i] = string(tipsType)
+	}
+
+	var mailTips []MailTips
+	for start := 0; start < len(addresses); start += mailTipsChunkSize {
+		end := start + mailTipsChunkSize
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+		body := struct {
+			EmailAddresses  []string `json:"EmailAddresses"`
+			MailTipsOptions string   `json:"MailTipsOptions"`
+		}{EmailAddresses: addresses[start:end], MailTipsOptions: strings.Join(options, ", ")}
+
+		var marsh struct {
+			MailTips []MailTips `json:"value"`
+		}
+		if err := g.makePOSTAPICall(ctx, userResource+"/getMailTips", body, &marsh); err != nil {
+			return mailTips, err
+		}
+		mailTips = append(mailTips, marsh.MailTips...)
+	}
+	return mailTips, nil
+}
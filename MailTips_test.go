@@ -0,0 +1,62 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUser_GetMailTips(t *testing.T) {
+	var chunks []int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/sender@contoso.com/getMailTips", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			EmailAddresses  []string
+			MailTipsOptions string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("cannot decode body: %v", err)
+		}
+		if body.MailTipsOptions != "automaticReplies, mailboxFullStatus" {
+			t.Errorf("MailTipsOptions = %v", body.MailTipsOptions)
+		}
+		chunks = append(chunks, len(body.EmailAddresses))
+		var values []string
+		for _, address := range body.EmailAddresses {
+			switch address {
+			case "alex@contoso.com":
+				values = append(values, `{"emailAddress": {"address": "alex@contoso.com"}, "automaticReplies": {"message": "<p>Out of office</p>",
+					"messageLanguage": {"locale": "en-US"}, "scheduledStartTime": {"dateTime": "2021-03-04T08:00:00.0000000", "timeZone": "UTC"},
+					"scheduledEndTime": {"dateTime": "2021-03-12T08:00:00.0000000", "timeZone": "UTC"}}, "mailboxFull": false}`)
+			case "full@contoso.com":
+				values = append(values, `{"emailAddress": {"address": "full@contoso.com"}, "automaticReplies": {"message": ""}, "mailboxFull": true}`)
+			default:
+				values = append(values, fmt.Sprintf(`{"emailAddress": {"address": "%v"}, "automaticReplies": {"message": ""}, "mailboxFull": false}`, address))
+			}
+		}
+		fmt.Fprintf(w, `{"value": [%v]}`, strings.Join(values, ","))
+	})
+	g := newTestGraphClient(t, mux)
+
+	addresses := []string{"alex@contoso.com", "full@contoso.com"}
+	for i := 0; i < mailTipsChunkSize; i++ {
+		addresses = append(addresses, fmt.Sprintf("user%v@contoso.com", i))
+	}
+	user := User{ID: "sender@contoso.com", graphClient: g}
+	got, err := user.GetMailTips(addresses, []MailTipsType{MailTipsTypeAutomaticReplies, MailTipsTypeMailboxFullStatus})
+	if err != nil {
+		t.Fatalf("User.GetMailTips() error = %v", err)
+	}
+	if fmt.Sprint(chunks) != fmt.Sprintf("[%v 2]", mailTipsChunkSize) || len(got) != len(addresses) {
+		t.Fatalf("User.GetMailTips() requested chunks %v and returned %v mail tips, want [%v 2] and %v", chunks, len(got), mailTipsChunkSize, len(addresses))
+	}
+	if !got[0].HasAutomaticReplies() || !got[0].AutomaticReplies.ScheduledEndTime.Equal(time.Date(2021, 3, 12, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("User.GetMailTips()[0] = %v, want automatic replies until 2021-03-12", got[0])
+	}
+	if got[1].HasAutomaticReplies() || !got[1].MailboxFull || got[2].MailboxFull {
+		t.Errorf("User.GetMailTips()[1:3] = %v, want only full@contoso.com to have a full mailbox", got[1:3])
+	}
+}
@@ -0,0 +1,73 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// managedIdentityEndpoint is the token endpoint of the Azure Instance Metadata Service (IMDS), which is reachable
+// from Azure VMs, VM scale sets and AKS nodes only
+const managedIdentityEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// NewGraphClientWithManagedIdentity creates a new GraphClient instance that acquires its token from the managed
+// identity of the Azure host, hence no client secret is needed. The clientID selects a user-assigned identity, the
+// system-assigned identity is used if it is empty. The opts are applied as for NewGraphClient.
+//
+// App Service and Azure Functions provide the token endpoint in IDENTITY_ENDPOINT and IDENTITY_HEADER, environments
+// like the Cloud Shell in MSI_ENDPOINT, otherwise the Instance Metadata Service of the VM is used.
+//
+// See https://docs.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/how-to-use-vm-token
+func NewGraphClientWithManagedIdentity(clientID string, opts ...ClientOption) (*GraphClient, error) {
+	withManagedIdentity := func(g *GraphClient) error {
+		g.managedIdentity = true
+		return nil
+	}
+	return NewGraphClient("", clientID, "", append([]ClientOption{withManagedIdentity}, opts...)...)
+}
+
+// refreshManagedIdentityToken acquires a new token for the managed identity of the Azure host, the ApplicationID
+// is the client ID of a user-assigned identity or empty for the system-assigned identity
+func (g *GraphClient) refreshManagedIdentityToken(ctx context.Context) error {
+	tokenResource := g.tokenResource
+	if tokenResource == "" {
+		tokenResource = g.endpoints().BaseURL
+	}
+	endpoint, header := managedIdentityEndpoint, http.Header{}
+	data := url.Values{}
+	data.Add("resource", tokenResource)
+	if identityEndpoint := os.Getenv("IDENTITY_ENDPOINT"); identityEndpoint != "" && os.Getenv("IDENTITY_HEADER") != "" {
+		endpoint = identityEndpoint // App Service and Azure Functions
+		header.Set("X-IDENTITY-HEADER", os.Getenv("IDENTITY_HEADER"))
+		data.Add("api-version", "2019-08-01")
+	} else {
+		if msiEndpoint := os.Getenv("MSI_ENDPOINT"); msiEndpoint != "" {
+			endpoint = msiEndpoint
+		}
+		header.Set("Metadata", "true")
+		data.Add("api-version", "2018-02-01")
+	}
+	if g.ApplicationID != "" {
+		data.Add("client_id", g.ApplicationID)
+	}
+
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to parse URI: %v", err)
+	}
+	u.RawQuery = data.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("HTTP Request Error: %v", err)
+	}
+	req.Header = header
+
+	var newToken Token
+	if err := g.performRequest(req, &newToken, requestOptions{}); err != nil {
+		return fmt.Errorf("error on getting msgraph Token of the managed identity: %w", err)
+	}
+	g.token = newToken
+	return nil
+}
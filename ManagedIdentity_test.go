@@ -0,0 +1,52 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewGraphClientWithManagedIdentity(t *testing.T) {
+	for _, key := range []string{"IDENTITY_ENDPOINT", "IDENTITY_HEADER", "MSI_ENDPOINT"} {
+		orig, ok := os.LookupEnv(key)
+		os.Unsetenv(key)
+		if ok {
+			defer os.Setenv(key, orig)
+		}
+	}
+
+	var queries []url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata/identity/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.Header.Get("Metadata") != "true" {
+			t.Errorf("IMDS request = %v with Metadata %q, want GET with Metadata true", r.Method, r.Header.Get("Metadata"))
+		}
+		queries = append(queries, r.URL.Query())
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "expires_in": "3599", "resource": "https://graph.microsoft.com", "access_token": "%v"}`,
+			time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix(), testAppToken)
+	})
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClientWithManagedIdentity("")
+	if err != nil || len(queries) != 1 {
+		t.Fatalf("NewGraphClientWithManagedIdentity() error = %v after %v token requests, want 1", err, len(queries))
+	}
+	if query := queries[0]; query.Get("resource") != BaseURL || query.Get("api-version") != "2018-02-01" || query.Get("client_id") != "" {
+		t.Errorf("IMDS query = %v, want the resource %v of the system-assigned identity", query, BaseURL)
+	}
+
+	g.token.ExpiresOn = time.Now() // wants to be refreshed on the next API-call
+	if _, err := g.GetUser("u1"); err != nil || len(queries) != 2 {
+		t.Errorf("GraphClient.GetUser() error = %v after %v token requests, want 2", err, len(queries))
+	}
+
+	if _, err := NewGraphClientWithManagedIdentity("user-assigned"); err != nil || queries[len(queries)-1].Get("client_id") != "user-assigned" {
+		t.Errorf("NewGraphClientWithManagedIdentity() error = %v with query %v, want the client_id of the user-assigned identity", err, queries[len(queries)-1])
+	}
+}
@@ -0,0 +1,182 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// meResource is the resource of the signed-in user of a delegated token
+const meResource = "/me"
+
+// SignedInUser is a handle on the user that signed in to obtain the delegated token of the GraphClient, its API-calls
+// are routed to /me instead of /users/{id}. Create it with GraphClient.Me().
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-get
+type SignedInUser struct {
+	graphClient *GraphClient
+}
+
+// Me returns a handle on the signed-in user of a delegated token, e.g. of NewGraphClientWithDeviceCode. With an
+// application token, e.g. of the client credentials flow used by NewGraphClient, there is no signed-in user and
+// every API-call of the handle fails with ErrMeWithApplicationToken.
+func (g *GraphClient) Me() SignedInUser {
+	return SignedInUser{graphClient: g}
+}
+
+func (m SignedInUser) String() string {
+	return fmt.Sprintf("SignedInUser(DirectAPIConnection: %v)", m.graphClient != nil)
+}
+
+// check returns an error if the handle cannot perform API-calls
+func (m SignedInUser) check() error {
+	if m.graphClient == nil {
+		return ErrNotGraphClientSourced
+	}
+	if m.graphClient.hasApplicationToken() {
+		return ErrMeWithApplicationToken
+	}
+	return nil
+}
+
+// Get returns the signed-in user
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-get
+func (m SignedInUser) Get() (User, error) {
+	return m.GetContext(context.Background())
+}
+
+// GetContext is Get with a context.
+func (m SignedInUser) GetContext(ctx context.Context) (User, error) {
+	if err := m.check(); err != nil {
+		return User{}, err
+	}
+	user := User{graphClient: m.graphClient}
+	err := m.graphClient.makeGETAPICall(ctx, meResource, nil, &user)
+	return user, err
+}
+
+// ListCalendars returns all calendars of the signed-in user, see User.ListCalendars
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-calendars
+func (m SignedInUser) ListCalendars() (Calendars, error) {
+	return m.ListCalendarsContext(context.Background())
+}
+
+// ListCalendarsContext is ListCalendars with a context.
+func (m SignedInUser) ListCalendarsContext(ctx context.Context) (Calendars, error) {
+	if err := m.check(); err != nil {
+		return Calendars{}, err
+	}
+	return m.graphClient.listCalendars(ctx, meResource)
+}
+
+// ListCalendarView returns the CalendarEvents of the default calendar of the signed-in user within the specified
+// start- and endDateTime, see User.ListCalendarView
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-calendarview
+func (m SignedInUser) ListCalendarView(startDateTime, endDateTime time.Time) (CalendarEvents, error) {
+	return m.ListCalendarViewContext(context.Background(), startDateTime, endDateTime)
+}
+
+// ListCalendarViewContext is ListCalendarView with a context.
+func (m SignedInUser) ListCalendarViewContext(ctx context.Context, startDateTime, endDateTime time.Time) (CalendarEvents, error) {
+	if err := m.check(); err != nil {
+		return CalendarEvents{}, err
+	}
+	return m.graphClient.listCalendarView(ctx, meResource, startDateTime, endDateTime)
+}
+
+// SendMail sends the mail as the signed-in user, the From of the mail is ignored by msgraph. The opts are
+// applied to the API-call, e.g. IdempotencyKey.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-sendmail
+func (m SignedInUser) SendMail(mail Mail, opts ...RequestOption) error {
+	return m.SendMailContext(context.Background(), mail, opts...)
+}
+
+// SendMailContext is SendMail with a context.
+func (m SignedInUser) SendMailContext(ctx context.Context, mail Mail, opts ...RequestOption) error {
+	if err := m.check(); err != nil {
+		return err
+	}
+	return m.graphClient.makePOSTAPICall(ctx, meResource+"/sendMail", mail, nil, opts...)
+}
+
+// ListMessages returns all messages in the mailbox of the signed-in user
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-messages
+func (m SignedInUser) ListMessages() ([]Message, error) {
+	return m.ListMessagesContext(context.Background())
+}
+
+// ListMessagesContext is ListMessages with a context.
+func (m SignedInUser) ListMessagesContext(ctx context.Context) ([]Message, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	var messages []Message
+	err := m.graphClient.makePagedGETAPICall(ctx, meResource+"/messages", nil, func(value json.RawMessage) (bool, error) {
+		var page []Message
+		err := json.Unmarshal(value, &page)
+		messages = append(messages, page...)
+		return true, err
+	})
+	return messages, err
+}
+
+// GetDrive returns the OneDrive of the signed-in user
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/drive-get
+func (m SignedInUser) GetDrive() (Drive, error) {
+	return m.GetDriveContext(context.Background())
+}
+
+// GetDriveContext is GetDrive with a context.
+func (m SignedInUser) GetDriveContext(ctx context.Context) (Drive, error) {
+	if err := m.check(); err != nil {
+		return Drive{}, err
+	}
+	var drive Drive
+	err := m.graphClient.makeGETAPICall(ctx, meResource+"/drive", nil, &drive)
+	return drive, err
+}
+
+// ListDriveItems returns the files and folders within the folder identified by folderID of the OneDrive of the
+// signed-in user, see GraphClient.ListGroupDriveItems. An empty folderID lists the root folder.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/driveitem-list-children
+func (m SignedInUser) ListDriveItems(folderID string) ([]DriveItem, error) {
+	return m.ListDriveItemsContext(context.Background(), folderID)
+}
+
+// ListDriveItemsContext is ListDriveItems with a context.
+func (m SignedInUser) ListDriveItemsContext(ctx context.Context, folderID string) ([]DriveItem, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	resource := meResource + "/drive/root/children"
+	if folderID != "" {
+		resource = fmt.Sprintf("%v/drive/items/%v/children", meResource, folderID)
+	}
+	return m.graphClient.listDriveItems(ctx, resource)
+}
+
+// hasApplicationToken returns true if the current token is an application token, hence has no signed-in user.
+// Tokens that cannot be decoded are not treated as application tokens, see Token.isApplicationToken.
+func (g *GraphClient) hasApplicationToken() bool {
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	return g.token.isApplicationToken()
+}
+
+// checkUserIdentifier returns ErrMeWithApplicationToken if the user identifier is "me" and the current token is an
+// application token, msgraph would respond with a BadRequest that does not point to the cause.
+func (g *GraphClient) checkUserIdentifier(identifier string) error {
+	if strings.EqualFold(identifier, "me") && g.hasApplicationToken() {
+		return ErrMeWithApplicationToken
+	}
+	return nil
+}
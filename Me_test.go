@@ -0,0 +1,109 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSignedInUser_paths(t *testing.T) {
+	var paths []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/me/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v1.0/me/outlook/supportedTimeZones":
+			fmt.Fprint(w, `{"value": [{"alias": "W. Europe Standard Time", "displayName": "(UTC+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna"}]}`)
+		case "/v1.0/me/sendMail":
+			w.WriteHeader(http.StatusAccepted)
+		case "/v1.0/me/drive":
+			fmt.Fprint(w, `{"id": "b!drive", "name": "OneDrive", "driveType": "business"}`)
+		default:
+			fmt.Fprint(w, `{"value": []}`)
+		}
+	})
+	g := newTestGraphClient(t, mux)
+	g.token.AccessToken = testDelegatedToken
+	me := g.Me()
+
+	if _, err := me.ListCalendars(); err != nil {
+		t.Errorf("SignedInUser.ListCalendars() error = %v", err)
+	}
+	origTimeZones := globalSupportedTimeZones
+	globalSupportedTimeZones = supportedTimeZones{} // let ListCalendarView load them from /me
+	t.Cleanup(func() { globalSupportedTimeZones = origTimeZones })
+	if _, err := me.ListCalendarView(time.Now(), time.Now().Add(24*time.Hour)); err != nil {
+		t.Errorf("SignedInUser.ListCalendarView() error = %v", err)
+	}
+	if err := me.SendMail(MakeMail()); err != nil {
+		t.Errorf("SignedInUser.SendMail() error = %v", err)
+	}
+	if _, err := me.ListMessages(); err != nil {
+		t.Errorf("SignedInUser.ListMessages() error = %v", err)
+	}
+	if _, err := me.GetDrive(); err != nil {
+		t.Errorf("SignedInUser.GetDrive() error = %v", err)
+	}
+	if _, err := me.ListDriveItems("01FOLDER"); err != nil {
+		t.Errorf("SignedInUser.ListDriveItems() error = %v", err)
+	}
+
+	want := []string{
+		"GET /v1.0/me/calendars",
+		"GET /v1.0/me/outlook/supportedTimeZones",
+		"GET /v1.0/me/calendar/calendarview",
+		"POST /v1.0/me/sendMail",
+		"GET /v1.0/me/messages",
+		"GET /v1.0/me/drive",
+		"GET /v1.0/me/drive/items/01FOLDER/children",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("SignedInUser requests = %v, want %v", paths, want)
+	}
+}
+
+func TestGraphClient_applicationTokenRejectsMe(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+	})
+	g := newTestGraphClient(t, mux)
+	g.token.AccessToken = testAppToken
+
+	if _, err := g.Me().ListCalendars(); !errors.Is(err, ErrMeWithApplicationToken) {
+		t.Errorf("SignedInUser.ListCalendars() error = %v, want ErrMeWithApplicationToken", err)
+	}
+	if _, err := g.GetUser("me"); !errors.Is(err, ErrMeWithApplicationToken) {
+		t.Errorf("GraphClient.GetUser(\"me\") error = %v, want ErrMeWithApplicationToken", err)
+	}
+	if _, err := (User{ID: "Me", graphClient: g}).ListCalendars(); !errors.Is(err, ErrMeWithApplicationToken) {
+		t.Errorf("User.ListCalendars() of \"Me\" error = %v, want ErrMeWithApplicationToken", err)
+	}
+}
+
+func TestGraphClient_hasApplicationToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{name: "application token", token: testAppToken, want: true},
+		{name: "delegated token", token: testDelegatedToken, want: false},
+		{name: "roles without idtyp", token: testJWT(`{"roles":["User.Read.All"]}`), want: true},
+		{name: "scopes without idtyp", token: testJWT(`{"scp":"User.Read"}`), want: false},
+		{name: "opaque token", token: "EwBwA8l6BAAURSN/FHlDW5xN74t6GzbtsBBeBUYAAQ", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GraphClient{token: Token{AccessToken: tt.token}}
+			if got := g.hasApplicationToken(); got != tt.want {
+				t.Errorf("GraphClient.hasApplicationToken() = %v, want %v", got, tt.want)
+			}
+			if err := g.checkUserIdentifier("me"); errors.Is(err, ErrMeWithApplicationToken) != tt.want {
+				t.Errorf("GraphClient.checkUserIdentifier(\"me\") error = %v", err)
+			}
+		})
+	}
+}
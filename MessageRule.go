@@ -0,0 +1,87 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+)
+
+// MessageRule represents an inbox rule of a mailbox. Only the actions relevant to forwarding are unmarshalled.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/messagerule
+type MessageRule struct {
+	ID          string             `json:"id"`
+	DisplayName string             `json:"displayName"`
+	Sequence    int                `json:"sequence"`
+	IsEnabled   bool               `json:"isEnabled"`
+	HasError    bool               `json:"hasError"`
+	Actions     MessageRuleActions `json:"actions"`
+}
+
+func (r MessageRule) String() string {
+	return fmt.Sprintf("MessageRule(ID: \"%v\", DisplayName: \"%v\", Sequence: \"%v\", IsEnabled: \"%v\", HasError: \"%v\")",
+		r.ID, r.DisplayName, r.Sequence, r.IsEnabled, r.HasError)
+}
+
+// MessageRuleActions are the forwarding actions of a MessageRule
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/messageruleactions
+type MessageRuleActions struct {
+	ForwardTo             []Recipient `json:"forwardTo"`
+	ForwardAsAttachmentTo []Recipient `json:"forwardAsAttachmentTo"`
+	RedirectTo            []Recipient `json:"redirectTo"`
+}
+
+// MessageRuleUpdate contains the properties of a MessageRule that are changed by GraphClient.UpdateMessageRule. Only
+// properties that are set are sent to msgraph.
+type MessageRuleUpdate struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Sequence    int    `json:"sequence,omitempty"`
+	IsEnabled   *bool  `json:"isEnabled,omitempty"`
+}
+
+// ListMessageRules returns the inbox rules of the mailbox of the user identified by either the given ID or
+// userPrincipalName
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/mailfolder-list-messagerules
+func (g *GraphClient) ListMessageRules(identifier string) ([]MessageRule, error) {
+	return g.ListMessageRulesContext(context.Background(), identifier)
+}
+
+// ListMessageRulesContext is ListMessageRules with a context.
+func (g *GraphClient) ListMessageRulesContext(ctx context.Context, identifier string) ([]MessageRule, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	var marsh struct {
+		MessageRules []MessageRule `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v/mailFolders/inbox/messageRules", identifier), nil, &marsh)
+	return marsh.MessageRules, err
+}
+
+// UpdateMessageRule updates the properties of the inbox rule identified by ruleID that are set in update
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/messagerule-update
+func (g *GraphClient) UpdateMessageRule(identifier, ruleID string, update MessageRuleUpdate) error {
+	return g.UpdateMessageRuleContext(context.Background(), identifier, ruleID, update)
+}
+
+// UpdateMessageRuleContext is UpdateMessageRule with a context.
+func (g *GraphClient) UpdateMessageRuleContext(ctx context.Context, identifier, ruleID string, update MessageRuleUpdate) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	return g.makePATCHAPICall(ctx, fmt.Sprintf("/users/%v/mailFolders/inbox/messageRules/%v", identifier, ruleID), update, nil)
+}
+
+// DisableMessageRule disables the inbox rule identified by ruleID without deleting it, e.g. to remediate an
+// external forwarding found by GetMailboxForwarding
+func (g *GraphClient) DisableMessageRule(identifier, ruleID string) error {
+	return g.DisableMessageRuleContext(context.Background(), identifier, ruleID)
+}
+
+// DisableMessageRuleContext is DisableMessageRule with a context.
+func (g *GraphClient) DisableMessageRuleContext(ctx context.Context, identifier, ruleID string) error {
+	disabled := false
+	return g.UpdateMessageRuleContext(ctx, identifier, ruleID, MessageRuleUpdate{IsEnabled: &disabled})
+}
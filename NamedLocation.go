@@ -0,0 +1,234 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// odataTypeIPNamedLocation is the @odata.type of a NamedLocation that is defined by IP ranges
+	odataTypeIPNamedLocation = "#microsoft.graph.ipNamedLocation"
+	// odataTypeCountryNamedLocation is the @odata.type of a NamedLocation that is defined by countries and regions
+	odataTypeCountryNamedLocation = "#microsoft.graph.countryNamedLocation"
+	// odataTypeIPv4CidrRange is the @odata.type of an IPv4 range within an ipNamedLocation
+	odataTypeIPv4CidrRange = "#microsoft.graph.iPv4CidrRange"
+	// odataTypeIPv6CidrRange is the @odata.type of an IPv6 range within an ipNamedLocation
+	odataTypeIPv6CidrRange = "#microsoft.graph.iPv6CidrRange"
+)
+
+// NamedLocation represents a named location of the conditional access configuration. A NamedLocation
+// is either an ipNamedLocation (IsTrusted and IPRanges are set) or a countryNamedLocation
+// (CountriesAndRegions, IncludeUnknownCountriesAndRegions and CountryLookupMethod are set).
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/namedlocation
+type NamedLocation struct {
+	ODataType        string    // the @odata.type, use IsIPNamedLocation or IsCountryNamedLocation to check it
+	ID               string    // The identifier of the named location. Read-only.
+	DisplayName      string    // Human-readable name of the location.
+	CreatedDateTime  time.Time // The time the named location was created. Read-only.
+	ModifiedDateTime time.Time // The time the named location was last modified. Read-only.
+
+	IsTrusted bool     // ipNamedLocation only: true if this location is explicitly trusted.
+	IPRanges  []string // ipNamedLocation only: the IPv4 and IPv6 ranges in CIDR notation, e.g. 10.0.0.0/8
+
+	CountriesAndRegions               []string // countryNamedLocation only: list of countries and/or regions in two-letter format specified by ISO 3166-2.
+	IncludeUnknownCountriesAndRegions bool     // countryNamedLocation only: true if IP addresses that don't map to a country or region should be included.
+	CountryLookupMethod               string   // countryNamedLocation only: determines what method is used to decide which country the user is located in.
+}
+
+func (n NamedLocation) String() string {
+	return fmt.Sprintf("NamedLocation(ODataType: \"%v\", ID: \"%v\", DisplayName: \"%v\", CreatedDateTime: \"%v\", ModifiedDateTime: \"%v\", "+
+		"IsTrusted: \"%v\", IPRanges: \"%v\", CountriesAndRegions: \"%v\", IncludeUnknownCountriesAndRegions: \"%v\", CountryLookupMethod: \"%v\")",
+		n.ODataType, n.ID, n.DisplayName, n.CreatedDateTime, n.ModifiedDateTime, n.IsTrusted, n.IPRanges,
+		n.CountriesAndRegions, n.IncludeUnknownCountriesAndRegions, n.CountryLookupMethod)
+}
+
+// IsIPNamedLocation returns true if the NamedLocation is defined by IP ranges
+func (n NamedLocation) IsIPNamedLocation() bool {
+	return n.ODataType == odataTypeIPNamedLocation
+}
+
+// IsCountryNamedLocation returns true if the NamedLocation is defined by countries and regions
+func (n NamedLocation) IsCountryNamedLocation() bool {
+	return n.ODataType == odataTypeCountryNamedLocation
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (n *NamedLocation) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ODataType        string `json:"@odata.type"`
+		ID               string `json:"id"`
+		DisplayName      string `json:"displayName"`
+		CreatedDateTime  string `json:"createdDateTime"`
+		ModifiedDateTime string `json:"modifiedDateTime"`
+		IsTrusted        bool   `json:"isTrusted"`
+		IPRanges         []struct {
+			CidrAddress string `json:"cidrAddress"`
+		} `json:"ipRanges"`
+		CountriesAndRegions               []string `json:"countriesAndRegions"`
+		IncludeUnknownCountriesAndRegions bool     `json:"includeUnknownCountriesAndRegions"`
+		CountryLookupMethod               string   `json:"countryLookupMethod"`
+	}{}
+
+	err := json.Unmarshal(data, &tmp)
+	if err != nil {
+		return err
+	}
+
+	n.ODataType = tmp.ODataType
+	n.ID = tmp.ID
+	n.DisplayName = tmp.DisplayName
+	n.CreatedDateTime, err = time.Parse(time.RFC3339Nano, tmp.CreatedDateTime)
+	if err != nil && tmp.CreatedDateTime != "" {
+		return fmt.Errorf("cannot parse CreatedDateTime %v with RFC3339Nano: %v", tmp.CreatedDateTime, err)
+	}
+	n.ModifiedDateTime, err = time.Parse(time.RFC3339Nano, tmp.ModifiedDateTime)
+	if err != nil && tmp.ModifiedDateTime != "" {
+		return fmt.Errorf("cannot parse ModifiedDateTime %v with RFC3339Nano: %v", tmp.ModifiedDateTime, err)
+	}
+	n.IsTrusted = tmp.IsTrusted
+	n.IPRanges = make([]string, len(tmp.IPRanges))
+	for i, ipRange := range tmp.IPRanges {
+		n.IPRanges[i] = ipRange.CidrAddress
+	}
+	n.CountriesAndRegions = tmp.CountriesAndRegions
+	n.IncludeUnknownCountriesAndRegions = tmp.IncludeUnknownCountriesAndRegions
+	n.CountryLookupMethod = tmp.CountryLookupMethod
+
+	return nil
+}
+
+// ipRange represents a single iPv4CidrRange or iPv6CidrRange as used in the request body of an ipNamedLocation
+type ipRange struct {
+	ODataType   string `json:"@odata.type"`
+	CidrAddress string `json:"cidrAddress"`
+}
+
+// ipNamedLocationBody represents the request body to create or update an ipNamedLocation
+type ipNamedLocationBody struct {
+	ODataType   string    `json:"@odata.type"`
+	DisplayName string    `json:"displayName,omitempty"`
+	IsTrusted   *bool     `json:"isTrusted,omitempty"`
+	IPRanges    []ipRange `json:"ipRanges"`
+}
+
+// toIPRanges validates the given CIDRs and converts them into iPv4CidrRanges or iPv6CidrRanges.
+// Returns an error that contains all invalid CIDRs if any.
+func toIPRanges(cidrs []string) ([]ipRange, error) {
+	if len(cidrs) == 0 {
+		return nil, fmt.Errorf("at least one CIDR is required")
+	}
+	var invalid []string
+	ranges := make([]ipRange, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			invalid = append(invalid, cidr)
+			continue
+		}
+		if ip.To4() != nil {
+			ranges = append(ranges, ipRange{ODataType: odataTypeIPv4CidrRange, CidrAddress: cidr})
+		} else {
+			ranges = append(ranges, ipRange{ODataType: odataTypeIPv6CidrRange, CidrAddress: cidr})
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("invalid CIDR notation: %v", strings.Join(invalid, ", "))
+	}
+	return ranges, nil
+}
+
+// ListNamedLocations returns all named locations of the conditional access configuration
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/conditionalaccessroot-list-namedlocations
+func (g *GraphClient) ListNamedLocations() ([]NamedLocation, error) {
+	return g.ListNamedLocationsContext(context.Background())
+}
+
+// ListNamedLocationsContext is ListNamedLocations with a context.
+func (g *GraphClient) ListNamedLocationsContext(ctx context.Context) ([]NamedLocation, error) {
+	resource := "/identity/conditionalAccess/namedLocations"
+	var marsh struct {
+		NamedLocations []NamedLocation `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, resource, nil, &marsh)
+	return marsh.NamedLocations, err
+}
+
+// GetNamedLocation returns the named location identified by the given id
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/namedlocation-get
+func (g *GraphClient) GetNamedLocation(id string) (NamedLocation, error) {
+	return g.GetNamedLocationContext(context.Background(), id)
+}
+
+// GetNamedLocationContext is GetNamedLocation with a context.
+func (g *GraphClient) GetNamedLocationContext(ctx context.Context, id string) (NamedLocation, error) {
+	resource := fmt.Sprintf("/identity/conditionalAccess/namedLocations/%v", id)
+	var namedLocation NamedLocation
+	err := g.makeGETAPICall(ctx, resource, nil, &namedLocation)
+	return namedLocation, err
+}
+
+// CreateIPNamedLocation creates a new ipNamedLocation with the given IPv4 and/or IPv6 ranges in
+// CIDR notation. The CIDRs are validated before the API-call is performed.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/conditionalaccessroot-post-namedlocations
+func (g *GraphClient) CreateIPNamedLocation(displayName string, cidrs []string, isTrusted bool) (NamedLocation, error) {
+	return g.CreateIPNamedLocationContext(context.Background(), displayName, cidrs, isTrusted)
+}
+
+// CreateIPNamedLocationContext is CreateIPNamedLocation with a context.
+func (g *GraphClient) CreateIPNamedLocationContext(ctx context.Context, displayName string, cidrs []string, isTrusted bool) (NamedLocation, error) {
+	ranges, err := toIPRanges(cidrs)
+	if err != nil {
+		return NamedLocation{}, err
+	}
+	body := ipNamedLocationBody{ODataType: odataTypeIPNamedLocation, DisplayName: displayName, IsTrusted: &isTrusted, IPRanges: ranges}
+
+	var namedLocation NamedLocation
+	err = g.makePOSTAPICall(ctx, "/identity/conditionalAccess/namedLocations", body, &namedLocation)
+	return namedLocation, err
+}
+
+// UpdateIPNamedLocation replaces the IP ranges of the ipNamedLocation identified by the given id. The
+// CIDRs are validated before the API-call is performed.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/ipnamedlocation-update
+func (g *GraphClient) UpdateIPNamedLocation(id string, cidrs []string) error {
+	return g.UpdateIPNamedLocationContext(context.Background(), id, cidrs)
+}
+
+// UpdateIPNamedLocationContext is UpdateIPNamedLocation with a context.
+func (g *GraphClient) UpdateIPNamedLocationContext(ctx context.Context, id string, cidrs []string) error {
+	ranges, err := toIPRanges(cidrs)
+	if err != nil {
+		return err
+	}
+	resource := fmt.Sprintf("/identity/conditionalAccess/namedLocations/%v", id)
+	body := ipNamedLocationBody{ODataType: odataTypeIPNamedLocation, IPRanges: ranges}
+	return g.makePATCHAPICall(ctx, resource, body, nil)
+}
+
+// DeleteNamedLocation deletes the named location identified by the given id. Returns ErrNamedLocationInUse
+// if the named location is still referenced by a conditional access policy.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/namedlocation-delete
+func (g *GraphClient) DeleteNamedLocation(id string) error {
+	return g.DeleteNamedLocationContext(context.Background(), id)
+}
+
+// DeleteNamedLocationContext is DeleteNamedLocation with a context.
+func (g *GraphClient) DeleteNamedLocationContext(ctx context.Context, id string) error {
+	resource := fmt.Sprintf("/identity/conditionalAccess/namedLocations/%v", id)
+	err := g.makeDELETEAPICall(ctx, resource)
+	if hasStatusCode(err, http.StatusBadRequest) && strings.Contains(strings.ToLower(err.Error()), "referenced") {
+		return &sentinelError{sentinel: ErrNamedLocationInUse, err: err}
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_toIPRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidrs   []string
+		want    []ipRange
+		wantErr bool
+	}{
+		{
+			name:  "IPv4 and IPv6",
+			cidrs: []string{"12.34.221.11/22", "2001:0:9d38:90d6:0:0:0:0/63"},
+			want: []ipRange{
+				{ODataType: odataTypeIPv4CidrRange, CidrAddress: "12.34.221.11/22"},
+				{ODataType: odataTypeIPv6CidrRange, CidrAddress: "2001:0:9d38:90d6:0:0:0:0/63"},
+			},
+			wantErr: false,
+		}, {
+			name:    "missing prefix length",
+			cidrs:   []string{"10.0.0.1"},
+			wantErr: true,
+		}, {
+			name:    "invalid IPv4",
+			cidrs:   []string{"10.0.0.0/8", "300.0.0.0/8"},
+			wantErr: true,
+		}, {
+			name:    "invalid IPv6 prefix",
+			cidrs:   []string{"2001:db8::/129"},
+			wantErr: true,
+		}, {
+			name:    "empty",
+			cidrs:   []string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toIPRanges(tt.cidrs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toIPRanges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toIPRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamedLocation_UnmarshalJSON(t *testing.T) {
+	data := []byte(`{"value": [
+		{"@odata.type": "#microsoft.graph.ipNamedLocation", "id": "1", "displayName": "Office", "createdDateTime": "2021-06-01T10:00:00.1234567Z",
+		 "isTrusted": true, "ipRanges": [{"@odata.type": "#microsoft.graph.iPv4CidrRange", "cidrAddress": "12.34.221.11/22"}]},
+		{"@odata.type": "#microsoft.graph.countryNamedLocation", "id": "2", "displayName": "Austria",
+		 "countriesAndRegions": ["AT"], "includeUnknownCountriesAndRegions": false, "countryLookupMethod": "clientIpAddress"}
+	]}`)
+	var marsh struct {
+		NamedLocations []NamedLocation `json:"value"`
+	}
+	if err := json.Unmarshal(data, &marsh); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(marsh.NamedLocations) != 2 {
+		t.Fatalf("json.Unmarshal() len = %v, want 2", len(marsh.NamedLocations))
+	}
+	ipLocation, countryLocation := marsh.NamedLocations[0], marsh.NamedLocations[1]
+	if !ipLocation.IsIPNamedLocation() || !ipLocation.IsTrusted || !reflect.DeepEqual(ipLocation.IPRanges, []string{"12.34.221.11/22"}) {
+		t.Errorf("NamedLocation.UnmarshalJSON() ipNamedLocation = %v", ipLocation)
+	}
+	if !countryLocation.IsCountryNamedLocation() || !reflect.DeepEqual(countryLocation.CountriesAndRegions, []string{"AT"}) {
+		t.Errorf("NamedLocation.UnmarshalJSON() countryNamedLocation = %v", countryLocation)
+	}
+}
+
+func TestGraphClient_DeleteNamedLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    error
+	}{
+		{
+			name:       "deleted",
+			statusCode: http.StatusNoContent,
+		}, {
+			name:       "referenced by a policy",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error": {"code": "1037", "message": "The named location is referenced by one or more Conditional Access policies."}}`,
+			wantErr:    ErrNamedLocationInUse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGraphClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/v1.0/identity/conditionalAccess/namedLocations/1" {
+					t.Errorf("unexpected request %v %v", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				fmt.Fprint(w, tt.body)
+			}))
+			err := g.DeleteNamedLocation("1")
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("GraphClient.DeleteNamedLocation() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GraphClient.DeleteNamedLocation() error = %v, want %v", err, tt.wantErr)
+			}
+			var gerr *GraphError
+			if tt.wantErr != nil && (!errors.As(err, &gerr) || gerr.StatusCode != tt.statusCode) {
+				t.Errorf("GraphClient.DeleteNamedLocation() error = %v, want it to wrap the GraphError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package msgraph
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// notificationSignaturePrefix is the optional prefix of a notification signature, the same
+// format as used by GitHub webhooks: sha256=<hex encoded HMAC-SHA256>
+const notificationSignaturePrefix = "sha256="
+
+// ComputeNotificationSignature computes the HMAC-SHA256 over the raw notification body using the
+// given secret (normally the clientState of the subscription) as key. The signature is returned
+// hex encoded and prefixed with "sha256=".
+func ComputeNotificationSignature(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return notificationSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyNotificationSignature returns true if the given signature matches the HMAC-SHA256 of the raw
+// notification body computed with the given secret. The signature may be given with or without the
+// "sha256=" prefix. The comparison is done in constant time.
+func VerifyNotificationSignature(secret, body, signature string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected := ComputeNotificationSignature(secret, body)
+	if !strings.HasPrefix(signature, notificationSignaturePrefix) {
+		signature = notificationSignaturePrefix + signature
+	}
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
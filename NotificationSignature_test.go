@@ -0,0 +1,61 @@
+package msgraph
+
+import "testing"
+
+func TestComputeNotificationSignature(t *testing.T) {
+	// test vector from the GitHub webhook documentation, which uses the same signature format
+	got := ComputeNotificationSignature("It's a Secret to Everybody", "Hello, World!")
+	want := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	if got != want {
+		t.Errorf("ComputeNotificationSignature() = %v, want %v", got, want)
+	}
+}
+
+func TestVerifyNotificationSignature(t *testing.T) {
+	body := `{"value":[{"subscriptionId":"1","clientState":"secretClientState","changeType":"updated"}]}`
+	signature := ComputeNotificationSignature("secretClientState", body)
+
+	type args struct {
+		secret    string
+		body      string
+		signature string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "valid signature",
+			args: args{secret: "secretClientState", body: body, signature: signature},
+			want: true,
+		}, {
+			name: "valid signature without prefix",
+			args: args{secret: "secretClientState", body: body, signature: signature[len(notificationSignaturePrefix):]},
+			want: true,
+		}, {
+			name: "tampered body",
+			args: args{secret: "secretClientState", body: body + " ", signature: signature},
+			want: false,
+		}, {
+			name: "wrong secret",
+			args: args{secret: "wrongClientState", body: body, signature: signature},
+			want: false,
+		}, {
+			name: "empty signature",
+			args: args{secret: "secretClientState", body: body, signature: ""},
+			want: false,
+		}, {
+			name: "empty secret",
+			args: args{secret: "", body: body, signature: ComputeNotificationSignature("", body)},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyNotificationSignature(tt.args.secret, tt.args.body, tt.args.signature); got != tt.want {
+				t.Errorf("VerifyNotificationSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
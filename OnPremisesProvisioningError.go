@@ -0,0 +1,81 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ProvisioningErrorCategoryPropertyConflict is the category of an OnPremisesProvisioningError caused by a value that
+// is already used by another object, e.g. a duplicate proxyAddress or userPrincipalName
+const ProvisioningErrorCategoryPropertyConflict = "PropertyConflict"
+
+// provisioningErrorsFilter is the $filter of the users and groups that have an OnPremisesProvisioningError, the
+// lambda operator requires the advanced query capabilities, see ConsistencyLevelEventual
+var provisioningErrorsFilter = fmt.Sprintf("onPremisesProvisioningErrors/any(e:e/category eq '%v')", ProvisioningErrorCategoryPropertyConflict)
+
+// OnPremisesProvisioningError is an error that occurred when a user or group has been synchronized from the
+// on-premises Active Directory by Azure AD Connect
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/onpremisesprovisioningerror
+type OnPremisesProvisioningError struct {
+	Category             string    `json:"category"` // e.g. ProvisioningErrorCategoryPropertyConflict
+	OccurredDateTime     time.Time `json:"occurredDateTime"`
+	PropertyCausingError string    `json:"propertyCausingError"` // e.g. UserPrincipalName or ProxyAddresses
+	Value                string    `json:"value"`                // the value of the property that caused the error
+}
+
+func (e OnPremisesProvisioningError) String() string {
+	return fmt.Sprintf("OnPremisesProvisioningError(Category: \"%v\", OccurredDateTime: \"%v\", PropertyCausingError: \"%v\", Value: \"%v\")",
+		e.Category, e.OccurredDateTime, e.PropertyCausingError, e.Value)
+}
+
+// ProvisioningErrorReport lists the OnPremisesProvisioningErrors of a user or group, see ListObjectsWithProvisioningErrors
+type ProvisioningErrorReport struct {
+	ObjectType  string // "user" or "group"
+	ID          string
+	DisplayName string
+	Errors      []OnPremisesProvisioningError
+}
+
+func (r ProvisioningErrorReport) String() string {
+	return fmt.Sprintf("ProvisioningErrorReport(ObjectType: \"%v\", ID: \"%v\", DisplayName: \"%v\", Errors: \"%v\")",
+		r.ObjectType, r.ID, r.DisplayName, r.Errors)
+}
+
+// ListObjectsWithProvisioningErrors returns the users and groups of the tenant with a PropertyConflict in their
+// synchronization from the on-premises Active Directory, users first.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/aad-advanced-queries
+func (g *GraphClient) ListObjectsWithProvisioningErrors() ([]ProvisioningErrorReport, error) {
+	return g.ListObjectsWithProvisioningErrorsContext(context.Background())
+}
+
+// ListObjectsWithProvisioningErrorsContext is ListObjectsWithProvisioningErrors with a context.
+func (g *GraphClient) ListObjectsWithProvisioningErrorsContext(ctx context.Context) ([]ProvisioningErrorReport, error) {
+	getParams := url.Values{}
+	getParams.Add("$filter", provisioningErrorsFilter)
+	getParams.Add("$count", "true")
+	getParams.Add("$select", "id,displayName,onPremisesProvisioningErrors")
+
+	var reports []ProvisioningErrorReport
+	for _, objectType := range []string{"user", "group"} {
+		var marsh struct {
+			Objects []struct {
+				ID                           string                        `json:"id"`
+				DisplayName                  string                        `json:"displayName"`
+				OnPremisesProvisioningErrors []OnPremisesProvisioningError `json:"onPremisesProvisioningErrors"`
+			} `json:"value"`
+		}
+		err := g.makeGETAPICall(ctx, "/"+objectType+"s", getParams, &marsh, ConsistencyLevelEventual())
+		if err != nil {
+			return reports, fmt.Errorf("cannot list %vs with provisioning errors: %w", objectType, err)
+		}
+		for _, object := range marsh.Objects {
+			reports = append(reports, ProvisioningErrorReport{ObjectType: objectType, ID: object.ID,
+				DisplayName: object.DisplayName, Errors: object.OnPremisesProvisioningErrors})
+		}
+	}
+	return reports, nil
+}
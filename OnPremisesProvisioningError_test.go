@@ -0,0 +1,50 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_ListObjectsWithProvisioningErrors(t *testing.T) {
+	wantFilter := "onPremisesProvisioningErrors/any(e:e/category eq 'PropertyConflict')"
+	wantQuery := "%24count=true&%24filter=onPremisesProvisioningErrors%2Fany%28e%3Ae%2Fcategory+eq+%27PropertyConflict%27%29" +
+		"&%24select=id%2CdisplayName%2ConPremisesProvisioningErrors&%24top=999"
+	var requests []string
+	mux := http.NewServeMux()
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.Header.Get("ConsistencyLevel") != "eventual" {
+			t.Errorf("request %v has ConsistencyLevel %q, want eventual", r.URL, r.Header.Get("ConsistencyLevel"))
+		}
+		if r.URL.Query().Get("$skiptoken") == "" && (r.URL.RawQuery != wantQuery || r.URL.Query().Get("$filter") != wantFilter) {
+			t.Errorf("request query = %v, want %v", r.URL.RawQuery, wantQuery)
+		}
+	}
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r)
+		if r.URL.Query().Get("$skiptoken") == "" {
+			fmt.Fprint(w, `{"@odata.count": 2, "@odata.nextLink": "https://graph.microsoft.com/v1.0/users?$skiptoken=2", "value": [{"id": "u1", "displayName": "Alice",
+				"onPremisesProvisioningErrors": [{"category": "PropertyConflict", "occurredDateTime": "2021-03-04T05:06:07Z", "propertyCausingError": "ProxyAddresses", "value": "smtp:alice@contoso.com"}]}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "u2", "displayName": "Bob", "onPremisesProvisioningErrors": [{"category": "PropertyConflict", "propertyCausingError": "UserPrincipalName"}]}]}`)
+	})
+	mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r)
+		fmt.Fprint(w, `{"value": [{"id": "g1", "displayName": "Sales", "onPremisesProvisioningErrors": [{"category": "PropertyConflict", "propertyCausingError": "Mail"}]}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	reports, err := g.ListObjectsWithProvisioningErrors()
+	if err != nil {
+		t.Fatalf("GraphClient.ListObjectsWithProvisioningErrors() error = %v", err)
+	}
+	if len(reports) != 3 || reports[0].ID != "u1" || reports[1].ID != "u2" || reports[2].ObjectType != "group" || len(requests) != 3 {
+		t.Fatalf("GraphClient.ListObjectsWithProvisioningErrors() = %v after requests %v, want u1, u2 and g1", reports, requests)
+	}
+	if occurred := reports[0].Errors[0].OccurredDateTime; !occurred.Equal(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)) {
+		t.Errorf("OnPremisesProvisioningError.OccurredDateTime = %v, want 2021-03-04T05:06:07Z", occurred)
+	}
+}
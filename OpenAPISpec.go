@@ -0,0 +1,388 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISpecURL is the location of the OpenAPI specification of msgraph, %v is the API version (v1.0 or beta)
+const openAPISpecURL = "https://raw.githubusercontent.com/microsoftgraph/msgraph-metadata/master/openapi/%v/openapi.yaml"
+
+// openAPISpecs caches the downloaded OpenAPI specifications and their parsed form per API version. The mutex only
+// guards the maps, downloads and parsing happen without holding it.
+var openAPISpecs = struct {
+	sync.Mutex
+	yaml      map[string][]byte
+	parsed    map[string]*openAPISpec
+	downloads map[string]*openAPISpecDownload // downloads in progress, waited for by concurrent callers
+}{yaml: map[string][]byte{}, parsed: map[string]*openAPISpec{}, downloads: map[string]*openAPISpecDownload{}}
+
+// openAPISpecDownload is a download of an OpenAPI specification in progress, done is closed once spec or err is set
+type openAPISpecDownload struct {
+	done chan struct{}
+	spec []byte
+	err  error
+}
+
+// FetchOpenAPISpec returns the OpenAPI specification (YAML) of the given msgraph API version, e.g. APIVersion or "beta".
+// The specification is downloaded once and cached in memory for the lifetime of the process, mind that it is large.
+// Concurrent calls for the same version share one download, a failed download is retried by the next call.
+//
+// See https://github.com/microsoftgraph/msgraph-metadata
+func FetchOpenAPISpec(version string) ([]byte, error) {
+	openAPISpecs.Lock()
+	if spec, ok := openAPISpecs.yaml[version]; ok {
+		openAPISpecs.Unlock()
+		return spec, nil
+	}
+	download, inProgress := openAPISpecs.downloads[version]
+	if !inProgress {
+		download = &openAPISpecDownload{done: make(chan struct{})}
+		openAPISpecs.downloads[version] = download
+	}
+	openAPISpecs.Unlock()
+	if inProgress {
+		<-download.done
+		return download.spec, download.err
+	}
+
+	download.spec, download.err = downloadOpenAPISpec(version)
+	openAPISpecs.Lock()
+	delete(openAPISpecs.downloads, version)
+	if download.err == nil {
+		openAPISpecs.yaml[version] = download.spec
+	}
+	openAPISpecs.Unlock()
+	close(download.done)
+	return download.spec, download.err
+}
+
+// downloadOpenAPISpec downloads the OpenAPI specification of the given msgraph API version
+func downloadOpenAPISpec(version string) ([]byte, error) {
+	httpClient := &http.Client{Timeout: time.Minute * 5}
+	resp, err := httpClient.Get(fmt.Sprintf(openAPISpecURL, version))
+	if err != nil {
+		return nil, fmt.Errorf("cannot download OpenAPI specification %v: %w", version, err)
+	}
+	defer resp.Body.Close()
+	spec, err := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot download OpenAPI specification %v, StatusCode is not OK: %v", version, resp.StatusCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read OpenAPI specification %v: %v", version, err)
+	}
+	return spec, nil
+}
+
+// ValidateRequestAgainstSpec returns an error if the request with the given method, path and body does not comply with
+// the OpenAPI specification of msgraph, see FetchOpenAPISpec. The path, e.g. "/users/{id}/sendMail" or
+// "/beta/users/{id}/sendMail", is matched against the path templates of the specification, the query is ignored.
+// The body is json-marshalled and validated against the schema of the operation's requestBody: the types, enums and
+// nullability of the properties are checked and properties that are not specified are rejected, OData annotations
+// like @odata.type are skipped. A nil body is not validated.
+//
+// It is meant for integration test suites, to verify that requests are built the way msgraph specifies them.
+func ValidateRequestAgainstSpec(method, path string, body interface{}) error {
+	version := APIVersion
+	for _, v := range []string{APIVersion, betaAPIVersion} {
+		if strings.HasPrefix(path, "/"+v+"/") {
+			version, path = v, strings.TrimPrefix(path, "/"+v)
+		}
+	}
+	path = strings.SplitN(path, "?", 2)[0]
+
+	spec, err := loadOpenAPISpec(version)
+	if err != nil {
+		return err
+	}
+	var pathFound bool
+	for template, item := range spec.Paths {
+		if !matchPathTemplate(template, path) {
+			continue
+		}
+		pathFound = true
+		if operation := item.operation(method); operation != nil {
+			return spec.validateRequestBody(operation, body)
+		}
+	}
+	if pathFound {
+		return fmt.Errorf("method %v is not specified for path %v in OpenAPI specification %v", method, path, version)
+	}
+	return fmt.Errorf("path %v is not specified in OpenAPI specification %v", path, version)
+}
+
+// loadOpenAPISpec returns the parsed OpenAPI specification of the given msgraph API version, it is parsed once
+func loadOpenAPISpec(version string) (*openAPISpec, error) {
+	openAPISpecs.Lock()
+	spec, ok := openAPISpecs.parsed[version]
+	openAPISpecs.Unlock()
+	if ok {
+		return spec, nil
+	}
+
+	data, err := FetchOpenAPISpec(version)
+	if err != nil {
+		return nil, err
+	}
+	spec = &openAPISpec{}
+	if err := yaml.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("cannot parse OpenAPI specification %v: %v", version, err)
+	}
+	openAPISpecs.Lock()
+	openAPISpecs.parsed[version] = spec
+	openAPISpecs.Unlock()
+	return spec, nil
+}
+
+// openAPISpec is the part of an OpenAPI specification that is needed to validate requests
+type openAPISpec struct {
+	Paths      map[string]openAPIPathItem `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*openAPISchema      `yaml:"schemas"`
+		RequestBodies map[string]*openAPIRequestBody `yaml:"requestBodies"`
+	} `yaml:"components"`
+}
+
+// openAPIPathItem contains the operations of a path template, nil if the method is not specified
+type openAPIPathItem struct {
+	Get    *openAPIOperation `yaml:"get"`
+	Put    *openAPIOperation `yaml:"put"`
+	Post   *openAPIOperation `yaml:"post"`
+	Delete *openAPIOperation `yaml:"delete"`
+	Patch  *openAPIOperation `yaml:"patch"`
+}
+
+// operation returns the operation of the given http method, nil if it is not specified
+func (p openAPIPathItem) operation(method string) *openAPIOperation {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPost:
+		return p.Post
+	case http.MethodDelete:
+		return p.Delete
+	case http.MethodPatch:
+		return p.Patch
+	}
+	return nil
+}
+
+// openAPIOperation is an operation of a path template
+type openAPIOperation struct {
+	RequestBody *openAPIRequestBody `yaml:"requestBody"`
+}
+
+// openAPIRequestBody is the requestBody of an operation or a reference to one of the components
+type openAPIRequestBody struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]struct {
+		Schema *openAPISchema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+// openAPISchema is a schema of the specification, msgraph models inheritance with allOf and nullable references
+// with anyOf
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Nullable   bool                      `yaml:"nullable"`
+	Enum       []interface{}             `yaml:"enum"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Items      *openAPISchema            `yaml:"items"`
+	AllOf      []*openAPISchema          `yaml:"allOf"`
+	AnyOf      []*openAPISchema          `yaml:"anyOf"`
+	OneOf      []*openAPISchema          `yaml:"oneOf"`
+}
+
+// validateRequestBody returns an error if body does not comply with the json schema of the operation's requestBody
+func (s *openAPISpec) validateRequestBody(operation *openAPIOperation, body interface{}) error {
+	if body == nil {
+		return nil
+	}
+	requestBody := operation.RequestBody
+	if requestBody != nil && requestBody.Ref != "" {
+		requestBody = s.Components.RequestBodies[strings.TrimPrefix(requestBody.Ref, "#/components/requestBodies/")]
+	}
+	if requestBody == nil {
+		return fmt.Errorf("the operation has no requestBody")
+	}
+	content, ok := requestBody.Content["application/json"]
+	if !ok || content.Schema == nil {
+		return nil // e.g. application/octet-stream of file uploads
+	}
+
+	marshalled, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("cannot json-marshal body: %v", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(marshalled, &value); err != nil {
+		return fmt.Errorf("cannot json-unmarshal body: %v", err)
+	}
+	return s.validateValue(content.Schema, value, "body")
+}
+
+// validateValue returns an error if the json-unmarshalled value does not comply with the schema, name is the path of
+// the value in the body, used in the error
+func (s *openAPISpec) validateValue(schema *openAPISchema, value interface{}, name string) error {
+	schema, err := s.resolveSchema(schema)
+	if err != nil || schema == nil {
+		return err
+	}
+	if alternatives := append(append([]*openAPISchema{}, schema.AnyOf...), schema.OneOf...); len(alternatives) > 0 {
+		var firstErr error
+		for _, alternative := range alternatives {
+			err := s.validateValue(alternative, value, name)
+			if err == nil {
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return fmt.Errorf("%v must not be null", name)
+	}
+
+	if _, isObject := value.(map[string]interface{}); !isObject {
+		for _, base := range schema.AllOf {
+			if err := s.validateValue(base, value, name); err != nil {
+				return err
+			}
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if schema.Type != "" && schema.Type != "object" {
+			return fmt.Errorf("%v is an object, want %v", name, schema.Type)
+		}
+		properties, err := s.schemaProperties(schema)
+		if err != nil || len(properties) == 0 { // an object without properties is free-form
+			return err
+		}
+		for key, property := range v {
+			if strings.Contains(key, "@") { // OData annotation, e.g. @odata.type or members@odata.bind
+				continue
+			}
+			propertySchema, ok := properties[key]
+			if !ok {
+				return fmt.Errorf("%v.%v is not specified", name, key)
+			}
+			if err := s.validateValue(propertySchema, property, name+"."+key); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if schema.Type != "" && schema.Type != "array" {
+			return fmt.Errorf("%v is an array, want %v", name, schema.Type)
+		}
+		for i, item := range v {
+			if err := s.validateValue(schema.Items, item, fmt.Sprintf("%v[%v]", name, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		if schema.Type != "" && schema.Type != "string" {
+			return fmt.Errorf("%v is a string, want %v", name, schema.Type)
+		}
+		if len(schema.Enum) > 0 && !containsEnumValue(schema.Enum, v) {
+			return fmt.Errorf("%v is %q, want one of %v", name, v, schema.Enum)
+		}
+	case bool:
+		if schema.Type != "" && schema.Type != "boolean" {
+			return fmt.Errorf("%v is a boolean, want %v", name, schema.Type)
+		}
+	case float64:
+		if schema.Type == "integer" && v != math.Trunc(v) {
+			return fmt.Errorf("%v is %v, want an integer", name, v)
+		}
+		if schema.Type != "" && schema.Type != "number" && schema.Type != "integer" {
+			return fmt.Errorf("%v is a number, want %v", name, schema.Type)
+		}
+	}
+	return nil
+}
+
+// resolveSchema follows the $ref of the schema to the schemas of the components
+func (s *openAPISpec) resolveSchema(schema *openAPISchema) (*openAPISchema, error) {
+	for schema != nil && schema.Ref != "" {
+		ref := schema.Ref
+		schema = s.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+		if schema == nil {
+			return nil, fmt.Errorf("cannot resolve schema %v", ref)
+		}
+	}
+	return schema, nil
+}
+
+// schemaProperties returns the properties of the schema including the ones inherited via allOf
+func (s *openAPISpec) schemaProperties(schema *openAPISchema) (map[string]*openAPISchema, error) {
+	properties := map[string]*openAPISchema{}
+	for key, property := range schema.Properties {
+		properties[key] = property
+	}
+	for _, base := range schema.AllOf {
+		base, err := s.resolveSchema(base)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil {
+			continue
+		}
+		inherited, err := s.schemaProperties(base)
+		if err != nil {
+			return nil, err
+		}
+		for key, property := range inherited {
+			properties[key] = property
+		}
+	}
+	return properties, nil
+}
+
+// containsEnumValue returns true if value is one of the values of the enum
+func containsEnumValue(enum []interface{}, value string) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathTemplate returns true if the path matches the path template of the OpenAPI specification. A segment in
+// curly brackets, e.g. {user-id}, matches any segment and actions may be given without their "microsoft.graph."
+// namespace, e.g. /users/{user-id}/microsoft.graph.sendMail matches /users/alice@contoso.com/sendMail.
+func matchPathTemplate(template, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range templateSegments {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+		case strings.EqualFold(segment, pathSegments[i]):
+		case strings.EqualFold(strings.TrimPrefix(segment, "microsoft.graph."), pathSegments[i]):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testOpenAPISpec is an excerpt of the OpenAPI specification of msgraph v1.0
+const testOpenAPISpec = `openapi: 3.0.4
+info:
+  title: OData Service for namespace microsoft.graph
+  version: v1.0
+paths:
+  /users:
+    description: Provides operations to manage the collection of user entities.
+    get:
+      tags:
+        - users.user
+      summary: List users
+      operationId: users.user.ListUser
+    post:
+      tags:
+        - users.user
+      operationId: users.user.CreateUser
+      requestBody:
+        description: New entity
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/microsoft.graph.user'
+        required: true
+  '/users/{user-id}':
+    get:
+      operationId: users.user.GetUser
+    patch:
+      operationId: users.user.UpdateUser
+      requestBody:
+        $ref: '#/components/requestBodies/userUpdate'
+    parameters:
+      - name: user-id
+        in: path
+  '/users/{user-id}/microsoft.graph.sendMail':
+    post:
+      operationId: users.user.sendMail
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                message:
+                  $ref: '#/components/schemas/microsoft.graph.message'
+                saveToSentItems:
+                  type: boolean
+                  default: false
+                  nullable: true
+        required: true
+components:
+  schemas:
+    microsoft.graph.entity:
+      title: entity
+      type: object
+      properties:
+        id:
+          type: string
+    microsoft.graph.user:
+      allOf:
+        - $ref: '#/components/schemas/microsoft.graph.entity'
+        - title: user
+          type: object
+          properties:
+            accountEnabled:
+              type: boolean
+              nullable: true
+            ageGroup:
+              type: string
+              nullable: true
+            businessPhones:
+              type: array
+              items:
+                type: string
+            displayName:
+              type: string
+              nullable: true
+    microsoft.graph.message:
+      allOf:
+        - $ref: '#/components/schemas/microsoft.graph.entity'
+        - title: message
+          type: object
+          properties:
+            subject:
+              type: string
+              nullable: true
+            importance:
+              anyOf:
+                - $ref: '#/components/schemas/microsoft.graph.importance'
+                - type: object
+                  nullable: true
+            toRecipients:
+              type: array
+              items:
+                $ref: '#/components/schemas/microsoft.graph.recipient'
+    microsoft.graph.recipient:
+      title: recipient
+      type: object
+      properties:
+        emailAddress:
+          anyOf:
+            - $ref: '#/components/schemas/microsoft.graph.emailAddress'
+            - type: object
+              nullable: true
+    microsoft.graph.emailAddress:
+      title: emailAddress
+      type: object
+      properties:
+        address:
+          type: string
+          nullable: true
+        name:
+          type: string
+          nullable: true
+    microsoft.graph.importance:
+      title: importance
+      enum:
+        - low
+        - normal
+        - high
+      type: string
+  requestBodies:
+    userUpdate:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/microsoft.graph.user'
+`
+
+func TestValidateRequestAgainstSpec(t *testing.T) {
+	var downloads int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/microsoftgraph/msgraph-metadata/master/openapi/v1.0/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		fmt.Fprint(w, testOpenAPISpec)
+	})
+	newTestGraphClient(t, mux)
+	t.Cleanup(func() {
+		openAPISpecs.Lock()
+		delete(openAPISpecs.yaml, APIVersion)
+		delete(openAPISpecs.parsed, APIVersion)
+		openAPISpecs.Unlock()
+	})
+
+	mail := map[string]interface{}{
+		"message": map[string]interface{}{"subject": "Hello", "importance": "high", "@odata.type": "#microsoft.graph.message",
+			"toRecipients": []interface{}{map[string]interface{}{"emailAddress": map[string]interface{}{"address": "bob@contoso.com"}}}},
+		"saveToSentItems": false,
+	}
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    interface{}
+		wantErr bool
+	}{
+		{name: "list users", method: http.MethodGet, path: "/users?$top=999", wantErr: false},
+		{name: "create user", method: http.MethodPost, path: "/users", body: map[string]interface{}{"id": "u1", "displayName": "Alice", "businessPhones": []string{"+43 1"}}, wantErr: false},
+		{name: "update user by requestBodies reference", method: http.MethodPatch, path: "/v1.0/users/alice@contoso.com", body: map[string]interface{}{"displayName": "Alice", "ageGroup": nil}, wantErr: false},
+		{name: "send mail action", method: http.MethodPost, path: "/users/alice@contoso.com/sendMail", body: mail, wantErr: false},
+		{name: "unspecified method", method: http.MethodDelete, path: "/users", wantErr: true},
+		{name: "unspecified path", method: http.MethodGet, path: "/users/alice@contoso.com/sendMails", wantErr: true},
+		{name: "body is no object", method: http.MethodPost, path: "/users", body: []string{"alice"}, wantErr: true},
+		{name: "unspecified property", method: http.MethodPatch, path: "/users/alice@contoso.com", body: map[string]interface{}{"displayNme": "Alice"}, wantErr: true},
+		{name: "wrong type", method: http.MethodPatch, path: "/users/alice@contoso.com", body: map[string]interface{}{"accountEnabled": "yes"}, wantErr: true},
+		{name: "wrong item type", method: http.MethodPost, path: "/users", body: map[string]interface{}{"businessPhones": []int{1}}, wantErr: true},
+		{name: "not nullable", method: http.MethodPost, path: "/users", body: map[string]interface{}{"id": nil}, wantErr: true},
+		{name: "unknown enum value", method: http.MethodPost, path: "/users/alice@contoso.com/sendMail", body: map[string]interface{}{"message": map[string]interface{}{"importance": "urgent"}}, wantErr: true},
+		{name: "nested wrong type", method: http.MethodPost, path: "/users/alice@contoso.com/sendMail", body: map[string]interface{}{"message": map[string]interface{}{"toRecipients": []interface{}{map[string]interface{}{"emailAddress": "bob@contoso.com"}}}}, wantErr: true},
+		{name: "body without requestBody", method: http.MethodGet, path: "/users", body: map[string]interface{}{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRequestAgainstSpec(tt.method, tt.path, tt.body); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRequestAgainstSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if downloads != 1 {
+		t.Errorf("OpenAPI specification has been downloaded %v times, want 1", downloads)
+	}
+}
+
+func TestFetchOpenAPISpec_concurrent(t *testing.T) {
+	release := make(chan struct{})
+	var downloads int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/microsoftgraph/msgraph-metadata/master/openapi/v1.0/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		<-release
+		fmt.Fprint(w, testOpenAPISpec)
+	})
+	newTestGraphClient(t, mux)
+	openAPISpecs.Lock()
+	openAPISpecs.yaml[betaAPIVersion] = []byte(testOpenAPISpec)
+	openAPISpecs.Unlock()
+	t.Cleanup(func() {
+		openAPISpecs.Lock()
+		delete(openAPISpecs.yaml, APIVersion)
+		delete(openAPISpecs.yaml, betaAPIVersion)
+		openAPISpecs.Unlock()
+	})
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := FetchOpenAPISpec(APIVersion)
+			results <- err
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		FetchOpenAPISpec(betaAPIVersion)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("FetchOpenAPISpec() of a cached version is blocked by a download")
+	}
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("FetchOpenAPISpec() error = %v", err)
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("OpenAPI specification has been downloaded %v times, want 1", downloads)
+	}
+}
@@ -0,0 +1,81 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+)
+
+// Organization represents the Azure Active Directory tenant
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/organization
+type Organization struct {
+	ID                string           `json:"id"`
+	DisplayName       string           `json:"displayName"`
+	CountryLetterCode string           `json:"countryLetterCode"` // Country/region abbreviation for the organization in ISO 3166-2 format.
+	PreferredLanguage string           `json:"preferredLanguage"`
+	City              string           `json:"city"`
+	Country           string           `json:"country"`
+	VerifiedDomains   []VerifiedDomain `json:"verifiedDomains"`
+}
+
+func (o Organization) String() string {
+	return fmt.Sprintf("Organization(ID: \"%v\", DisplayName: \"%v\", CountryLetterCode: \"%v\", PreferredLanguage: \"%v\", City: \"%v\", Country: \"%v\", VerifiedDomains: \"%v\")",
+		o.ID, o.DisplayName, o.CountryLetterCode, o.PreferredLanguage, o.City, o.Country, o.VerifiedDomains)
+}
+
+// VerifiedDomain represents a domain of the tenant that has been verified
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/verifieddomain
+type VerifiedDomain struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"` // e.g. Managed or Federated
+	IsDefault    bool   `json:"isDefault"`
+	IsInitial    bool   `json:"isInitial"` // true for the initial <tenant>.onmicrosoft.com domain
+	Capabilities string `json:"capabilities"`
+}
+
+// GetOrganization returns the organization of the tenant the GraphClient is authenticated for
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/organization-get
+func (g *GraphClient) GetOrganization() (Organization, error) {
+	return g.GetOrganizationContext(context.Background())
+}
+
+// GetOrganizationContext is GetOrganization with a context.
+func (g *GraphClient) GetOrganizationContext(ctx context.Context) (Organization, error) {
+	var marsh struct {
+		Organizations []Organization `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, "/organization", nil, &marsh)
+	if err != nil {
+		return Organization{}, err
+	}
+	if len(marsh.Organizations) == 0 {
+		return Organization{}, fmt.Errorf("no organization returned by msgraph")
+	}
+	return marsh.Organizations[0], nil
+}
+
+// GetDefaultUsageLocation returns the usageLocation (ISO 3166-1 alpha-2, e.g. "AT") to be used for new users.
+//
+// msgraph does not expose a tenant-wide default usage location, hence GraphClient.DefaultUsageLocation is
+// returned if it is set. Otherwise the countryLetterCode of the organization is returned, which is the
+// country the tenant has been created for.
+func (g *GraphClient) GetDefaultUsageLocation() (string, error) {
+	return g.GetDefaultUsageLocationContext(context.Background())
+}
+
+// GetDefaultUsageLocationContext is GetDefaultUsageLocation with a context.
+func (g *GraphClient) GetDefaultUsageLocationContext(ctx context.Context) (string, error) {
+	if g.DefaultUsageLocation != "" {
+		return g.DefaultUsageLocation, nil
+	}
+	organization, err := g.GetOrganizationContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("cannot get organization for the countryLetterCode: %w", err)
+	}
+	if organization.CountryLetterCode == "" {
+		return "", fmt.Errorf("organization %v has no countryLetterCode, set GraphClient.DefaultUsageLocation", organization.DisplayName)
+	}
+	return organization.CountryLetterCode, nil
+}
@@ -0,0 +1,54 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_GetDefaultUsageLocation(t *testing.T) {
+	tests := []struct {
+		name                 string
+		defaultUsageLocation string
+		organizations        string
+		want                 string
+		wantErr              bool
+	}{
+		{
+			name:          "countryLetterCode of the organization",
+			organizations: `{"value": [{"id": "1", "displayName": "Contoso", "countryLetterCode": "AT"}]}`,
+			want:          "AT",
+			wantErr:       false,
+		}, {
+			name:                 "configured DefaultUsageLocation",
+			defaultUsageLocation: "DE",
+			organizations:        `{"value": [{"id": "1", "displayName": "Contoso", "countryLetterCode": "AT"}]}`,
+			want:                 "DE",
+			wantErr:              false,
+		}, {
+			name:          "no countryLetterCode",
+			organizations: `{"value": [{"id": "1", "displayName": "Contoso"}]}`,
+			want:          "",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGraphClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1.0/organization" {
+					t.Errorf("unexpected request %v", r.URL.Path)
+				}
+				fmt.Fprint(w, tt.organizations)
+			}))
+			g.DefaultUsageLocation = tt.defaultUsageLocation
+			got, err := g.GetDefaultUsageLocation()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GraphClient.GetDefaultUsageLocation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GraphClient.GetDefaultUsageLocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
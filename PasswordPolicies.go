@@ -0,0 +1,91 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PasswordPolicy is a single password policy of a user, see PasswordPolicies
+type PasswordPolicy string
+
+// Password policies as accepted by msgraph in passwordPolicies
+const (
+	PasswordPolicyNone                      PasswordPolicy = "None"
+	PasswordPolicyDisableStrongPassword     PasswordPolicy = "DisableStrongPassword"
+	PasswordPolicyDisablePasswordExpiration PasswordPolicy = "DisablePasswordExpiration"
+)
+
+// IsValid returns true if p is one of the PasswordPolicy constants
+func (p PasswordPolicy) IsValid() bool {
+	return p == PasswordPolicyNone || p == PasswordPolicyDisableStrongPassword || p == PasswordPolicyDisablePasswordExpiration
+}
+
+// PasswordPolicies are the password policies of a user, e.g. PasswordPolicyDisablePasswordExpiration for a service
+// account whose password must never expire. msgraph represents them as a single comma separated string,
+// e.g. "DisablePasswordExpiration, DisableStrongPassword", which is what PasswordPolicies json-marshals to.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/resources/user
+type PasswordPolicies []PasswordPolicy
+
+func (p PasswordPolicies) String() string {
+	policies := make([]string, len(p))
+	for i, policy := range p {
+		policies[i] = string(policy)
+	}
+	return strings.Join(policies, ", ")
+}
+
+// Contains returns true if policy is one of the password policies
+func (p PasswordPolicies) Contains(policy PasswordPolicy) bool {
+	for _, q := range p {
+		if q == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if one of the password policies is unknown to msgraph, is given twice or if
+// PasswordPolicyNone is combined with another policy.
+func (p PasswordPolicies) Validate() error {
+	seen := make(map[PasswordPolicy]bool, len(p))
+	for _, policy := range p {
+		if !policy.IsValid() {
+			return fmt.Errorf("invalid PasswordPolicy %q", policy)
+		}
+		if seen[policy] {
+			return fmt.Errorf("duplicate PasswordPolicy %q", policy)
+		}
+		seen[policy] = true
+	}
+	if seen[PasswordPolicyNone] && len(p) > 1 {
+		return fmt.Errorf("PasswordPolicy %q cannot be combined with other policies: %v", PasswordPolicyNone, p)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library, the comma separated string
+// of msgraph is split into the single policies
+func (p *PasswordPolicies) UnmarshalJSON(data []byte) error {
+	var policies *string
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return fmt.Errorf("cannot UnmarshalJSON passwordPolicies: %v | Data: %v", err, string(data))
+	}
+	*p = nil
+	if policies == nil {
+		return nil
+	}
+	for _, policy := range strings.Split(*policies, ",") {
+		if policy = strings.TrimSpace(policy); policy != "" {
+			*p = append(*p, PasswordPolicy(policy))
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the policies are joined into the comma
+// separated string of msgraph
+func (p PasswordPolicies) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
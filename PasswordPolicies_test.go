@@ -0,0 +1,65 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPasswordPolicies_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want PasswordPolicies
+	}{
+		{name: "null", data: `null`, want: nil},
+		{name: "none", data: `"None"`, want: PasswordPolicies{PasswordPolicyNone}},
+		{name: "combined", data: `"DisablePasswordExpiration, DisableStrongPassword"`,
+			want: PasswordPolicies{PasswordPolicyDisablePasswordExpiration, PasswordPolicyDisableStrongPassword}},
+		{name: "without space", data: `"DisableStrongPassword,DisablePasswordExpiration"`,
+			want: PasswordPolicies{PasswordPolicyDisableStrongPassword, PasswordPolicyDisablePasswordExpiration}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got PasswordPolicies
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("PasswordPolicies.UnmarshalJSON() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PasswordPolicies.UnmarshalJSON() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicies_MarshalJSON(t *testing.T) {
+	got, err := json.Marshal(UserUpdate{PasswordPolicies: PasswordPolicies{PasswordPolicyDisablePasswordExpiration, PasswordPolicyDisableStrongPassword}})
+	if err != nil {
+		t.Fatalf("PasswordPolicies.MarshalJSON() error = %v", err)
+	}
+	if want := `{"passwordPolicies":"DisablePasswordExpiration, DisableStrongPassword"}`; string(got) != want {
+		t.Errorf("PasswordPolicies.MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestPasswordPolicies_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies PasswordPolicies
+		wantErr  bool
+	}{
+		{name: "empty", policies: nil},
+		{name: "none", policies: PasswordPolicies{PasswordPolicyNone}},
+		{name: "combined", policies: PasswordPolicies{PasswordPolicyDisablePasswordExpiration, PasswordPolicyDisableStrongPassword}},
+		{name: "unknown", policies: PasswordPolicies{"NeverExpire"}, wantErr: true},
+		{name: "duplicate", policies: PasswordPolicies{PasswordPolicyDisableStrongPassword, PasswordPolicyDisableStrongPassword}, wantErr: true},
+		{name: "none combined", policies: PasswordPolicies{PasswordPolicyNone, PasswordPolicyDisableStrongPassword}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policies.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("PasswordPolicies.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
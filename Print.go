@@ -0,0 +1,329 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// States of a PrintJobStatus
+const (
+	PrintJobStateUnknown    = "unknown"
+	PrintJobStatePending    = "pending"
+	PrintJobStateProcessing = "processing"
+	PrintJobStatePaused     = "paused"
+	PrintJobStateStopped    = "stopped"
+	PrintJobStateCompleted  = "completed"
+	PrintJobStateCanceled   = "canceled"
+	PrintJobStateAborted    = "aborted"
+)
+
+// Printer represents a printer registered in Universal Print
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printer
+type Printer struct {
+	ID              string        `json:"id"`
+	DisplayName     string        `json:"displayName"`
+	Manufacturer    string        `json:"manufacturer"`
+	Model           string        `json:"model"`
+	IsShared        bool          `json:"isShared"`
+	IsAcceptingJobs bool          `json:"isAcceptingJobs"`
+	Status          PrinterStatus `json:"status"`
+}
+
+func (p Printer) String() string {
+	return fmt.Sprintf("Printer(ID: \"%v\", DisplayName: \"%v\", Manufacturer: \"%v\", Model: \"%v\", IsShared: \"%v\", IsAcceptingJobs: \"%v\", Status: \"%v\")",
+		p.ID, p.DisplayName, p.Manufacturer, p.Model, p.IsShared, p.IsAcceptingJobs, p.Status)
+}
+
+// PrinterStatus is the processing state of a Printer
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printerstatus
+type PrinterStatus struct {
+	State       string   `json:"state"` // e.g. idle, processing or stopped
+	Description string   `json:"description"`
+	Details     []string `json:"details"` // e.g. paused or mediaEmpty
+}
+
+func (s PrinterStatus) String() string {
+	return fmt.Sprintf("PrinterStatus(State: \"%v\", Description: \"%v\", Details: \"%v\")", s.State, s.Description, s.Details)
+}
+
+// PrinterShare represents a Printer that has been shared with users, print jobs of users are created on the share
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printershare
+type PrinterShare struct {
+	ID              string    `json:"id"`
+	DisplayName     string    `json:"displayName"`
+	Manufacturer    string    `json:"manufacturer"`
+	Model           string    `json:"model"`
+	IsAcceptingJobs bool      `json:"isAcceptingJobs"`
+	CreatedDateTime time.Time `json:"createdDateTime"`
+}
+
+func (s PrinterShare) String() string {
+	return fmt.Sprintf("PrinterShare(ID: \"%v\", DisplayName: \"%v\", Manufacturer: \"%v\", Model: \"%v\", IsAcceptingJobs: \"%v\", CreatedDateTime: \"%v\")",
+		s.ID, s.DisplayName, s.Manufacturer, s.Model, s.IsAcceptingJobs, s.CreatedDateTime)
+}
+
+// PrintJobConfiguration configures how the document of a PrintJob is printed, properties that are not set use the
+// defaults of the printer
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printjobconfiguration
+type PrintJobConfiguration struct {
+	Copies       int              `json:"copies,omitempty"`
+	ColorMode    string           `json:"colorMode,omitempty"`   // e.g. blackAndWhite or color
+	Duplex       string           `json:"duplexMode,omitempty"`  // e.g. oneSided or flipOnLongEdge
+	Orientation  string           `json:"orientation,omitempty"` // e.g. portrait or landscape
+	MediaSize    string           `json:"mediaSize,omitempty"`   // e.g. A4 or "4x6"
+	Quality      string           `json:"quality,omitempty"`     // e.g. medium or high
+	DPI          int              `json:"dpi,omitempty"`
+	FitPDFToPage *bool            `json:"fitPdfToPage,omitempty"`
+	PageRanges   []PrintPageRange `json:"pageRanges,omitempty"`
+}
+
+// PrintPageRange is a range of pages to print, Start and End are inclusive and 1-based
+type PrintPageRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// PrintJob represents a print job of a Printer
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printjob
+type PrintJob struct {
+	ID              string                `json:"id"`
+	CreatedDateTime time.Time             `json:"createdDateTime"`
+	Status          PrintJobStatus        `json:"status"`
+	Configuration   PrintJobConfiguration `json:"configuration"`
+	Documents       []PrintDocument       `json:"documents"`
+}
+
+func (j PrintJob) String() string {
+	return fmt.Sprintf("PrintJob(ID: \"%v\", CreatedDateTime: \"%v\", Status: \"%v\", Documents: \"%v\")",
+		j.ID, j.CreatedDateTime, j.Status, len(j.Documents))
+}
+
+// PrintJobStatus is the processing state of a PrintJob
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printjobstatus
+type PrintJobStatus struct {
+	State               string   `json:"state"` // one of the PrintJobState constants
+	Description         string   `json:"description"`
+	Details             []string `json:"details"` // e.g. interpreting or completedSuccessfully
+	IsAcquiredByPrinter bool     `json:"isAcquiredByPrinter"`
+}
+
+func (s PrintJobStatus) String() string {
+	return fmt.Sprintf("PrintJobStatus(State: \"%v\", Description: \"%v\", Details: \"%v\", IsAcquiredByPrinter: \"%v\")",
+		s.State, s.Description, s.Details, s.IsAcquiredByPrinter)
+}
+
+// IsFinished returns true if the job has completed, has been canceled or aborted
+func (s PrintJobStatus) IsFinished() bool {
+	return s.State == PrintJobStateCompleted || s.State == PrintJobStateCanceled || s.State == PrintJobStateAborted
+}
+
+// PrintDocument is the document of a PrintJob
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/printdocument
+type PrintDocument struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// PrintError is returned by the Universal Print funcs if msgraph denied the API-call. It matches its Kind, i.e.
+// ErrPrintNotLicensed or ErrPrintPermission, with errors.Is and unwraps to the GraphError of msgraph, hence e.g.
+// errors.Is(err, ErrForbidden) works too.
+type PrintError struct {
+	Kind error // ErrPrintNotLicensed or ErrPrintPermission
+	Err  error // the GraphError returned by msgraph
+}
+
+func (e *PrintError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+// Is returns true if target is the Kind of e, it is used by errors.Is
+func (e *PrintError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the GraphError of e
+func (e *PrintError) Unwrap() error {
+	return e.Err
+}
+
+// printError returns err as PrintError if msgraph denied the Universal Print API-call
+func printError(err error) error {
+	if !hasStatusCode(err, http.StatusForbidden) && !hasStatusCode(err, http.StatusUnauthorized) {
+		return err
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "licens") {
+		return &PrintError{Kind: ErrPrintNotLicensed, Err: err}
+	}
+	return &PrintError{Kind: ErrPrintPermission, Err: err}
+}
+
+// ListPrinters returns all printers registered in Universal Print
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/print-list-printers
+func (g *GraphClient) ListPrinters() ([]Printer, error) {
+	return g.ListPrintersContext(context.Background())
+}
+
+// ListPrintersContext is ListPrinters with a context.
+func (g *GraphClient) ListPrintersContext(ctx context.Context) ([]Printer, error) {
+	var marsh struct {
+		Printers []Printer `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, "/print/printers", nil, &marsh)
+	return marsh.Printers, printError(err)
+}
+
+// GetPrinter returns the printer identified by the given id
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/printer-get
+func (g *GraphClient) GetPrinter(id string) (Printer, error) {
+	return g.GetPrinterContext(context.Background(), id)
+}
+
+// GetPrinterContext is GetPrinter with a context.
+func (g *GraphClient) GetPrinterContext(ctx context.Context, id string) (Printer, error) {
+	var printer Printer
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/print/printers/%v", id), nil, &printer)
+	return printer, printError(err)
+}
+
+// ListPrinterShares returns all printer shares of Universal Print
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/print-list-shares
+func (g *GraphClient) ListPrinterShares() ([]PrinterShare, error) {
+	return g.ListPrinterSharesContext(context.Background())
+}
+
+// ListPrinterSharesContext is ListPrinterShares with a context.
+func (g *GraphClient) ListPrinterSharesContext(ctx context.Context) ([]PrinterShare, error) {
+	var marsh struct {
+		Shares []PrinterShare `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, "/print/shares", nil, &marsh)
+	return marsh.Shares, printError(err)
+}
+
+// CreatePrintJob creates a print job with the given configuration on the printer. Upload its document with
+// UploadPrintDocument and start it with StartPrintJob afterwards.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/printer-post-jobs
+func (g *GraphClient) CreatePrintJob(printerID string, config PrintJobConfiguration) (PrintJob, error) {
+	return g.CreatePrintJobContext(context.Background(), printerID, config)
+}
+
+// CreatePrintJobContext is CreatePrintJob with a context.
+func (g *GraphClient) CreatePrintJobContext(ctx context.Context, printerID string, config PrintJobConfiguration) (PrintJob, error) {
+	body := map[string]PrintJobConfiguration{"configuration": config}
+	var job PrintJob
+	err := g.makePOSTAPICall(ctx, fmt.Sprintf("/print/printers/%v/jobs", printerID), body, &job)
+	return job, printError(err)
+}
+
+// UploadPrintDocument uploads the content of r as the document of the print job, e.g. with contentType
+// "application/pdf". The content is read into memory as its size has to be known for the upload session, it is
+// uploaded in chunks.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/printdocument-createuploadsession
+func (g *GraphClient) UploadPrintDocument(printerID, jobID, filename, contentType string, r io.Reader) error {
+	return g.UploadPrintDocumentContext(context.Background(), printerID, jobID, filename, contentType, r)
+}
+
+// UploadPrintDocumentContext is UploadPrintDocument with a context.
+func (g *GraphClient) UploadPrintDocumentContext(ctx context.Context, printerID, jobID, filename, contentType string, r io.Reader) error {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("cannot read print document %v: %v", filename, err)
+	}
+	if len(content) == 0 {
+		return fmt.Errorf("print document %v is empty", filename)
+	}
+	resource := fmt.Sprintf("/print/printers/%v/jobs/%v/documents", printerID, jobID)
+	var documents struct {
+		Documents []PrintDocument `json:"value"`
+	}
+	if err := g.makeGETAPICall(ctx, resource, nil, &documents); err != nil {
+		return printError(err)
+	}
+	if len(documents.Documents) == 0 {
+		return fmt.Errorf("print job %v has no document", jobID)
+	}
+
+	body := map[string]interface{}{"properties": map[string]interface{}{
+		"documentName": filename, "contentType": contentType, "size": len(content),
+	}}
+	var session uploadSession
+	err = g.makePOSTAPICall(ctx, fmt.Sprintf("%v/%v/createUploadSession", resource, documents.Documents[0].ID), body, &session)
+	if err != nil {
+		return printError(err)
+	}
+	return g.uploadToSession(ctx, session.UploadURL, content, nil)
+}
+
+// StartPrintJob submits the print job to the printer after its document has been uploaded
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/printjob-start
+func (g *GraphClient) StartPrintJob(printerID, jobID string) error {
+	return g.StartPrintJobContext(context.Background(), printerID, jobID)
+}
+
+// StartPrintJobContext is StartPrintJob with a context.
+func (g *GraphClient) StartPrintJobContext(ctx context.Context, printerID, jobID string) error {
+	err := g.makePOSTAPICall(ctx, fmt.Sprintf("/print/printers/%v/jobs/%v/start", printerID, jobID), nil, nil)
+	return printError(err)
+}
+
+// GetPrintJob returns the print job, e.g. to check its Status
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/printjob-get
+func (g *GraphClient) GetPrintJob(printerID, jobID string) (PrintJob, error) {
+	return g.GetPrintJobContext(context.Background(), printerID, jobID)
+}
+
+// GetPrintJobContext is GetPrintJob with a context.
+func (g *GraphClient) GetPrintJobContext(ctx context.Context, printerID, jobID string) (PrintJob, error) {
+	var job PrintJob
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/print/printers/%v/jobs/%v", printerID, jobID), nil, &job)
+	return job, printError(err)
+}
+
+// WaitForPrintJob polls the print job every pollInterval until it is finished, see PrintJobStatus.IsFinished, and
+// returns it. An error is returned if the job is not finished after timeout.
+func (g *GraphClient) WaitForPrintJob(printerID, jobID string, pollInterval, timeout time.Duration) (PrintJob, error) {
+	return g.WaitForPrintJobContext(context.Background(), printerID, jobID, pollInterval, timeout)
+}
+
+// WaitForPrintJobContext is WaitForPrintJob with a context, polling stops as soon as ctx is done.
+func (g *GraphClient) WaitForPrintJobContext(ctx context.Context, printerID, jobID string, pollInterval, timeout time.Duration) (PrintJob, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := g.GetPrintJobContext(ctx, printerID, jobID)
+		if err != nil {
+			return job, err
+		}
+		if job.Status.IsFinished() {
+			return job, nil
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			return job, fmt.Errorf("print job %v is still %v after %v", jobID, job.Status.State, timeout)
+		}
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return job, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package msgraph
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_PrintJob(t *testing.T) {
+	origChunkSize := uploadChunkSize
+	uploadChunkSize = 4
+	defer func() { uploadChunkSize = origChunkSize }()
+
+	var uploaded, contentRanges []string
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/print/printers/p1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"configuration":{"copies":2}`) {
+			t.Errorf("CreatePrintJob() body = %s, want the configuration", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "j1", "status": {"state": "paused"}, "documents": [{"id": "d1"}]}`)
+	})
+	mux.HandleFunc("/v1.0/print/printers/p1/jobs/j1/documents", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "d1"}]}`)
+	})
+	mux.HandleFunc("/v1.0/print/printers/p1/jobs/j1/documents/d1/createUploadSession", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"size":10`) {
+			t.Errorf("createUploadSession body = %s, want the size of the document", body)
+		}
+		fmt.Fprint(w, `{"uploadUrl": "https://print.print.microsoft.com/uploadSessions/s1?tempauthtoken=x"}`)
+	})
+	mux.HandleFunc("/uploadSessions/s1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("upload chunk has an Authorization header, want none as the uploadUrl is pre-authenticated")
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		uploaded = append(uploaded, string(body))
+		contentRanges = append(contentRanges, r.Header.Get("Content-Range"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v1.0/print/printers/p1/jobs/j1/start", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"state": "processing"}`)
+	})
+	mux.HandleFunc("/v1.0/print/printers/p1/jobs/j1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		state := PrintJobStateProcessing
+		if polls == 2 {
+			state = PrintJobStateCompleted
+		}
+		fmt.Fprintf(w, `{"id": "j1", "status": {"state": "%v", "details": ["completedSuccessfully"]}}`, state)
+	})
+	mux.HandleFunc("/v1.0/print/printers/unlicensed", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": "Forbidden", "message": "The tenant does not have a valid Universal Print license."}}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	job, err := g.CreatePrintJob("p1", PrintJobConfiguration{Copies: 2})
+	if err != nil || job.ID != "j1" {
+		t.Fatalf("GraphClient.CreatePrintJob() = %v, %v, want job j1", job, err)
+	}
+	if err := g.UploadPrintDocument("p1", "j1", "label.pdf", "application/pdf", strings.NewReader("0123456789")); err != nil {
+		t.Fatalf("GraphClient.UploadPrintDocument() error = %v", err)
+	}
+	if fmt.Sprint(uploaded) != "[0123 4567 89]" || fmt.Sprint(contentRanges) != "[bytes 0-3/10 bytes 4-7/10 bytes 8-9/10]" {
+		t.Errorf("uploaded chunks = %v with Content-Ranges %v, want 3 chunks", uploaded, contentRanges)
+	}
+	if err := g.StartPrintJob("p1", "j1"); err != nil {
+		t.Fatalf("GraphClient.StartPrintJob() error = %v", err)
+	}
+	job, err = g.WaitForPrintJob("p1", "j1", time.Millisecond, time.Second)
+	if err != nil || job.Status.State != PrintJobStateCompleted || polls != 2 {
+		t.Errorf("GraphClient.WaitForPrintJob() = %v, %v after %v polls, want the completed job after 2", job, err, polls)
+	}
+
+	_, err = g.GetPrinter("unlicensed")
+	var gerr *GraphError
+	if !errors.Is(err, ErrPrintNotLicensed) || errors.Is(err, ErrPrintPermission) || !errors.Is(err, ErrForbidden) || !errors.As(err, &gerr) {
+		t.Errorf("GraphClient.GetPrinter() without license error = %v, want %v with the GraphError", err, ErrPrintNotLicensed)
+	}
+}
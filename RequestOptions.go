@@ -0,0 +1,164 @@
+package msgraph
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestOption configures a single API-call to msgraph, e.g. IdempotencyKey
+type RequestOption func(*requestOptions)
+
+// requestOptions is the configuration of a single API-call, built from the RequestOptions passed to it
+type requestOptions struct {
+	header            http.Header                          // additional headers of the request
+	query             url.Values                           // OData query parameters of the request, e.g. $select
+	apiVersion        string                               // msgraph API version of the request, the one of the GraphClient if empty
+	dryRun            func(req *http.Request, body []byte) // receives the request instead of sending it, see DryRun
+	responseHeader    *http.Header                         // receives the header of the response, see ResponseHeader
+	statusCode        *int                                 // receives the status code of the response, see startOperation
+	retryServerErrors bool                                 // retry a POST on any 5xx StatusCode, see RetryServerErrors
+}
+
+// newRequestOptions returns the requestOptions configured by opts
+func newRequestOptions(opts []RequestOption) requestOptions {
+	o := requestOptions{header: http.Header{}, query: url.Values{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// apply sets the options on the given request. It is called for every attempt of the API-call,
+// hence retries of the request carry the same headers.
+func (o requestOptions) apply(req *http.Request) {
+	for key, values := range o.header {
+		req.Header[key] = values
+	}
+	if len(o.query) > 0 { // replaces the parameter of an @odata.nextLink, which already contains it
+		query := req.URL.Query()
+		for key, values := range o.query {
+			query[key] = values
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// withContentType sets the Content-Type header of the request, e.g. of binary content passed as []byte body
+func withContentType(contentType string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set("Content-Type", contentType)
+	}
+}
+
+// IdempotencyKey sets the client-request-id header of the request to key, see NewIdempotencyKey to derive a
+// deterministic key. The client-request-id is the same for every retry of the request. Without IdempotencyKey
+// every API-call gets a random client-request-id, see NewRequestID.
+//
+// msgraph echoes the client-request-id in the response and logs it for correlation, it does however not document
+// server-side deduplication by that header for any v1.0 endpoint, e.g. sendMail or POST /users. Hence the key makes
+// a duplicated write traceable in the audit and sign-in logs rather than preventing it. Endpoints that have to be
+// strictly idempotent should be guarded by the caller, e.g. by looking up the userPrincipalName before creating a user.
+//
+// See https://docs.microsoft.com/en-us/graph/best-practices-concept#reliability-and-support
+func IdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set("client-request-id", key)
+	}
+}
+
+// RetryServerErrors makes the GraphClient retry a POST request on any 5xx StatusCode, see WithMaxRetries. Without it,
+// a POST is only retried if msgraph throttled it or is unavailable with a Retry-After header, as the server may have
+// failed after it performed the request, e.g. sent a mail or created a user. Use it for POST requests that are safe
+// to repeat, e.g. queries like getByIds.
+func RetryServerErrors() RequestOption {
+	return func(o *requestOptions) {
+		o.retryServerErrors = true
+	}
+}
+
+// WithAPIVersion sends the request to the given msgraph API version, e.g. "beta", instead of the API version of the
+// GraphClient, see GraphClient.Beta.
+func WithAPIVersion(version string) RequestOption {
+	return func(o *requestOptions) {
+		o.apiVersion = version
+	}
+}
+
+// DryRun builds the request of the API-call exactly as it would be sent and passes it to inspect together with the
+// json-marshalled body instead of sending it, e.g. to review the payload of SendEmail before enabling a notification.
+// The API-call returns nil and leaves its result untouched. A token is acquired if needed, as for a real API-call.
+func DryRun(inspect func(req *http.Request, body []byte)) RequestOption {
+	return func(o *requestOptions) {
+		o.dryRun = inspect
+	}
+}
+
+// ResponseHeader stores the header of the response to the API-call in header, e.g. to read the Location of an
+// asynchronous operation or the request-id for a support case. The header is stored for error responses too.
+func ResponseHeader(header *http.Header) RequestOption {
+	return func(o *requestOptions) {
+		o.responseHeader = header
+	}
+}
+
+// ConsistencyLevelEventual sets the ConsistencyLevel header of the request to eventual, which enables the advanced
+// query capabilities of msgraph on directory objects, e.g. $count, $search or lambda operators in $filter. Results
+// may lag behind recent changes.
+//
+// See https://docs.microsoft.com/en-us/graph/aad-advanced-queries
+func ConsistencyLevelEventual() RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set("ConsistencyLevel", "eventual")
+	}
+}
+
+// AdvancedQuery enables the advanced query capabilities of msgraph on directory objects, hence it sets the
+// ConsistencyLevel header to eventual and the $count query parameter to true, which are required together e.g.
+// for endsWith in $filter, for $orderby combined with $filter or for $search. Without them msgraph answers such
+// queries with StatusCode 400. See ConsistencyLevelEventual for the consistency of the results.
+//
+// See https://docs.microsoft.com/en-us/graph/aad-advanced-queries
+func AdvancedQuery() RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set("ConsistencyLevel", "eventual")
+		o.query.Set("$count", "true")
+	}
+}
+
+// Select sets the $select query parameter of the request to the given properties, e.g. "id" and
+// "userPrincipalName", hence msgraph returns only them instead of the default properties. The other fields of the
+// result stay empty. This reduces the size of large collections considerably.
+//
+// See https://docs.microsoft.com/en-us/graph/query-parameters#select-parameter
+func Select(properties ...string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Set("$select", strings.Join(properties, ","))
+	}
+}
+
+// OrderBy sets the $orderby query parameter of the request to the given properties, each optionally followed by
+// "asc" or "desc", e.g. "displayName desc", hence msgraph returns the collection sorted. Some properties, e.g. mail
+// or createdDateTime of users, can only be sorted with the advanced query capabilities, see AdvancedQuery.
+//
+// See https://docs.microsoft.com/en-us/graph/query-parameters#orderby-parameter
+func OrderBy(properties ...string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Set("$orderby", strings.Join(properties, ","))
+	}
+}
+
+// Search sets the $search query parameter of the request to the given query, e.g. "displayName:john", and enables
+// the advanced query capabilities required by $search, see AdvancedQuery. msgraph requires the query to be wrapped
+// in double quotes, which is done unless it already contains them, e.g. `"displayName:john" OR "mail:john"`.
+//
+// See https://docs.microsoft.com/en-us/graph/search-query-parameter
+func Search(query string) RequestOption {
+	if !strings.Contains(query, `"`) {
+		query = `"` + query + `"`
+	}
+	return func(o *requestOptions) {
+		AdvancedQuery()(o)
+		o.query.Set("$search", query)
+	}
+}
@@ -0,0 +1,145 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	var got string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/alice@contoso.com/sendMail", func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("client-request-id")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	g := newTestGraphClient(t, mux)
+
+	mail := MakeMail()
+	mail.From("alice@contoso.com")
+	key := NewIdempotencyKey("bob@contoso.com 2021-03-04T05:06:07Z")
+	if err := g.SendEmail(mail, IdempotencyKey(key)); err != nil {
+		t.Fatalf("GraphClient.SendEmail() error = %v", err)
+	}
+	if got != key {
+		t.Errorf("client-request-id = %v, want %v", got, key)
+	}
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	g := newTestGraphClient(t, mux)
+
+	mail := MakeMail()
+	mail.From("alice@contoso.com")
+	if err := g.SendEmail(mail, WithAPIVersion("beta")); err != nil {
+		t.Fatalf("GraphClient.SendEmail() error = %v", err)
+	}
+	if err := g.Beta().SendEmail(mail, WithAPIVersion(APIVersion)); err != nil {
+		t.Fatalf("GraphClient.SendEmail() error = %v", err)
+	}
+	if want := "[/beta/users/alice@contoso.com/sendMail /v1.0/users/alice@contoso.com/sendMail]"; fmt.Sprint(got) != want {
+		t.Errorf("request paths = %v, want %v", got, want)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("$select"))
+		if r.URL.Query().Get("$skiptoken") == "" {
+			fmt.Fprint(w, `{"value": [{"id": "u1", "userPrincipalName": "alice@contoso.com"}],
+				"@odata.nextLink": "https://graph.microsoft.com/v1.0/users?$select=id,userPrincipalName&$skiptoken=page2"}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "u2", "userPrincipalName": "bob@contoso.com"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("$select"))
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	users, err := g.ListUsers(Select("id", "userPrincipalName"))
+	if err != nil || len(users) != 2 || users[1].UserPrincipalName != "bob@contoso.com" || users[1].DisplayName != "" {
+		t.Errorf("GraphClient.ListUsers() = %v, %v, want u1 and u2 with their userPrincipalName only", users, err)
+	}
+	if user, err := g.GetUser("u1", Select("id")); err != nil || user.ID != "u1" {
+		t.Errorf("GraphClient.GetUser() = %v, %v, want u1", user, err)
+	}
+	if want := "[id,userPrincipalName id,userPrincipalName id]"; fmt.Sprint(queries) != want {
+		t.Errorf("$select of the requests = %v, want %v", queries, want)
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/groups", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("$filter")+"|"+r.URL.Query().Get("$orderby"))
+		fmt.Fprint(w, `{"value": [{"id": "g1"}]}`)
+	})
+	mux.HandleFunc("/v1.0/groups/g1/members", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("$select")+"|"+r.URL.Query().Get("$orderby"))
+		fmt.Fprint(w, `{"value": [{"id": "u2", "displayName": "Bob"}, {"id": "u1", "displayName": "Alice"}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	groups, err := g.ListGroupsWithFilter("securityEnabled eq true", OrderBy("displayName desc"))
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("GraphClient.ListGroupsWithFilter() = %v, %v, want g1", groups, err)
+	}
+	if _, err := groups[0].ListMembers(Select("id", "displayName"), OrderBy("displayName desc", "id")); err != nil {
+		t.Errorf("Group.ListMembers() error = %v", err)
+	}
+	if want := "[securityEnabled eq true|displayName desc id,displayName|displayName desc,id]"; fmt.Sprint(queries) != want {
+		t.Errorf("queries of the requests = %v, want %v", queries, want)
+	}
+}
+
+func TestAdvancedQuery(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("ConsistencyLevel"), r.URL.Query().Get("$count"), r.URL.Query().Get("$filter"))
+		fmt.Fprint(w, `{"@odata.count": 1, "value": [{"id": "u1", "mail": "alice@contoso.com"}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	users, err := g.ListUsersWithFilter("endswith(mail,'@contoso.com')", AdvancedQuery())
+	if err != nil || len(users) != 1 {
+		t.Fatalf("GraphClient.ListUsersWithFilter() = %v, %v, want u1", users, err)
+	}
+	if want := "[eventual true endswith(mail,'@contoso.com')]"; fmt.Sprint(got) != want {
+		t.Errorf("ConsistencyLevel, $count and $filter = %v, want %v", got, want)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("ConsistencyLevel")+" "+r.URL.Query().Get("$count")+" "+r.URL.Query().Get("$search"))
+		fmt.Fprint(w, `{"value": [{"id": "1"}]}`)
+	}
+	mux.HandleFunc("/v1.0/users", handler)
+	mux.HandleFunc("/v1.0/groups", handler)
+	g := newTestGraphClient(t, mux)
+
+	if users, err := g.SearchUsers("displayName:john"); err != nil || len(users) != 1 {
+		t.Errorf("GraphClient.SearchUsers() = %v, %v", users, err)
+	}
+	if groups, err := g.SearchGroups(`"displayName:sales" OR "mail:sales"`, OrderBy("displayName")); err != nil || len(groups) != 1 {
+		t.Errorf("GraphClient.SearchGroups() = %v, %v", groups, err)
+	}
+	want := []string{`eventual true "displayName:john"`, `eventual true "displayName:sales" OR "mail:sales"`}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ConsistencyLevel, $count and $search = %q, want %q", got, want)
+	}
+}
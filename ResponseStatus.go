@@ -6,10 +6,34 @@ import (
 	"time"
 )
 
+// ResponseType is the type of the response of an Attendee to a CalendarEvent
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/responsestatus
+type ResponseType string
+
+// Response types as returned by msgraph in responseStatus.response
+const (
+	ResponseTypeNone                ResponseType = "none"
+	ResponseTypeOrganizer           ResponseType = "organizer"
+	ResponseTypeTentativelyAccepted ResponseType = "tentativelyAccepted"
+	ResponseTypeAccepted            ResponseType = "accepted"
+	ResponseTypeDeclined            ResponseType = "declined"
+	ResponseTypeNotResponded        ResponseType = "notResponded"
+)
+
+// IsValid returns true if t is one of the ResponseType constants
+func (t ResponseType) IsValid() bool {
+	switch t {
+	case ResponseTypeNone, ResponseTypeOrganizer, ResponseTypeTentativelyAccepted, ResponseTypeAccepted, ResponseTypeDeclined, ResponseTypeNotResponded:
+		return true
+	}
+	return false
+}
+
 // ResponseStatus represents the response status for an Attendee to a CalendarEvent or just for a CalendarEvent
 type ResponseStatus struct {
-	Response string    // status of the response, may be organizer, accepted, declined etc.
-	Time     time.Time // represents the time when the response was performed
+	Response ResponseType // status of the response, may be organizer, accepted, declined etc.
+	Time     time.Time    // represents the time when the response was performed
 }
 
 func (s ResponseStatus) String() string {
@@ -25,8 +49,8 @@ func (s ResponseStatus) Equal(other ResponseStatus) bool {
 // UnmarshalJSON implements the json unmarshal to be used by the json-library
 func (s *ResponseStatus) UnmarshalJSON(data []byte) error {
 	tmp := struct {
-		Response  string `json:"response"`
-		Timestamp string `json:"time"`
+		Response  ResponseType `json:"response"`
+		Timestamp string       `json:"time"`
 	}{}
 
 	err := json.Unmarshal(data, &tmp)
@@ -45,3 +69,11 @@ func (s *ResponseStatus) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements the json marshal to be used by the json-library, it is the inverse of UnmarshalJSON
+func (s ResponseStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Response  ResponseType `json:"response"`
+		Timestamp string       `json:"time"`
+	}{Response: s.Response, Timestamp: s.Time.Format(time.RFC3339Nano)})
+}
@@ -90,3 +90,36 @@ func TestResponseStatus_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseStatus_UnmarshalJSON_ResponseType(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want ResponseStatus
+	}{
+		{
+			name: "tentatively accepted",
+			data: `{"response": "tentativelyAccepted", "time": "2021-03-04T05:06:07.1234567Z"}`,
+			want: ResponseStatus{Response: ResponseTypeTentativelyAccepted, Time: time.Date(2021, 3, 4, 5, 6, 7, 123456700, time.UTC)},
+		}, {
+			name: "not responded",
+			data: `{"response": "notResponded", "time": "0001-01-01T00:00:00Z"}`,
+			want: ResponseStatus{Response: ResponseTypeNotResponded},
+		}, {
+			name: "none",
+			data: `{"response": "none", "time": "0001-01-01T00:00:00Z"}`,
+			want: ResponseStatus{Response: ResponseTypeNone},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ResponseStatus
+			if err := got.UnmarshalJSON([]byte(tt.data)); err != nil {
+				t.Fatalf("ResponseStatus.UnmarshalJSON() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ResponseStatus.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package msgraph
 
 import (
+	"context"
 	"net"
 	"time"
 )
@@ -188,11 +189,16 @@ type VulnerabilityState struct {
 
 // ListAlerts returns a slice of Alert objects from MS Graph's security API. Each Alert represents a security event reported by some component.
 func (g *GraphClient) ListAlerts() ([]Alert, error) {
+	return g.ListAlertsContext(context.Background())
+}
+
+// ListAlertsContext is ListAlerts with a context.
+func (g *GraphClient) ListAlertsContext(ctx context.Context) ([]Alert, error) {
 	resource := "/security/alerts"
 	var marsh struct {
 		Alerts []Alert `json:"value"`
 	}
-	err := g.makeGETAPICall(resource, nil, &marsh)
+	err := g.makeGETAPICall(ctx, resource, nil, &marsh)
 	return marsh.Alerts, err
 }
 
@@ -229,11 +235,16 @@ type ControlScore struct {
 // ListSecureScores returns a slice of SecureScore objects. Each SecureScore represents
 // a tenant's security score for a particular day.
 func (g *GraphClient) ListSecureScores() ([]SecureScore, error) {
+	return g.ListSecureScoresContext(context.Background())
+}
+
+// ListSecureScoresContext is ListSecureScores with a context.
+func (g *GraphClient) ListSecureScoresContext(ctx context.Context) ([]SecureScore, error) {
 	resource := "/security/secureScores"
 	var marsh struct {
 		Scores []SecureScore `json:"value"`
 	}
-	err := g.makeGETAPICall(resource, nil, &marsh)
+	err := g.makeGETAPICall(ctx, resource, nil, &marsh)
 	return marsh.Scores, err
 }
 
@@ -288,10 +299,15 @@ type SecureScoreControlStateUpdate struct {
 // Each object represents a secure score control profile, which is used when calculating
 // a tenant's secure score.
 func (g *GraphClient) ListSecureScoreControlProfiles() ([]SecureScoreControlProfile, error) {
+	return g.ListSecureScoreControlProfilesContext(context.Background())
+}
+
+// ListSecureScoreControlProfilesContext is ListSecureScoreControlProfiles with a context.
+func (g *GraphClient) ListSecureScoreControlProfilesContext(ctx context.Context) ([]SecureScoreControlProfile, error) {
 	resource := "/security/secureScoreControlProfiles"
 	var marsh struct {
 		Profiles []SecureScoreControlProfile `json:"value"`
 	}
-	err := g.makeGETAPICall(resource, nil, &marsh)
+	err := g.makeGETAPICall(ctx, resource, nil, &marsh)
 	return marsh.Profiles, err
 }
\ No newline at end of file
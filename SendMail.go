@@ -1,8 +1,13 @@
 package msgraph
 
 import (
+	"bytes"
 	b64 "encoding/base64"
+	"encoding/json"
 	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
 )
 
 type Mail struct {
@@ -17,14 +22,71 @@ type Message struct {
 	BccRecipients []Recipient  `json:"bccRecipients"`
 	From          Recipient    `json:"from"`
 	Attachments   []Attachment `json:"attachments"`
+	BodyPreview   string       `json:"bodyPreview,omitempty"` // read-only, the first 255 characters of the body as text
+	UniqueBody    *MsgBody     `json:"uniqueBody,omitempty"`  // read-only, the part of the body that is unique to this message in its conversation, only returned if selected
+
+	AdditionalData AdditionalData `json:"-"` // properties that are not mapped to a field, see CaptureAdditionalData
 	// SaveToSentItems bool         `json:"saveToSentItems"`
 }
 
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type message Message // message has no UnmarshalJSON, prevents the recursion
+	if err := json.Unmarshal(data, (*message)(m)); err != nil {
+		return err
+	}
+	var err error
+	m.AdditionalData, err = extractAdditionalData(data, m)
+	return err
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the AdditionalData is included
+func (m Message) MarshalJSON() ([]byte, error) {
+	type message Message // message has no MarshalJSON, prevents the recursion
+	marshalled, err := json.Marshal(message(m))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, m.AdditionalData)
+}
+
+// BodyText returns the body of the message as readable plain text, see MsgBody.Text
+func (m Message) BodyText() string {
+	return m.Body.Text()
+}
+
+// UniqueBodyText returns the unique body of the message as readable plain text if it has been
+// returned by msgraph, otherwise the whole body as plain text. See MsgBody.Text
+func (m Message) UniqueBodyText() string {
+	if m.UniqueBody != nil {
+		return m.UniqueBody.Text()
+	}
+	return m.BodyText()
+}
+
+// BodyPreviewTruncated returns the BodyPreview (or the body as text if there's no preview) truncated to at most n runes.
+// A truncated preview ends with "…".
+func (m Message) BodyPreviewTruncated(n int) string {
+	if m.BodyPreview != "" {
+		return truncateRunes(m.BodyPreview, n)
+	}
+	return truncateRunes(m.BodyText(), n)
+}
+
 type MsgBody struct {
 	ContentType string `json:"contentType"`
 	Content     string `json:"content"`
 }
 
+// Text returns the content as readable plain text. HTML content is stripped of its markup, line breaks
+// and paragraphs become newlines and list items become bullets. Text content is returned unchanged.
+func (b MsgBody) Text() string {
+	if strings.EqualFold(b.ContentType, "HTML") {
+		return htmlToText(b.Content)
+	}
+	return b.Content
+}
+
 type Recipient struct {
 	EmailAddress EmailAddress `json:"emailAddress"`
 }
@@ -131,6 +193,49 @@ func (m *Mail) Body(contentType, content string) {
 	m.Message.Body.Content = content
 }
 
+// RenderTemplate executes the template tmpl with the given data and sets the result as content of the body,
+// e.g. "Dear {{.Name}}". The content type of the body has to be set before, see Body(). For a HTML body the
+// template is executed with html/template, hence the data is escaped and cannot inject HTML. Otherwise
+// text/template is used.
+func (m *Mail) RenderTemplate(tmpl string, data interface{}) error {
+	var content bytes.Buffer
+	if strings.EqualFold(m.Message.Body.ContentType, "HTML") {
+		t, err := htmltemplate.New("body").Parse(tmpl)
+		if err != nil {
+			return fmt.Errorf("cannot parse HTML template: %v", err)
+		}
+		if err := t.Execute(&content, data); err != nil {
+			return fmt.Errorf("cannot execute HTML template: %v", err)
+		}
+	} else {
+		t, err := template.New("body").Parse(tmpl)
+		if err != nil {
+			return fmt.Errorf("cannot parse template: %v", err)
+		}
+		if err := t.Execute(&content, data); err != nil {
+			return fmt.Errorf("cannot execute template: %v", err)
+		}
+	}
+	m.Message.Body.Content = content.String()
+	return nil
+}
+
+// Preview returns the json payload of the mail as sent by SendEmail, indented for reading. The content of
+// attachments is replaced by a note of its size. Use the DryRun option of SendEmail to get the exact request.
+func (m Mail) Preview() ([]byte, error) {
+	preview := m
+	preview.Message.Attachments = make([]Attachment, len(m.Message.Attachments))
+	for i, attachment := range m.Message.Attachments {
+		size := b64.StdEncoding.DecodedLen(len(attachment.ContentBytes))
+		if decoded, err := b64.StdEncoding.DecodeString(attachment.ContentBytes); err == nil {
+			size = len(decoded)
+		}
+		attachment.ContentBytes = fmt.Sprintf("[%v bytes]", size)
+		preview.Message.Attachments[i] = attachment
+	}
+	return json.MarshalIndent(preview, "", "  ")
+}
+
 func (m *Mail) AddFileAttachment(attachmentName, contentType, content string) {
 	attachment := Attachment{
 		DataType:     "#microsoft.graph.fileAttachment",
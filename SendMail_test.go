@@ -1,7 +1,12 @@
 package msgraph
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -33,3 +38,95 @@ func TestSendEmail(t *testing.T) {
 	}
 
 }
+
+func TestMail_RenderTemplate(t *testing.T) {
+	data := struct{ Name string }{Name: "<b>Bob</b> & Co"}
+	tests := []struct {
+		name        string
+		contentType string
+		tmpl        string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "text",
+			contentType: "Text",
+			tmpl:        "Dear {{.Name}}",
+			want:        "Dear <b>Bob</b> & Co",
+		}, {
+			name:        "HTML is escaped",
+			contentType: "HTML",
+			tmpl:        "<p>Dear {{.Name}}</p>",
+			want:        "<p>Dear &lt;b&gt;Bob&lt;/b&gt; &amp; Co</p>",
+		}, {
+			name:        "invalid template",
+			contentType: "Text",
+			tmpl:        "Dear {{.Name",
+			wantErr:     true,
+		}, {
+			name:        "unknown field",
+			contentType: "HTML",
+			tmpl:        "Dear {{.Surname}}",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mail := MakeMail()
+			mail.Body(tt.contentType, "")
+			err := mail.RenderTemplate(tt.tmpl, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Mail.RenderTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := mail.Message.Body.Content; !tt.wantErr && got != tt.want {
+				t.Errorf("Mail.RenderTemplate() content = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRun_SendEmail(t *testing.T) {
+	var sent []byte
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/alice@contoso.com/sendMail", func(w http.ResponseWriter, r *http.Request) {
+		sent, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	g := newTestGraphClient(t, mux)
+
+	mail := MakeMail()
+	mail.From("alice@contoso.com")
+	mail.AddRecipient("bob@contoso.com")
+	mail.Subject("Maintenance window")
+	mail.Body("HTML", "<p>The plant is offline on <b>Saturday</b>.</p>")
+
+	var dryRunURL string
+	var dryRunBody []byte
+	err := g.SendEmail(mail, DryRun(func(req *http.Request, body []byte) {
+		dryRunURL, dryRunBody = req.URL.String(), body
+	}))
+	if err != nil || sent != nil {
+		t.Fatalf("GraphClient.SendEmail() with DryRun error = %v, sent = %s, want nothing sent", err, sent)
+	}
+	if err := g.SendEmail(mail); err != nil {
+		t.Fatalf("GraphClient.SendEmail() error = %v", err)
+	}
+	if !bytes.Equal(dryRunBody, sent) || dryRunURL != "https://graph.microsoft.com/v1.0/users/alice@contoso.com/sendMail" {
+		t.Errorf("DryRun request = %v %s, want the sent body %s", dryRunURL, dryRunBody, sent)
+	}
+
+	preview, err := mail.Preview()
+	if err != nil {
+		t.Fatalf("Mail.Preview() error = %v", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, preview); err != nil || !bytes.Equal(compact.Bytes(), sent) {
+		t.Errorf("Mail.Preview() = %s, want the indented sent body %s", preview, sent)
+	}
+
+	mail.AddFileAttachment("report.csv", "text/csv", strings.Repeat("x", 2048))
+	preview, _ = mail.Preview()
+	if !strings.Contains(string(preview), `"contentBytes": "[2048 bytes]"`) {
+		t.Errorf("Mail.Preview() = %s, want the attachment content replaced by its size", preview)
+	}
+}
@@ -0,0 +1,153 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SensitivityLabel represents an information protection label, e.g. Confidential or Public, that can be
+// applied to emails and files.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/informationprotectionlabel?view=graph-rest-beta
+type SensitivityLabel struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Color       string            `json:"color"`   // the color that the UI should display for the label, if configured
+	Tooltip     string            `json:"tooltip"` // the tooltip that should be displayed for the label in a UI
+	IsActive    bool              `json:"isActive"`
+	Priority    int               `json:"sensitivity"` // the sensitivity value of the label, where lower is less sensitive
+	Parent      *SensitivityLabel `json:"parent"`      // the parent label, nil if the label is a top-level label
+}
+
+func (s SensitivityLabel) String() string {
+	var parent string
+	if s.Parent != nil {
+		parent = s.Parent.Name
+	}
+	return fmt.Sprintf("SensitivityLabel(ID: \"%v\", Name: \"%v\", Description: \"%v\", Color: \"%v\", Tooltip: \"%v\", IsActive: \"%v\", Priority: \"%v\", Parent: \"%v\")",
+		s.ID, s.Name, s.Description, s.Color, s.Tooltip, s.IsActive, s.Priority, parent)
+}
+
+// MatchingLabel represents a sensitivity label that should be applied to content according to the
+// evaluation of the information protection policy
+type MatchingLabel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Tooltip     string `json:"tooltip"`
+	Priority    int    `json:"sensitivity"`
+}
+
+// ListSensitivityLabels returns all sensitivity labels that are available to the user.
+//
+// Beta: uses the msgraph beta endpoint, the API may change without notice.
+//
+// See https://docs.microsoft.com/en-us/graph/api/informationprotectionpolicy-list-labels?view=graph-rest-beta
+func (u User) ListSensitivityLabels() ([]SensitivityLabel, error) {
+	return u.ListSensitivityLabelsContext(context.Background())
+}
+
+// ListSensitivityLabelsContext is ListSensitivityLabels with a context.
+func (u User) ListSensitivityLabelsContext(ctx context.Context) ([]SensitivityLabel, error) {
+	if u.graphClient == nil {
+		return nil, ErrNotGraphClientSourced
+	}
+	resource := fmt.Sprintf("/users/%v/informationProtection/policy/labels", u.ID)
+
+	var marsh struct {
+		Labels []SensitivityLabel `json:"value"`
+	}
+	err := u.graphClient.makeBetaAPICall(ctx, http.MethodGet, resource, nil, nil, &marsh)
+	return marsh.Labels, err
+}
+
+// EvaluateLabelsForEmail returns the sensitivity labels that the information protection policy of the user
+// requires for an email with the given content. The content is classified first (dataClassification/classifyText),
+// the resulting sensitive information types are then evaluated against the policy of the user.
+//
+// Beta: uses the msgraph beta endpoint, the API may change without notice.
+//
+// See https://docs.microsoft.com/en-us/graph/api/informationprotectionlabel-evaluateclassificationresults?view=graph-rest-beta
+func (u User) EvaluateLabelsForEmail(sensitiveContent string) ([]MatchingLabel, error) {
+	return u.EvaluateLabelsForEmailContext(context.Background(), sensitiveContent)
+}
+
+// EvaluateLabelsForEmailContext is EvaluateLabelsForEmail with a context.
+func (u User) EvaluateLabelsForEmailContext(ctx context.Context, sensitiveContent string) ([]MatchingLabel, error) {
+	if u.graphClient == nil {
+		return nil, ErrNotGraphClientSourced
+	}
+
+	classifyBody := struct {
+		Text string `json:"text"`
+	}{Text: sensitiveContent}
+	var classification struct {
+		Results []json.RawMessage `json:"value"`
+	}
+	err := u.graphClient.makeBetaAPICall(ctx, http.MethodPost, "/dataClassification/classifyText", nil, classifyBody, &classification)
+	if err != nil {
+		return nil, fmt.Errorf("cannot classify content: %w", err)
+	}
+
+	evaluateBody := struct {
+		ContentInfo           map[string]interface{} `json:"contentInfo"`
+		ClassificationResults []json.RawMessage      `json:"classificationResults"`
+	}{
+		ContentInfo: map[string]interface{}{
+			"@odata.type":         "#microsoft.graph.contentInfo",
+			"format@odata.type":   "#microsoft.graph.contentFormat",
+			"format":              "email",
+			"state@odata.type":    "#microsoft.graph.contentState",
+			"state":               "motion",
+			"metadata@odata.type": "#Collection(microsoft.graph.keyValuePair)",
+			"metadata":            []interface{}{},
+		},
+		ClassificationResults: classification.Results,
+	}
+	if evaluateBody.ClassificationResults == nil {
+		evaluateBody.ClassificationResults = []json.RawMessage{}
+	}
+	var actions struct {
+		Value []struct {
+			ODataType string        `json:"@odata.type"`
+			Label     MatchingLabel `json:"label"`
+		} `json:"value"`
+	}
+	resource := fmt.Sprintf("/users/%v/informationProtection/policy/labels/evaluateClassificationResults", u.ID)
+	err = u.graphClient.makeBetaAPICall(ctx, http.MethodPost, resource, nil, evaluateBody, &actions)
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate classification results: %w", err)
+	}
+
+	var labels []MatchingLabel
+	for _, action := range actions.Value {
+		if action.ODataType == "#microsoft.graph.applyLabelAction" && action.Label.ID != "" {
+			labels = append(labels, action.Label)
+		}
+	}
+	return labels, nil
+}
+
+// ApplyLabelToDriveItem assigns the sensitivity label identified by labelID to the item identified by itemID in the
+// OneDrive of the user identified by either the given ID or userPrincipalName. msgraph applies the label asynchronously.
+//
+// Beta: uses the msgraph beta endpoint, the API may change without notice.
+//
+// See https://docs.microsoft.com/en-us/graph/api/driveitem-assignsensitivitylabel?view=graph-rest-beta
+func (g *GraphClient) ApplyLabelToDriveItem(identifier, itemID, labelID string) error {
+	return g.ApplyLabelToDriveItemContext(context.Background(), identifier, itemID, labelID)
+}
+
+// ApplyLabelToDriveItemContext is ApplyLabelToDriveItem with a context.
+func (g *GraphClient) ApplyLabelToDriveItemContext(ctx context.Context, identifier, itemID, labelID string) error {
+	resource := fmt.Sprintf("/users/%v/drive/items/%v/assignSensitivityLabel", identifier, itemID)
+	body := struct {
+		SensitivityLabelID string `json:"sensitivityLabelId"`
+		AssignmentMethod   string `json:"assignmentMethod"`
+	}{SensitivityLabelID: labelID, AssignmentMethod: "standard"}
+	return g.makeBetaAPICall(ctx, http.MethodPost, resource, nil, body, nil)
+}
@@ -0,0 +1,92 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ServicePrincipal represents an instance of an application in a directory, e.g. an enterprise application.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/serviceprincipal
+type ServicePrincipal struct {
+	ID                        string   `json:"id"`
+	AppID                     string   `json:"appId"`
+	AppDisplayName            string   `json:"appDisplayName"`
+	DisplayName               string   `json:"displayName"`
+	AccountEnabled            bool     `json:"accountEnabled"`
+	AppRoleAssignmentRequired bool     `json:"appRoleAssignmentRequired"`
+	ServicePrincipalType      string   `json:"servicePrincipalType"`
+	Tags                      []string `json:"tags"`
+}
+
+func (s ServicePrincipal) String() string {
+	return fmt.Sprintf("ServicePrincipal(ID: \"%v\", AppID: \"%v\", AppDisplayName: \"%v\", DisplayName: \"%v\", AccountEnabled: \"%v\", "+
+		"AppRoleAssignmentRequired: \"%v\", ServicePrincipalType: \"%v\", Tags: \"%v\")",
+		s.ID, s.AppID, s.AppDisplayName, s.DisplayName, s.AccountEnabled, s.AppRoleAssignmentRequired, s.ServicePrincipalType, s.Tags)
+}
+
+// AppRoleAssignment represents an app role that has been granted to a user, group or service principal
+// (the principal) for an application (the resource).
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/approleassignment
+type AppRoleAssignment struct {
+	ID                   string    `json:"id"`
+	AppRoleID            string    `json:"appRoleId"` // 00000000-0000-0000-0000-000000000000 is the default role of an application
+	CreatedDateTime      time.Time `json:"createdDateTime"`
+	PrincipalDisplayName string    `json:"principalDisplayName"`
+	PrincipalID          string    `json:"principalId"`
+	PrincipalType        string    `json:"principalType"` // either User, Group or ServicePrincipal
+	ResourceDisplayName  string    `json:"resourceDisplayName"`
+	ResourceID           string    `json:"resourceId"`
+}
+
+// ListServicePrincipals returns all service principals of the tenant
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/serviceprincipal-list
+func (g *GraphClient) ListServicePrincipals() ([]ServicePrincipal, error) {
+	return g.ListServicePrincipalsContext(context.Background())
+}
+
+// ListServicePrincipalsContext is ListServicePrincipals with a context.
+func (g *GraphClient) ListServicePrincipalsContext(ctx context.Context) ([]ServicePrincipal, error) {
+	return g.listServicePrincipals(ctx, "")
+}
+
+// listServicePrincipals returns all service principals that match the given OData $filter, all
+// service principals are returned if the filter is empty.
+func (g *GraphClient) listServicePrincipals(ctx context.Context, filter string) ([]ServicePrincipal, error) {
+	getParams := url.Values{}
+	if filter != "" {
+		getParams.Add("$filter", filter)
+	}
+	var servicePrincipals []ServicePrincipal
+	err := g.makePagedGETAPICall(ctx, "/servicePrincipals", getParams, func(value json.RawMessage) (bool, error) {
+		var page []ServicePrincipal
+		err := json.Unmarshal(value, &page)
+		servicePrincipals = append(servicePrincipals, page...)
+		return true, err
+	})
+	return servicePrincipals, err
+}
+
+// ListServicePrincipalAppRoleAssignedTo returns all app role assignments that have been granted to
+// users, groups and service principals for the service principal identified by the given ID. All
+// pages are loaded, the opts are applied to the API-call of every page, e.g. Select.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/serviceprincipal-list-approleassignedto
+func (g *GraphClient) ListServicePrincipalAppRoleAssignedTo(servicePrincipalID string, opts ...RequestOption) ([]AppRoleAssignment, error) {
+	return g.ListServicePrincipalAppRoleAssignedToContext(context.Background(), servicePrincipalID, opts...)
+}
+
+// ListServicePrincipalAppRoleAssignedToContext is ListServicePrincipalAppRoleAssignedTo with a context.
+func (g *GraphClient) ListServicePrincipalAppRoleAssignedToContext(ctx context.Context, servicePrincipalID string, opts ...RequestOption) ([]AppRoleAssignment, error) {
+	resource := fmt.Sprintf("/servicePrincipals/%v/appRoleAssignedTo", servicePrincipalID)
+	var marsh struct {
+		Assignments []AppRoleAssignment `json:"value"`
+	}
+	err := g.makeGETAPICall(ctx, resource, nil, &marsh, opts...)
+	return marsh.Assignments, err
+}
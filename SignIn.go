@@ -0,0 +1,118 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SignIn represents a sign-in of a user or application in the tenant
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/signin
+type SignIn struct {
+	ID                      string       `json:"id"`
+	CreatedDateTime         time.Time    `json:"createdDateTime"`
+	UserID                  string       `json:"userId"`
+	UserDisplayName         string       `json:"userDisplayName"`
+	UserPrincipalName       string       `json:"userPrincipalName"`
+	AppID                   string       `json:"appId"`
+	AppDisplayName          string       `json:"appDisplayName"`
+	IPAddress               string       `json:"ipAddress"`
+	ClientAppUsed           string       `json:"clientAppUsed"` // e.g. Browser or Mobile Apps and Desktop clients
+	ConditionalAccessStatus string       `json:"conditionalAccessStatus"`
+	IsInteractive           bool         `json:"isInteractive"`
+	Status                  SignInStatus `json:"status"`
+	DeviceDetail            DeviceDetail `json:"deviceDetail"`
+	Location                struct {
+		City            string `json:"city"`
+		State           string `json:"state"`
+		CountryOrRegion string `json:"countryOrRegion"`
+	} `json:"location"`
+}
+
+func (s SignIn) String() string {
+	return fmt.Sprintf("SignIn(ID: \"%v\", CreatedDateTime: \"%v\", UserPrincipalName: \"%v\", AppDisplayName: \"%v\", IPAddress: \"%v\", "+
+		"ClientAppUsed: \"%v\", ConditionalAccessStatus: \"%v\", Status: \"%v\", DeviceDetail: \"%v\", Location: \"%v, %v\")",
+		s.ID, s.CreatedDateTime, s.UserPrincipalName, s.AppDisplayName, s.IPAddress, s.ClientAppUsed, s.ConditionalAccessStatus,
+		s.Status, s.DeviceDetail, s.Location.City, s.Location.CountryOrRegion)
+}
+
+// IsSuccess returns true if the sign-in succeeded
+func (s SignIn) IsSuccess() bool {
+	return s.Status.ErrorCode == 0
+}
+
+// SignInStatus is the result of a SignIn
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/signinstatus
+type SignInStatus struct {
+	ErrorCode         int    `json:"errorCode"` // 0 on success, otherwise the AADSTS error code, e.g. 50126 for invalid credentials
+	FailureReason     string `json:"failureReason"`
+	AdditionalDetails string `json:"additionalDetails"`
+}
+
+func (s SignInStatus) String() string {
+	if s.ErrorCode == 0 {
+		return "success"
+	}
+	return fmt.Sprintf("%v: %v", s.ErrorCode, s.FailureReason)
+}
+
+// DeviceDetail describes the device a SignIn has been performed with
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/devicedetail
+type DeviceDetail struct {
+	DeviceID        string `json:"deviceId"`
+	DisplayName     string `json:"displayName"`
+	OperatingSystem string `json:"operatingSystem"`
+	Browser         string `json:"browser"`
+	IsCompliant     bool   `json:"isCompliant"`
+	IsManaged       bool   `json:"isManaged"`
+	TrustType       string `json:"trustType"`
+}
+
+func (d DeviceDetail) String() string {
+	return fmt.Sprintf("%v (%v, %v)", d.DisplayName, d.OperatingSystem, d.Browser)
+}
+
+// ListSignInsForApp returns the sign-ins to the application identified by appID (the client id) since the given time,
+// newest first. Requires the AuditLog.Read.All permission, msgraph keeps sign-ins for 30 days at most.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/signin-list
+func (g *GraphClient) ListSignInsForApp(appID string, since time.Time) ([]SignIn, error) {
+	return g.ListSignInsForAppContext(context.Background(), appID, since)
+}
+
+// ListSignInsForAppContext is ListSignInsForApp with a context.
+func (g *GraphClient) ListSignInsForAppContext(ctx context.Context, appID string, since time.Time) ([]SignIn, error) {
+	return g.ListSignInsForAppBetweenContext(ctx, appID, since, time.Time{})
+}
+
+// ListSignInsForAppBetween returns the sign-ins to the application identified by appID (the client id) within the
+// given time window, newest first. A zero end means until now.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/signin-list
+func (g *GraphClient) ListSignInsForAppBetween(appID string, start, end time.Time) ([]SignIn, error) {
+	return g.ListSignInsForAppBetweenContext(context.Background(), appID, start, end)
+}
+
+// ListSignInsForAppBetweenContext is ListSignInsForAppBetween with a context.
+func (g *GraphClient) ListSignInsForAppBetweenContext(ctx context.Context, appID string, start, end time.Time) ([]SignIn, error) {
+	filter := fmt.Sprintf("appId eq '%v' and createdDateTime ge %v", appID, start.UTC().Format(time.RFC3339))
+	if !end.IsZero() {
+		filter += fmt.Sprintf(" and createdDateTime le %v", end.UTC().Format(time.RFC3339))
+	}
+	getParams := url.Values{}
+	getParams.Add("$filter", filter)
+
+	var signIns []SignIn
+	err := g.makePagedGETAPICall(ctx, "/auditLogs/signIns", getParams, func(value json.RawMessage) (bool, error) {
+		var page []SignIn
+		err := json.Unmarshal(value, &page)
+		signIns = append(signIns, page...)
+		return true, err
+	})
+	return signIns, err
+}
@@ -0,0 +1,38 @@
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_ListSignInsForAppBetween(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/auditLogs/signIns", func(w http.ResponseWriter, r *http.Request) {
+		want := "appId eq 'app1' and createdDateTime ge 2021-03-01T00:00:00Z and createdDateTime le 2021-03-02T00:00:00Z"
+		if got := r.URL.Query().Get("$filter"); got != want {
+			t.Errorf("GET /auditLogs/signIns $filter = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"value": [
+			{"id": "s1", "createdDateTime": "2021-03-01T10:00:00Z", "userPrincipalName": "alice@contoso.com", "appId": "app1", "ipAddress": "203.0.113.7",
+			 "status": {"errorCode": 50126, "failureReason": "Invalid username or password."},
+			 "deviceDetail": {"deviceId": "", "operatingSystem": "Windows 10", "browser": "Edge 89.0"}, "location": {"city": "Vienna", "countryOrRegion": "AT"}},
+			{"id": "s2", "createdDateTime": "2021-03-01T10:01:00Z", "userPrincipalName": "alice@contoso.com", "appId": "app1", "status": {"errorCode": 0}}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	got, err := g.ListSignInsForAppBetween("app1", time.Date(2021, 3, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)), time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GraphClient.ListSignInsForAppBetween() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("GraphClient.ListSignInsForAppBetween() = %v, want 2 sign-ins", got)
+	}
+	if got[0].IsSuccess() || got[0].Status.ErrorCode != 50126 || got[0].DeviceDetail.OperatingSystem != "Windows 10" || got[0].Location.CountryOrRegion != "AT" {
+		t.Errorf("GraphClient.ListSignInsForAppBetween()[0] = %v, want a failed sign-in with error code 50126", got[0])
+	}
+	if !got[1].IsSuccess() {
+		t.Errorf("GraphClient.ListSignInsForAppBetween()[1] = %v, want a successful sign-in", got[1])
+	}
+}
@@ -0,0 +1,229 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TermStore is the managed metadata term store of a SharePoint site
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/termstore-store
+type TermStore struct {
+	ID                 string   `json:"id"`
+	DefaultLanguageTag string   `json:"defaultLanguageTag"` // e.g. "en-US"
+	LanguageTags       []string `json:"languageTags"`       // languages terms can be labeled in
+}
+
+func (t TermStore) String() string {
+	return fmt.Sprintf("TermStore(ID: \"%v\", DefaultLanguageTag: \"%v\", LanguageTags: \"%v\")", t.ID, t.DefaultLanguageTag, t.LanguageTags)
+}
+
+// TermGroup is a group of term sets within a TermStore
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/termstore-group
+type TermGroup struct {
+	ID              string    `json:"id"`
+	DisplayName     string    `json:"displayName"`
+	Description     string    `json:"description"`
+	Scope           string    `json:"scope"` // global, system or siteCollection
+	CreatedDateTime time.Time `json:"createdDateTime"`
+}
+
+func (t TermGroup) String() string {
+	return fmt.Sprintf("TermGroup(ID: \"%v\", DisplayName: \"%v\", Description: \"%v\", Scope: \"%v\", CreatedDateTime: \"%v\")",
+		t.ID, t.DisplayName, t.Description, t.Scope, t.CreatedDateTime)
+}
+
+// TermSet is a set of hierarchical terms within a TermGroup
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/termstore-set
+type TermSet struct {
+	ID              string          `json:"id"`
+	LocalizedNames  []LocalizedName `json:"localizedNames"`
+	Description     string          `json:"description"`
+	CreatedDateTime time.Time       `json:"createdDateTime"`
+}
+
+func (t TermSet) String() string {
+	return fmt.Sprintf("TermSet(ID: \"%v\", LocalizedNames: \"%v\", Description: \"%v\", CreatedDateTime: \"%v\")",
+		t.ID, t.LocalizedNames, t.Description, t.CreatedDateTime)
+}
+
+// Term is a term of a TermSet, e.g. a tag used for content classification. Terms are hierarchical, Children are the
+// terms below this term.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/termstore-term
+type Term struct {
+	ID                   string                 `json:"id,omitempty"`
+	Labels               []LocalizedLabel       `json:"labels"`
+	Descriptions         []LocalizedDescription `json:"descriptions,omitempty"`
+	CreatedDateTime      time.Time              `json:"-"`
+	LastModifiedDateTime time.Time              `json:"-"`
+	Children             []Term                 `json:"-"` // loaded by ListTerms, not part of the json of msgraph
+}
+
+func (t Term) String() string {
+	return fmt.Sprintf("Term(ID: \"%v\", Labels: \"%v\", Descriptions: \"%v\", CreatedDateTime: \"%v\", LastModifiedDateTime: \"%v\", Children: %v)",
+		t.ID, t.Labels, t.Descriptions, t.CreatedDateTime, t.LastModifiedDateTime, len(t.Children))
+}
+
+// DefaultLabel returns the name of the default label of the term, the name of the first label if none is the default
+func (t Term) DefaultLabel() string {
+	for _, label := range t.Labels {
+		if label.IsDefault {
+			return label.Name
+		}
+	}
+	if len(t.Labels) > 0 {
+		return t.Labels[0].Name
+	}
+	return ""
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (t *Term) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ID                   string                 `json:"id"`
+		Labels               []LocalizedLabel       `json:"labels"`
+		Descriptions         []LocalizedDescription `json:"descriptions"`
+		CreatedDateTime      time.Time              `json:"createdDateTime"`
+		LastModifiedDateTime time.Time              `json:"lastModifiedDateTime"`
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("cannot UnmarshalJSON: %v | Data: %v", err, string(data))
+	}
+	t.ID = tmp.ID
+	t.Labels = tmp.Labels
+	t.Descriptions = tmp.Descriptions
+	t.CreatedDateTime = tmp.CreatedDateTime
+	t.LastModifiedDateTime = tmp.LastModifiedDateTime
+	return nil
+}
+
+// LocalizedLabel is the label of a Term in a language
+type LocalizedLabel struct {
+	Name        string `json:"name"`
+	LanguageTag string `json:"languageTag"`
+	IsDefault   bool   `json:"isDefault"`
+}
+
+// LocalizedDescription is the description of a Term in a language
+type LocalizedDescription struct {
+	Description string `json:"description"`
+	LanguageTag string `json:"languageTag"`
+}
+
+// LocalizedName is the name of a TermSet in a language
+type LocalizedName struct {
+	Name        string `json:"name"`
+	LanguageTag string `json:"languageTag"`
+}
+
+// GetTermStore returns the term store of the SharePoint site identified by siteID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/termstore-store-get
+func (g *GraphClient) GetTermStore(siteID string) (TermStore, error) {
+	return g.GetTermStoreContext(context.Background(), siteID)
+}
+
+// GetTermStoreContext is GetTermStore with a context.
+func (g *GraphClient) GetTermStoreContext(ctx context.Context, siteID string) (TermStore, error) {
+	var termStore TermStore
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/sites/%v/termStore", siteID), nil, &termStore)
+	return termStore, err
+}
+
+// ListTermGroups returns the term groups of the term store of the SharePoint site identified by siteID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/termstore-list-groups
+func (g *GraphClient) ListTermGroups(siteID string) ([]TermGroup, error) {
+	return g.ListTermGroupsContext(context.Background(), siteID)
+}
+
+// ListTermGroupsContext is ListTermGroups with a context.
+func (g *GraphClient) ListTermGroupsContext(ctx context.Context, siteID string) ([]TermGroup, error) {
+	var groups []TermGroup
+	err := g.makePagedGETAPICall(ctx, fmt.Sprintf("/sites/%v/termStore/groups", siteID), nil, func(value json.RawMessage) (bool, error) {
+		var page []TermGroup
+		err := json.Unmarshal(value, &page)
+		groups = append(groups, page...)
+		return true, err
+	})
+	return groups, err
+}
+
+// ListTermSets returns the term sets of the term group identified by groupID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/termstore-group-list-sets
+func (g *GraphClient) ListTermSets(siteID, groupID string) ([]TermSet, error) {
+	return g.ListTermSetsContext(context.Background(), siteID, groupID)
+}
+
+// ListTermSetsContext is ListTermSets with a context.
+func (g *GraphClient) ListTermSetsContext(ctx context.Context, siteID, groupID string) ([]TermSet, error) {
+	var sets []TermSet
+	err := g.makePagedGETAPICall(ctx, fmt.Sprintf("/sites/%v/termStore/groups/%v/sets", siteID, groupID), nil, func(value json.RawMessage) (bool, error) {
+		var page []TermSet
+		err := json.Unmarshal(value, &page)
+		sets = append(sets, page...)
+		return true, err
+	})
+	return sets, err
+}
+
+// ListTerms returns the terms of the term set identified by setID including their Children. As msgraph returns one
+// level of the hierarchy per API-call, the children of every term are requested separately.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/termstore-term-list-children
+func (g *GraphClient) ListTerms(siteID, groupID, setID string) ([]Term, error) {
+	return g.ListTermsContext(context.Background(), siteID, groupID, setID)
+}
+
+// ListTermsContext is ListTerms with a context.
+func (g *GraphClient) ListTermsContext(ctx context.Context, siteID, groupID, setID string) ([]Term, error) {
+	setResource := fmt.Sprintf("/sites/%v/termStore/groups/%v/sets/%v", siteID, groupID, setID)
+	return g.listTerms(ctx, setResource, setResource+"/children")
+}
+
+// listTerms returns the terms of the given resource and recursively their children, setResource is the term set
+// the terms belong to
+func (g *GraphClient) listTerms(ctx context.Context, setResource, resource string) ([]Term, error) {
+	var terms []Term
+	err := g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+		var page []Term
+		err := json.Unmarshal(value, &page)
+		terms = append(terms, page...)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range terms {
+		terms[i].Children, err = g.listTerms(ctx, setResource, fmt.Sprintf("%v/terms/%v/children", setResource, terms[i].ID))
+		if err != nil {
+			return nil, fmt.Errorf("cannot list children of term %v: %w", terms[i].ID, err)
+		}
+	}
+	return terms, nil
+}
+
+// CreateTerm creates the term at the top level of the term set identified by setID. At least one label of the term
+// must be set. The Children of term are not created.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/termstore-term-post
+func (g *GraphClient) CreateTerm(siteID, groupID, setID string, term Term) (Term, error) {
+	return g.CreateTermContext(context.Background(), siteID, groupID, setID, term)
+}
+
+// CreateTermContext is CreateTerm with a context.
+func (g *GraphClient) CreateTermContext(ctx context.Context, siteID, groupID, setID string, term Term) (Term, error) {
+	if len(term.Labels) == 0 {
+		return Term{}, fmt.Errorf("term has no labels")
+	}
+	body := Term{Labels: term.Labels, Descriptions: term.Descriptions}
+	var created Term
+	err := g.makePOSTAPICall(ctx, fmt.Sprintf("/sites/%v/termStore/groups/%v/sets/%v/children", siteID, groupID, setID), body, &created)
+	return created, err
+}
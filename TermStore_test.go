@@ -0,0 +1,58 @@
+package msgraph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_ListTerms(t *testing.T) {
+	const set = "/v1.0/sites/contoso.sharepoint.com/termStore/groups/grp1/sets/set1"
+	mux := http.NewServeMux()
+	mux.HandleFunc(set+"/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"id": "t1", "createdDateTime": "2021-03-04T05:06:07Z", "labels": [{"name": "Departments", "languageTag": "en-US", "isDefault": true}]},
+			{"id": "t2", "labels": [{"name": "Regions", "languageTag": "en-US", "isDefault": true}]}]}`)
+	})
+	mux.HandleFunc(set+"/terms/t1/children", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "t11", "labels": [{"name": "Finanzen", "languageTag": "de-DE"}, {"name": "Finance", "languageTag": "en-US", "isDefault": true}]}]}`)
+	})
+	mux.HandleFunc(set+"/terms/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": []}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	terms, err := g.ListTerms("contoso.sharepoint.com", "grp1", "set1")
+	if err != nil {
+		t.Fatalf("GraphClient.ListTerms() error = %v", err)
+	}
+	if len(terms) != 2 || terms[0].DefaultLabel() != "Departments" || terms[0].CreatedDateTime.Year() != 2021 {
+		t.Fatalf("GraphClient.ListTerms() = %v, want Departments and Regions", terms)
+	}
+	if len(terms[0].Children) != 1 || terms[0].Children[0].DefaultLabel() != "Finance" || len(terms[1].Children) != 0 {
+		t.Errorf("GraphClient.ListTerms() Children = %v and %v, want Finance below Departments", terms[0].Children, terms[1].Children)
+	}
+}
+
+func TestGraphClient_CreateTerm(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/sites/site1/termStore/groups/grp1/sets/set1/children", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if want := `{"labels":[{"name":"Marketing","languageTag":"en-US","isDefault":true}]}`; r.Method != http.MethodPost || string(body) != want {
+			t.Errorf("CreateTerm request = %v %s, want POST %v", r.Method, body, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "t3", "labels": [{"name": "Marketing", "languageTag": "en-US", "isDefault": true}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	term := Term{ID: "ignored", Labels: []LocalizedLabel{{Name: "Marketing", LanguageTag: "en-US", IsDefault: true}}}
+	got, err := g.CreateTerm("site1", "grp1", "set1", term)
+	if err != nil || got.ID != "t3" {
+		t.Errorf("GraphClient.CreateTerm() = %v, %v, want t3", got, err)
+	}
+	if _, err := g.CreateTerm("site1", "grp1", "set1", Term{}); err == nil {
+		t.Errorf("GraphClient.CreateTerm() without labels error = nil, want an error")
+	}
+}
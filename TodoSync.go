@@ -0,0 +1,236 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TodoConflictPolicy decides which change of a task is kept by TodoSyncSession.Sync if the task has been
+// changed both locally and in Microsoft To Do since the last sync
+type TodoConflictPolicy string
+
+// Conflict policies of TodoSyncSession
+const (
+	TodoConflictRemoteWins TodoConflictPolicy = "remoteWins" // the local change is dropped
+	TodoConflictLocalWins  TodoConflictPolicy = "localWins"  // the local change overwrites the remote change
+	TodoConflictCallback   TodoConflictPolicy = "callback"   // TodoSyncSession.Resolve merges both changes
+)
+
+// TodoSyncState is the state of a TodoSyncSession that has to be persisted between runs, it can be json-marshalled
+type TodoSyncState struct {
+	DeltaLinks     map[string]string    `json:"deltaLinks"`     // @odata.deltaLink of the last sync, keyed by the task list ID
+	HighWaterMarks map[string]time.Time `json:"highWaterMarks"` // lastModifiedDateTime of every task as of the last sync, keyed by the task ID, zero for tasks deleted by the session
+}
+
+// TodoTaskChange is a change of a task in the local application that is to be synced to Microsoft To Do. A task
+// without ID is created, a task with ID is updated or, if Deleted is set, deleted.
+type TodoTaskChange struct {
+	Task    TodoTask
+	Deleted bool
+}
+
+// TodoConflict is a task that has been changed both locally and in Microsoft To Do since the last sync
+type TodoConflict struct {
+	Local  TodoTaskChange
+	Remote TodoTask // Remote.Deleted is set if the task has been deleted in Microsoft To Do
+	Kept   TodoTask // the task as kept in Microsoft To Do after the conflict has been resolved, zero if it has been deleted
+}
+
+// TodoSyncResult is the result of TodoSyncSession.Sync
+type TodoSyncResult struct {
+	RemoteChanges []TodoTask       // changes of Microsoft To Do to apply locally, including remote winners of conflicts. Deleted tasks have Deleted set.
+	Applied       []TodoTask       // local changes as written to Microsoft To Do, including local winners and resolutions of conflicts
+	Conflicts     []TodoConflict   // every conflict and how it has been resolved
+	Pending       []TodoTaskChange // local changes that have not been applied because Sync failed, pass them to the next Sync
+}
+
+// TodoSyncSession synchronizes tasks between a local application and the task lists of a user in Microsoft To Do.
+// Remote changes are fetched with a delta query, conflicts are detected by comparing the lastModifiedDateTime of
+// a remotely changed task with its high-water mark, the lastModifiedDateTime the session recorded at the last sync.
+//
+// Persist State after every Sync and pass it to NewTodoSyncSession on the next run.
+type TodoSyncSession struct {
+	State   TodoSyncState
+	Policy  TodoConflictPolicy
+	Resolve func(local TodoTaskChange, remote TodoTask) TodoTask // merges a conflict if Policy is TodoConflictCallback
+
+	graphClient *GraphClient
+	identifier  string
+}
+
+// NewTodoSyncSession returns a TodoSyncSession for the task lists of the user identified by either the given ID or
+// userPrincipalName. Pass the zero TodoSyncState for the first run, the first Sync of a task list then returns all of
+// its tasks as RemoteChanges.
+func (g *GraphClient) NewTodoSyncSession(identifier string, state TodoSyncState, policy TodoConflictPolicy) *TodoSyncSession {
+	if state.DeltaLinks == nil {
+		state.DeltaLinks = map[string]string{}
+	}
+	if state.HighWaterMarks == nil {
+		state.HighWaterMarks = map[string]time.Time{}
+	}
+	return &TodoSyncSession{State: state, Policy: policy, graphClient: g, identifier: identifier}
+}
+
+// Sync fetches the changes of the task list identified by listID since the last sync and applies the localChanges,
+// resolving conflicts by the Policy of the session.
+//
+// If a local change cannot be applied, Sync returns the error together with the changes applied so far and the
+// Pending local changes. The State then records the tasks written so far, but not the remote changes, hence the
+// next Sync with the Pending changes does not write the applied changes again and returns the remote changes again.
+func (s *TodoSyncSession) Sync(listID string, localChanges []TodoTaskChange) (TodoSyncResult, error) {
+	return s.SyncContext(context.Background(), listID, localChanges)
+}
+
+// SyncContext is Sync with a context.
+func (s *TodoSyncSession) SyncContext(ctx context.Context, listID string, localChanges []TodoTaskChange) (TodoSyncResult, error) {
+	var result TodoSyncResult
+	if s.Policy == TodoConflictCallback && s.Resolve == nil {
+		return result, fmt.Errorf("conflict policy %v requires Resolve", s.Policy)
+	}
+	if s.Policy != TodoConflictRemoteWins && s.Policy != TodoConflictLocalWins && s.Policy != TodoConflictCallback {
+		return result, fmt.Errorf("invalid TodoConflictPolicy %q", s.Policy)
+	}
+	if err := s.graphClient.checkUserIdentifier(s.identifier); err != nil {
+		return result, err
+	}
+
+	deltaLink := s.State.DeltaLinks[listID]
+	if deltaLink == "" {
+		deltaLink = fmt.Sprintf("/users/%v/todo/lists/%v/tasks/delta", s.identifier, listID)
+	}
+	var remoteChanges []TodoTask
+	deltaLink, err := s.graphClient.makeDeltaGETAPICall(ctx, deltaLink, func(value json.RawMessage) error {
+		var page []TodoTask
+		err := json.Unmarshal(value, &page)
+		remoteChanges = append(remoteChanges, page...)
+		return err
+	})
+	if err != nil {
+		return result, fmt.Errorf("cannot get the changes of task list %v: %w", listID, err)
+	}
+
+	// changes of tasks the session wrote itself come back with the recorded high-water mark, they are no remote changes
+	highWaterMarks := make(map[string]time.Time, len(s.State.HighWaterMarks))
+	for id, mark := range s.State.HighWaterMarks {
+		highWaterMarks[id] = mark
+	}
+	remote := map[string]TodoTask{}
+	var remoteOrder, confirmedDeletions []string
+	for _, task := range remoteChanges {
+		mark, ok := highWaterMarks[task.ID]
+		if ok && task.Deleted && mark.IsZero() {
+			delete(highWaterMarks, task.ID)
+			confirmedDeletions = append(confirmedDeletions, task.ID)
+			continue
+		}
+		if ok && !task.Deleted && !task.LastModifiedDateTime.After(mark) {
+			continue
+		}
+		if _, ok := remote[task.ID]; !ok {
+			remoteOrder = append(remoteOrder, task.ID)
+		}
+		remote[task.ID] = task
+	}
+
+	// fail records the tasks written so far, the delta query is repeated by the next Sync
+	fail := func(pending []TodoTaskChange, err error) (TodoSyncResult, error) {
+		for _, id := range confirmedDeletions {
+			if _, ok := highWaterMarks[id]; !ok {
+				highWaterMarks[id] = time.Time{}
+			}
+		}
+		s.State.HighWaterMarks = highWaterMarks
+		return TodoSyncResult{Applied: result.Applied, Conflicts: result.Conflicts, Pending: pending}, err
+	}
+
+	for i, change := range localChanges {
+		remoteTask, conflict := remote[change.Task.ID]
+		if change.Task.ID == "" || !conflict {
+			applied, err := s.apply(ctx, listID, change, false, highWaterMarks)
+			if err != nil {
+				return fail(localChanges[i:], err)
+			}
+			if !change.Deleted {
+				result.Applied = append(result.Applied, applied)
+			}
+			continue
+		}
+
+		resolved := TodoConflict{Local: change, Remote: remoteTask}
+		switch s.Policy {
+		case TodoConflictRemoteWins:
+			resolved.Kept = remoteTask
+			if remoteTask.Deleted {
+				resolved.Kept = TodoTask{}
+			}
+			result.Conflicts = append(result.Conflicts, resolved)
+			continue // the remote change stays in remote and is returned in RemoteChanges
+		case TodoConflictLocalWins:
+		case TodoConflictCallback:
+			change = TodoTaskChange{Task: s.Resolve(change, remoteTask)}
+			change.Task.ID = remoteTask.ID
+		}
+		delete(remote, remoteTask.ID)
+		applied, err := s.apply(ctx, listID, change, remoteTask.Deleted, highWaterMarks)
+		if err != nil {
+			return fail(localChanges[i:], err)
+		}
+		if !change.Deleted {
+			resolved.Kept = applied
+			result.Applied = append(result.Applied, applied)
+		}
+		result.Conflicts = append(result.Conflicts, resolved)
+	}
+
+	for _, id := range remoteOrder {
+		task, ok := remote[id]
+		if !ok {
+			continue
+		}
+		if task.Deleted {
+			delete(highWaterMarks, id)
+		} else {
+			highWaterMarks[id] = task.LastModifiedDateTime
+		}
+		result.RemoteChanges = append(result.RemoteChanges, task)
+	}
+
+	s.State.HighWaterMarks = highWaterMarks
+	s.State.DeltaLinks[listID] = deltaLink
+	return result, nil
+}
+
+// apply writes the local change to the task list and records the high-water mark of the written task. If the task
+// has been deleted remotely, an update re-creates it with a new ID.
+func (s *TodoSyncSession) apply(ctx context.Context, listID string, change TodoTaskChange, remoteDeleted bool, highWaterMarks map[string]time.Time) (TodoTask, error) {
+	var written TodoTask
+	var err error
+	switch {
+	case change.Deleted && remoteDeleted:
+	case change.Deleted:
+		err = s.graphClient.DeleteTodoTaskContext(ctx, s.identifier, listID, change.Task.ID)
+		if hasStatusCode(err, http.StatusNotFound) {
+			err = nil
+		}
+	case change.Task.ID == "" || remoteDeleted:
+		written, err = s.graphClient.CreateTodoTaskContext(ctx, s.identifier, listID, change.Task)
+	default:
+		written, err = s.graphClient.UpdateTodoTaskContext(ctx, s.identifier, listID, change.Task)
+	}
+	if err != nil {
+		return TodoTask{}, fmt.Errorf("cannot sync task %v (%v): %w", change.Task.ID, change.Task.Title, err)
+	}
+	switch {
+	case remoteDeleted:
+		delete(highWaterMarks, change.Task.ID)
+	case change.Deleted:
+		highWaterMarks[change.Task.ID] = time.Time{} // the deletion comes back from the next delta query
+	}
+	if written.ID != "" {
+		highWaterMarks[written.ID] = written.LastModifiedDateTime
+	}
+	return written, nil
+}
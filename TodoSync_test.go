@@ -0,0 +1,189 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// todoSyncTestServer serves a single task list L1 of user u1. The delta query returns the tasks in delta, every
+// write returns the written task with lastModifiedDateTime writeTime.
+type todoSyncTestServer struct {
+	t         *testing.T
+	delta     []string // json of the tasks returned by the next delta query
+	writes    []string // method, task ID and title of every write
+	failTitle string   // writes of a task with this title fail
+}
+
+var todoSyncWriteTime = time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC)
+
+func (s *todoSyncTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const list = "/v1.0/users/u1/todo/lists/L1/tasks"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == list+"/delta":
+		token := r.URL.Query().Get("$deltatoken")
+		fmt.Fprintf(w, `{"@odata.deltaLink": "https://graph.microsoft.com%v/delta?$deltatoken=%v1", "value": [%v]}`, list, token, strings.Join(s.delta, ","))
+		s.delta = nil
+	case r.Method == http.MethodPatch || r.Method == http.MethodPost:
+		var task struct {
+			Title string `json:"title"`
+		}
+		json.NewDecoder(r.Body).Decode(&task)
+		if task.Title == s.failTitle {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": "invalidRequest", "message": "Invalid request."}}`)
+			return
+		}
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, list), "/")
+		if id == "" {
+			id = "created"
+		}
+		s.writes = append(s.writes, fmt.Sprintf("%v %v %v", r.Method, id, task.Title))
+		fmt.Fprintf(w, `{"id": "%v", "title": "%v", "lastModifiedDateTime": "%v"}`, id, task.Title, todoSyncWriteTime.Format(time.RFC3339))
+	case r.Method == http.MethodDelete:
+		s.writes = append(s.writes, fmt.Sprintf("%v %v", r.Method, strings.TrimPrefix(r.URL.Path, list+"/")))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.t.Errorf("unexpected request %v %v", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestTodoSyncSession_Sync(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           TodoConflictPolicy
+		wantWrites       []string
+		wantRemoteTitles []string
+		wantKeptTitle    string
+	}{
+		{name: "remote wins", policy: TodoConflictRemoteWins,
+			wantWrites: nil, wantRemoteTitles: []string{"Replace fuse (remote)"}, wantKeptTitle: "Replace fuse (remote)"},
+		{name: "local wins", policy: TodoConflictLocalWins,
+			wantWrites: []string{"PATCH t1 Replace fuse (local)"}, wantRemoteTitles: nil, wantKeptTitle: "Replace fuse (local)"},
+		{name: "callback", policy: TodoConflictCallback,
+			wantWrites: []string{"PATCH t1 Replace fuse (local) + Replace fuse (remote)"}, wantRemoteTitles: nil, wantKeptTitle: "Replace fuse (local) + Replace fuse (remote)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &todoSyncTestServer{t: t}
+			g := newTestGraphClient(t, server)
+
+			// first run: all tasks are remote changes
+			server.delta = []string{
+				`{"id": "t1", "title": "Replace fuse", "status": "notStarted", "lastModifiedDateTime": "2021-03-04T10:00:00Z"}`,
+				`{"id": "t2", "title": "Order cable", "status": "notStarted", "lastModifiedDateTime": "2021-03-04T10:00:00Z"}`,
+			}
+			resolve := func(local TodoTaskChange, remote TodoTask) TodoTask {
+				merged := local.Task
+				merged.Title = local.Task.Title + " + " + remote.Title
+				return merged
+			}
+			session := g.NewTodoSyncSession("u1", TodoSyncState{}, tt.policy)
+			session.Resolve = resolve
+			result, err := session.Sync("L1", nil)
+			if err != nil {
+				t.Fatalf("TodoSyncSession.Sync() error = %v", err)
+			}
+			if len(result.RemoteChanges) != 2 || len(result.Conflicts) != 0 {
+				t.Fatalf("TodoSyncSession.Sync() of the first run = %+v, want 2 remote changes", result)
+			}
+
+			// persist and restore the state, as between two runs
+			state, err := json.Marshal(session.State)
+			if err != nil {
+				t.Fatalf("cannot json.Marshal TodoSyncState: %v", err)
+			}
+			var restored TodoSyncState
+			if err := json.Unmarshal(state, &restored); err != nil {
+				t.Fatalf("cannot json.Unmarshal TodoSyncState: %v", err)
+			}
+			session = g.NewTodoSyncSession("u1", restored, tt.policy)
+			session.Resolve = resolve
+
+			// second run: t1 has been edited remotely and locally, t2 locally only, a new task is created locally
+			server.delta = []string{`{"id": "t1", "title": "Replace fuse (remote)", "status": "inProgress", "lastModifiedDateTime": "2021-03-04T11:00:00Z"}`}
+			result, err = session.Sync("L1", []TodoTaskChange{
+				{Task: TodoTask{ID: "t1", Title: "Replace fuse (local)"}},
+				{Task: TodoTask{ID: "t2", Title: "Order cable"}, Deleted: true},
+				{Task: TodoTask{Title: "Inspect panel"}},
+			})
+			if err != nil {
+				t.Fatalf("TodoSyncSession.Sync() error = %v", err)
+			}
+			wantWrites := append(tt.wantWrites, "DELETE t2", "POST created Inspect panel")
+			if fmt.Sprint(server.writes) != fmt.Sprint(wantWrites) {
+				t.Errorf("TodoSyncSession.Sync() writes = %v, want %v", server.writes, wantWrites)
+			}
+			var remoteTitles []string
+			for _, task := range result.RemoteChanges {
+				remoteTitles = append(remoteTitles, task.Title)
+			}
+			if fmt.Sprint(remoteTitles) != fmt.Sprint(tt.wantRemoteTitles) {
+				t.Errorf("TodoSyncSession.Sync() RemoteChanges = %v, want %v", remoteTitles, tt.wantRemoteTitles)
+			}
+			if len(result.Conflicts) != 1 || result.Conflicts[0].Kept.Title != tt.wantKeptTitle {
+				t.Errorf("TodoSyncSession.Sync() Conflicts = %+v, want t1 kept as %v", result.Conflicts, tt.wantKeptTitle)
+			}
+
+			// third run: the writes of the session come back from the delta query and are no remote changes
+			server.writes = nil
+			server.delta = []string{
+				fmt.Sprintf(`{"id": "created", "title": "Inspect panel", "lastModifiedDateTime": "%v"}`, todoSyncWriteTime.Format(time.RFC3339)),
+				`{"id": "t2", "@removed": {"reason": "deleted"}}`,
+			}
+			if tt.policy != TodoConflictRemoteWins {
+				server.delta = append(server.delta, fmt.Sprintf(`{"id": "t1", "title": "%v", "lastModifiedDateTime": "%v"}`, tt.wantKeptTitle, todoSyncWriteTime.Format(time.RFC3339)))
+			}
+			result, err = session.Sync("L1", nil)
+			if err != nil {
+				t.Fatalf("TodoSyncSession.Sync() error = %v", err)
+			}
+			if len(result.RemoteChanges) != 0 {
+				t.Errorf("TodoSyncSession.Sync() of the echoed writes RemoteChanges = %v, want none", result.RemoteChanges)
+			}
+			if _, ok := session.State.HighWaterMarks["t2"]; ok {
+				t.Errorf("TodoSyncSession.State.HighWaterMarks = %v, want no mark of the deleted t2", session.State.HighWaterMarks)
+			}
+			if !strings.HasSuffix(session.State.DeltaLinks["L1"], "$deltatoken=111") {
+				t.Errorf("TodoSyncSession.State.DeltaLinks = %v, want the deltaLink of the third run", session.State.DeltaLinks)
+			}
+		})
+	}
+}
+
+func TestTodoSyncSession_Sync_retry(t *testing.T) {
+	server := &todoSyncTestServer{t: t, failTitle: "Order cable"}
+	g := newTestGraphClient(t, server)
+	session := g.NewTodoSyncSession("u1", TodoSyncState{}, TodoConflictRemoteWins)
+
+	server.delta = []string{`{"id": "t1", "title": "Replace fuse", "lastModifiedDateTime": "2021-03-04T10:00:00Z"}`}
+	localChanges := []TodoTaskChange{{Task: TodoTask{Title: "Inspect panel"}}, {Task: TodoTask{Title: "Order cable"}}}
+	result, err := session.Sync("L1", localChanges)
+	if err == nil || len(result.Applied) != 1 || len(result.Pending) != 1 || result.Pending[0].Task.Title != "Order cable" {
+		t.Fatalf("TodoSyncSession.Sync() = %+v, %v, want an error with Inspect panel applied and Order cable pending", result, err)
+	}
+	if session.State.DeltaLinks["L1"] != "" {
+		t.Errorf("TodoSyncSession.State.DeltaLinks = %v, want none after a failed Sync", session.State.DeltaLinks)
+	}
+
+	// the retry writes only the pending change and returns the remote changes again
+	server.writes, server.failTitle = nil, ""
+	server.delta = []string{
+		`{"id": "t1", "title": "Replace fuse", "lastModifiedDateTime": "2021-03-04T10:00:00Z"}`,
+		fmt.Sprintf(`{"id": "created", "title": "Inspect panel", "lastModifiedDateTime": "%v"}`, todoSyncWriteTime.Format(time.RFC3339)),
+	}
+	result, err = session.Sync("L1", result.Pending)
+	if err != nil {
+		t.Fatalf("TodoSyncSession.Sync() retry error = %v", err)
+	}
+	if fmt.Sprint(server.writes) != "[POST created Order cable]" {
+		t.Errorf("TodoSyncSession.Sync() retry writes = %v, want only the pending change", server.writes)
+	}
+	if len(result.RemoteChanges) != 1 || result.RemoteChanges[0].ID != "t1" {
+		t.Errorf("TodoSyncSession.Sync() retry RemoteChanges = %v, want t1 only", result.RemoteChanges)
+	}
+}
@@ -0,0 +1,223 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TodoTaskStatus is the status of a TodoTask
+type TodoTaskStatus string
+
+// Task statuses as returned by msgraph in status
+const (
+	TodoTaskStatusNotStarted      TodoTaskStatus = "notStarted"
+	TodoTaskStatusInProgress      TodoTaskStatus = "inProgress"
+	TodoTaskStatusCompleted       TodoTaskStatus = "completed"
+	TodoTaskStatusWaitingOnOthers TodoTaskStatus = "waitingOnOthers"
+	TodoTaskStatusDeferred        TodoTaskStatus = "deferred"
+)
+
+// IsValid returns true if s is one of the TodoTaskStatus constants
+func (s TodoTaskStatus) IsValid() bool {
+	switch s {
+	case TodoTaskStatusNotStarted, TodoTaskStatusInProgress, TodoTaskStatusCompleted, TodoTaskStatusWaitingOnOthers, TodoTaskStatusDeferred:
+		return true
+	}
+	return false
+}
+
+// TodoTaskList is a list of tasks in Microsoft To Do
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/todotasklist
+type TodoTaskList struct {
+	ID                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	IsOwner           bool   `json:"isOwner"`
+	IsShared          bool   `json:"isShared"`
+	WellknownListName string `json:"wellknownListName"` // e.g. "defaultList", "none" for lists created by users
+}
+
+func (l TodoTaskList) String() string {
+	return fmt.Sprintf("TodoTaskList(ID: \"%v\", DisplayName: \"%v\", IsOwner: \"%v\", IsShared: \"%v\", WellknownListName: \"%v\")",
+		l.ID, l.DisplayName, l.IsOwner, l.IsShared, l.WellknownListName)
+}
+
+// TodoTask is a task in a TodoTaskList of Microsoft To Do. Only Title, Body, Status, Importance and DueDateTime are
+// written by CreateTodoTask and UpdateTodoTask, the other fields are read-only.
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/todotask
+type TodoTask struct {
+	ID                   string
+	Title                string
+	Body                 MsgBody
+	Status               TodoTaskStatus
+	Importance           Importance
+	DueDateTime          time.Time // zero if the task has no due date
+	CreatedDateTime      time.Time
+	LastModifiedDateTime time.Time
+	Deleted              bool // set for tasks returned by a delta query that have been deleted, only ID is set then
+}
+
+func (t TodoTask) String() string {
+	return fmt.Sprintf("TodoTask(ID: \"%v\", Title: \"%v\", Status: \"%v\", Importance: \"%v\", DueDateTime: \"%v\", "+
+		"CreatedDateTime: \"%v\", LastModifiedDateTime: \"%v\", Deleted: \"%v\")",
+		t.ID, t.Title, t.Status, t.Importance, t.DueDateTime, t.CreatedDateTime, t.LastModifiedDateTime, t.Deleted)
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (t *TodoTask) UnmarshalJSON(data []byte) error {
+	tmp := struct {
+		ID          string         `json:"id"`
+		Title       string         `json:"title"`
+		Body        MsgBody        `json:"body"`
+		Status      TodoTaskStatus `json:"status"`
+		Importance  Importance     `json:"importance"`
+		DueDateTime *struct {
+			DateTime string `json:"dateTime"`
+			TimeZone string `json:"timeZone"`
+		} `json:"dueDateTime"`
+		CreatedDateTime      time.Time        `json:"createdDateTime"`
+		LastModifiedDateTime time.Time        `json:"lastModifiedDateTime"`
+		Removed              *json.RawMessage `json:"@removed"`
+	}{}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return fmt.Errorf("cannot UnmarshalJSON: %v | Data: %v", err, string(data))
+	}
+
+	*t = TodoTask{
+		ID:                   tmp.ID,
+		Title:                tmp.Title,
+		Body:                 tmp.Body,
+		Status:               tmp.Status,
+		Importance:           tmp.Importance,
+		CreatedDateTime:      tmp.CreatedDateTime,
+		LastModifiedDateTime: tmp.LastModifiedDateTime,
+		Deleted:              tmp.Removed != nil,
+	}
+	if tmp.DueDateTime != nil && tmp.DueDateTime.DateTime != "" {
+		var err error
+		t.DueDateTime, err = parseTimeAndLocation(tmp.DueDateTime.DateTime, tmp.DueDateTime.TimeZone)
+		if err != nil {
+			return fmt.Errorf("cannot parse dueDateTime %v AND timeZone %v: %v", tmp.DueDateTime.DateTime, tmp.DueDateTime.TimeZone, err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, only the writable fields are included
+func (t TodoTask) MarshalJSON() ([]byte, error) {
+	type dateTimeTimeZone struct {
+		DateTime string `json:"dateTime"`
+		TimeZone string `json:"timeZone"`
+	}
+	tmp := struct {
+		Title       string            `json:"title"`
+		Body        *MsgBody          `json:"body,omitempty"`
+		Status      TodoTaskStatus    `json:"status,omitempty"`
+		Importance  Importance        `json:"importance,omitempty"`
+		DueDateTime *dateTimeTimeZone `json:"dueDateTime,omitempty"`
+	}{Title: t.Title, Status: t.Status, Importance: t.Importance}
+	if t.Body.Content != "" {
+		tmp.Body = &t.Body
+	}
+	if !t.DueDateTime.IsZero() {
+		tmp.DueDateTime = &dateTimeTimeZone{DateTime: t.DueDateTime.UTC().Format("2006-01-02T15:04:05"), TimeZone: "UTC"}
+	}
+	return json.Marshal(tmp)
+}
+
+// ListTodoTaskLists returns the task lists of the user identified by either the given ID or userPrincipalName
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/todo-list-lists
+func (g *GraphClient) ListTodoTaskLists(identifier string) ([]TodoTaskList, error) {
+	return g.ListTodoTaskListsContext(context.Background(), identifier)
+}
+
+// ListTodoTaskListsContext is ListTodoTaskLists with a context.
+func (g *GraphClient) ListTodoTaskListsContext(ctx context.Context, identifier string) ([]TodoTaskList, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	var lists []TodoTaskList
+	err := g.makePagedGETAPICall(ctx, fmt.Sprintf("/users/%v/todo/lists", identifier), nil, func(value json.RawMessage) (bool, error) {
+		var page []TodoTaskList
+		err := json.Unmarshal(value, &page)
+		lists = append(lists, page...)
+		return true, err
+	})
+	return lists, err
+}
+
+// ListTodoTasks returns the tasks of the task list identified by listID of the user identified by either the
+// given ID or userPrincipalName
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/todotasklist-list-tasks
+func (g *GraphClient) ListTodoTasks(identifier, listID string) ([]TodoTask, error) {
+	return g.ListTodoTasksContext(context.Background(), identifier, listID)
+}
+
+// ListTodoTasksContext is ListTodoTasks with a context.
+func (g *GraphClient) ListTodoTasksContext(ctx context.Context, identifier, listID string) ([]TodoTask, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	var tasks []TodoTask
+	err := g.makePagedGETAPICall(ctx, fmt.Sprintf("/users/%v/todo/lists/%v/tasks", identifier, listID), nil, func(value json.RawMessage) (bool, error) {
+		var page []TodoTask
+		err := json.Unmarshal(value, &page)
+		tasks = append(tasks, page...)
+		return true, err
+	})
+	return tasks, err
+}
+
+// CreateTodoTask creates the task in the task list identified by listID and returns it as created by msgraph
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/todotasklist-post-tasks
+func (g *GraphClient) CreateTodoTask(identifier, listID string, task TodoTask) (TodoTask, error) {
+	return g.CreateTodoTaskContext(context.Background(), identifier, listID, task)
+}
+
+// CreateTodoTaskContext is CreateTodoTask with a context.
+func (g *GraphClient) CreateTodoTaskContext(ctx context.Context, identifier, listID string, task TodoTask) (TodoTask, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return TodoTask{}, err
+	}
+	var created TodoTask
+	err := g.makePOSTAPICall(ctx, fmt.Sprintf("/users/%v/todo/lists/%v/tasks", identifier, listID), task, &created)
+	return created, err
+}
+
+// UpdateTodoTask updates the writable fields of the task identified by task.ID and returns it as updated by msgraph
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/todotask-update
+func (g *GraphClient) UpdateTodoTask(identifier, listID string, task TodoTask) (TodoTask, error) {
+	return g.UpdateTodoTaskContext(context.Background(), identifier, listID, task)
+}
+
+// UpdateTodoTaskContext is UpdateTodoTask with a context.
+func (g *GraphClient) UpdateTodoTaskContext(ctx context.Context, identifier, listID string, task TodoTask) (TodoTask, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return TodoTask{}, err
+	}
+	var updated TodoTask
+	err := g.makePATCHAPICall(ctx, fmt.Sprintf("/users/%v/todo/lists/%v/tasks/%v", identifier, listID, task.ID), task, &updated)
+	return updated, err
+}
+
+// DeleteTodoTask deletes the task identified by taskID from the task list identified by listID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/todotask-delete
+func (g *GraphClient) DeleteTodoTask(identifier, listID, taskID string) error {
+	return g.DeleteTodoTaskContext(context.Background(), identifier, listID, taskID)
+}
+
+// DeleteTodoTaskContext is DeleteTodoTask with a context.
+func (g *GraphClient) DeleteTodoTaskContext(ctx context.Context, identifier, listID, taskID string) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	return g.makeDELETEAPICall(ctx, fmt.Sprintf("/users/%v/todo/lists/%v/tasks/%v", identifier, listID, taskID))
+}
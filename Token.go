@@ -1,8 +1,11 @@
 package msgraph
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,6 +16,8 @@ type Token struct {
 	ExpiresOn   time.Time // time when the access token expires
 	Resource    string    // will most likely always be https://graph.microsoft.com, hence the BaseURL
 	AccessToken string    // the access-token itself
+
+	refreshToken string // acquires the next token of a delegated flow, see NewGraphClientWithDeviceCode
 }
 
 func (t Token) String() string {
@@ -26,6 +31,62 @@ func (t Token) GetAccessToken() string {
 	return fmt.Sprintf("%v %v", t.TokenType, t.AccessToken)
 }
 
+// Roles returns the application permissions (roles claim) granted to the AccessToken, e.g. "User.Read.All".
+// Returns nil if the AccessToken cannot be decoded or has no roles claim, e.g. a token of a delegated flow.
+func (t Token) Roles() []string {
+	var claims struct {
+		Roles []string `json:"roles"`
+	}
+	if t.decodeClaims(&claims) != nil {
+		return nil
+	}
+	return claims.Roles
+}
+
+// Scopes returns the delegated permissions (scp claim) granted to the AccessToken, e.g. "Mail.Send".
+// Returns nil if the AccessToken cannot be decoded or has no scp claim, e.g. a token of the client credentials flow.
+func (t Token) Scopes() []string {
+	var claims struct {
+		Scopes string `json:"scp"` // space separated
+	}
+	if t.decodeClaims(&claims) != nil || claims.Scopes == "" {
+		return nil
+	}
+	return strings.Fields(claims.Scopes)
+}
+
+// isApplicationToken returns true if the AccessToken is a token of an application without signed-in user, i.e. its
+// idtyp claim is "app" or, without idtyp claim, it has roles but no scp claim. Returns false for delegated tokens and
+// tokens that cannot be decoded, e.g. opaque tokens of a TokenProvider, as their kind is unknown.
+func (t Token) isApplicationToken() bool {
+	var claims struct {
+		IDType string   `json:"idtyp"`
+		Roles  []string `json:"roles"`
+		Scopes string   `json:"scp"`
+	}
+	if t.decodeClaims(&claims) != nil {
+		return false
+	}
+	if claims.IDType != "" {
+		return claims.IDType == "app"
+	}
+	return len(claims.Roles) > 0 && claims.Scopes == ""
+}
+
+// decodeClaims json-unmarshals the claims (the payload) of the AccessToken, which is a JWT, into v.
+// The signature of the token is not verified, msgraph does that.
+func (t Token) decodeClaims(v interface{}) error {
+	parts := strings.Split(t.AccessToken, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("access token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return fmt.Errorf("cannot base64 decode the claims of the access token: %v", err)
+	}
+	return json.Unmarshal(payload, v)
+}
+
 // IsValid returns true if the token is already valid and is still valid. Otherwise false.
 //
 // Hint: this is a wrapper for >>token.IsAlreadyValid() && token.IsStillValid()<<
@@ -56,11 +117,20 @@ func (t Token) HasExpired() bool {
 	return !t.IsStillValid()
 }
 
+// defaultTokenRefreshMargin is how long before ExpiresOn a token wants to be refreshed, see WithTokenRefreshMargin
+const defaultTokenRefreshMargin = 10 * time.Second
+
 // WantsToBeRefreshed returns true if the token is already invalid or close to
 // expire (10 second before ExpiresOn), otherwise false. time.Now() is used to
 // determine the current time.
 func (t Token) WantsToBeRefreshed() bool {
-	return !t.IsValid() || time.Now().After(t.ExpiresOn.Add(-10*time.Second))
+	return t.ExpiresWithin(defaultTokenRefreshMargin)
+}
+
+// ExpiresWithin returns true if the token is already invalid or expires within
+// the given margin, otherwise false. time.Now() is used to determine the current time.
+func (t Token) ExpiresWithin(margin time.Duration) bool {
+	return !t.IsValid() || time.Now().After(t.ExpiresOn.Add(-margin))
 }
 
 // UnmarshalJSON implements the json unmarshal to be used by the json-library.
@@ -69,12 +139,13 @@ func (t Token) WantsToBeRefreshed() bool {
 // the current time.Now() is after NotBefore and before ExpiresOn
 func (t *Token) UnmarshalJSON(data []byte) error {
 	tmp := struct {
-		TokenType   string `json:"token_type"`        // should normally be "Bearer"
-		ExpiresOn   int64  `json:"expires_on,string"` // = UNIX timestamp, parse to int64 immediately
-		NotBefore   int64  `json:"not_before,string"` // = UNIX timestamp, parse to int64 immediately
-		Resource    string `json:"resource"`          // will typically be https://graph.microsoft.com or wherever it came from
-		AccessToken string `json:"access_token"`      // the actual access token - veeery long string
-		//ExpiresIn   string `json:"expires_in"` // not used
+		TokenType    string          `json:"token_type"`        // should normally be "Bearer"
+		ExpiresOn    int64           `json:"expires_on,string"` // = UNIX timestamp, parse to int64 immediately
+		NotBefore    int64           `json:"not_before,string"` // = UNIX timestamp, parse to int64 immediately
+		Resource     string          `json:"resource"`          // will typically be https://graph.microsoft.com or wherever it came from
+		AccessToken  string          `json:"access_token"`      // the actual access token - veeery long string
+		ExpiresIn    json.RawMessage `json:"expires_in"`        // seconds, the only expiry of a v2.0 token. A number or a string
+		RefreshToken string          `json:"refresh_token"`     // only returned by delegated flows with the offline_access scope
 	}{}
 
 	// unmarshal to tmp-struct, return if error
@@ -82,11 +153,20 @@ func (t *Token) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("err on json.Unmarshal: %v | Data: %v", err, string(data))
 	}
 
+	if tmp.ExpiresOn == 0 && len(tmp.ExpiresIn) > 0 {
+		expiresIn, err := strconv.ParseInt(strings.Trim(string(tmp.ExpiresIn), `"`), 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot parse expires_in %s: %v", tmp.ExpiresIn, err)
+		}
+		tmp.ExpiresOn = time.Now().Unix() + expiresIn
+	}
+
 	t.TokenType = tmp.TokenType
 	t.ExpiresOn = time.Unix(tmp.ExpiresOn, 0)
 	t.NotBefore = time.Unix(tmp.NotBefore, 0)
 	t.Resource = tmp.Resource
 	t.AccessToken = tmp.AccessToken
+	t.refreshToken = tmp.RefreshToken
 
 	if t.HasExpired() {
 		return fmt.Errorf("Access-Token ExpiresOn %v is before current system-time %v", t.ExpiresOn, time.Now())
@@ -0,0 +1,70 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TokenProvider acquires the tokens of a GraphClient instead of its built-in flows, e.g. to use the credentials of
+// azidentity. GetToken is called whenever the current token WantsToBeRefreshed, the API-calls of the GraphClient wait
+// for it. The returned Token must be valid, a missing TokenType defaults to "Bearer".
+type TokenProvider interface {
+	GetToken(ctx context.Context) (Token, error)
+}
+
+// TokenProviderFunc is a func that implements TokenProvider
+type TokenProviderFunc func(ctx context.Context) (Token, error)
+
+// GetToken calls f(ctx)
+func (f TokenProviderFunc) GetToken(ctx context.Context) (Token, error) {
+	return f(ctx)
+}
+
+// NewGraphClientWithTokenProvider creates a new GraphClient instance that acquires its tokens from the given provider.
+// The opts are applied as for NewGraphClient, options that configure the built-in flows, e.g. WithTokenScopes, have
+// no effect.
+func NewGraphClientWithTokenProvider(provider TokenProvider, opts ...ClientOption) (*GraphClient, error) {
+	withTokenProvider := func(g *GraphClient) error {
+		if provider == nil {
+			return fmt.Errorf("token provider must not be nil")
+		}
+		g.tokenProvider = provider
+		return nil
+	}
+	return NewGraphClient("", "", "", append([]ClientOption{withTokenProvider}, opts...)...)
+}
+
+// NewGraphClientWithToken creates a new GraphClient instance that uses the given, externally acquired access token
+// until expiresOn, e.g. of the Azure CLI. The token is never refreshed, the API-calls return ErrTokenExpired after
+// it has expired. The opts are applied as for NewGraphClient.
+func NewGraphClientWithToken(accessToken string, expiresOn time.Time, opts ...ClientOption) (*GraphClient, error) {
+	return NewGraphClientWithTokenProvider(staticToken{TokenType: "Bearer", AccessToken: accessToken, ExpiresOn: expiresOn}, opts...)
+}
+
+// staticToken is a TokenProvider that returns the same token until it has expired
+type staticToken Token
+
+// GetToken returns the token or ErrTokenExpired
+func (t staticToken) GetToken(ctx context.Context) (Token, error) {
+	if Token(t).HasExpired() {
+		return Token{}, ErrTokenExpired
+	}
+	return Token(t), nil
+}
+
+// refreshProvidedToken replaces the current token with the one of the TokenProvider of g
+func (g *GraphClient) refreshProvidedToken(ctx context.Context) error {
+	newToken, err := g.tokenProvider.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("error on getting msgraph Token from the token provider: %w", err)
+	}
+	if newToken.TokenType == "" {
+		newToken.TokenType = "Bearer"
+	}
+	if !newToken.IsValid() {
+		return fmt.Errorf("token provider returned an invalid Token, ExpiresOn %v", newToken.ExpiresOn)
+	}
+	g.token = newToken
+	return nil
+}
@@ -0,0 +1,68 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewGraphClientWithTokenProvider(t *testing.T) {
+	var calls int
+	provider := TokenProviderFunc(func(ctx context.Context) (Token, error) {
+		calls++
+		return Token{AccessToken: fmt.Sprintf("token%v", calls), ExpiresOn: time.Now().Add(5 * time.Second)}, nil
+	})
+	var authorization string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClientWithTokenProvider(provider)
+	if err != nil || calls != 1 {
+		t.Fatalf("NewGraphClientWithTokenProvider() error = %v after %v calls, want 1", err, calls)
+	}
+	// the token expires within 10 seconds, hence it is refreshed before the API-call
+	if _, err := g.GetUser("u1"); err != nil || calls != 2 || authorization != "Bearer token2" {
+		t.Errorf("GraphClient.GetUser() error = %v with Authorization %q after %v calls, want Bearer token2 after 2", err, authorization, calls)
+	}
+
+	errProvider := errors.New("no credentials")
+	_, err = NewGraphClientWithTokenProvider(TokenProviderFunc(func(ctx context.Context) (Token, error) {
+		return Token{}, errProvider
+	}))
+	if !errors.Is(err, errProvider) {
+		t.Errorf("NewGraphClientWithTokenProvider() error = %v, want %v", err, errProvider)
+	}
+}
+
+func TestNewGraphClientWithToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer static" {
+			t.Errorf("Authorization = %q, want Bearer static", r.Header.Get("Authorization"))
+		}
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClientWithToken("static", time.Now().Add(200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewGraphClientWithToken() error = %v", err)
+	}
+	if _, err := g.GetUser("u1"); err != nil {
+		t.Errorf("GraphClient.GetUser() error = %v", err)
+	}
+	time.Sleep(250 * time.Millisecond)
+	if _, err := g.GetUser("u1"); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("GraphClient.GetUser() with an expired token error = %v, want %v", err, ErrTokenExpired)
+	}
+	if _, err := NewGraphClientWithToken("static", time.Now().Add(-time.Minute)); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("NewGraphClientWithToken() of an expired token error = %v, want %v", err, ErrTokenExpired)
+	}
+}
@@ -0,0 +1,89 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxTokenRefreshRetryDelay is the maximum delay between two attempts of the background refresh of a token whose
+// refresh failed, see StartTokenRefresh
+const maxTokenRefreshRetryDelay = time.Minute
+
+// StartTokenRefresh starts a goroutine that refreshes the token of g in the background margin before it expires, so
+// API-calls do not wait for the refresh. The refresh holds the same lock as the on-demand refresh of API-calls, which
+// remains the fallback, e.g. if the background refresh fails. A failed refresh is retried with an exponential backoff.
+//
+// The goroutine runs until Close is called. Returns an error if margin is not positive or the refresh is already
+// running. A clone of g, e.g. of Clone or Beta, does not refresh its token in the background.
+func (g *GraphClient) StartTokenRefresh(margin time.Duration) error {
+	if margin <= 0 {
+		return fmt.Errorf("token refresh margin must be positive, got %v", margin)
+	}
+	g.tokenLock.Lock()
+	defer g.tokenLock.Unlock()
+	if g.stopTokenRefresh != nil {
+		return errors.New("token refresh is already running")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	g.stopTokenRefresh = func() {
+		cancel()
+		<-done
+	}
+	go g.refreshTokenInBackground(ctx, margin, done)
+	return nil
+}
+
+// Close stops the background refresh of StartTokenRefresh and waits until its goroutine has returned, it is a no-op if
+// the refresh has not been started. The GraphClient remains usable, its token is refreshed on demand again.
+func (g *GraphClient) Close() error {
+	g.tokenLock.Lock()
+	stop := g.stopTokenRefresh
+	g.stopTokenRefresh = nil
+	g.tokenLock.Unlock()
+	if stop != nil {
+		stop() // outside of the lock, a running refresh has to finish first
+	}
+	return nil
+}
+
+// refreshTokenInBackground refreshes the token of g margin before it expires until ctx is done, then it closes done
+func (g *GraphClient) refreshTokenInBackground(ctx context.Context, margin time.Duration, done chan<- struct{}) {
+	defer close(done)
+	var failures int
+	var refreshed bool // the token has just been refreshed by the goroutine
+	for {
+		g.tokenLock.Lock()
+		expiresOn := g.token.ExpiresOn
+		g.tokenLock.Unlock()
+
+		wait := time.Until(expiresOn.Add(-margin))
+		if failures > 0 {
+			wait = retryBaseDelay << uint(failures-1)
+			if wait > maxTokenRefreshRetryDelay || wait <= 0 {
+				wait = maxTokenRefreshRetryDelay
+			}
+		} else if wait <= 0 && refreshed {
+			wait = time.Until(expiresOn) / 2 // the new token is valid for less than margin
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+
+		if err := g.tokenLock.LockContext(ctx); err != nil {
+			return
+		}
+		err := g.refreshToken(ctx)
+		if err != nil {
+			failures++
+		} else {
+			failures = 0
+		}
+		refreshed = err == nil
+		g.tokenLock.Unlock()
+	}
+}
@@ -0,0 +1,48 @@
+package msgraph
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_StartTokenRefresh(t *testing.T) {
+	var refreshes int32
+	provider := TokenProviderFunc(func(ctx context.Context) (Token, error) {
+		atomic.AddInt32(&refreshes, 1)
+		return Token{AccessToken: testAppToken, ExpiresOn: time.Now().Add(time.Hour)}, nil
+	})
+	g, err := NewGraphClientWithTokenProvider(provider)
+	if err != nil {
+		t.Fatalf("NewGraphClientWithTokenProvider() error = %v", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Errorf("GraphClient.Close() without StartTokenRefresh error = %v", err)
+	}
+	if err := g.StartTokenRefresh(0); err == nil {
+		t.Errorf("GraphClient.StartTokenRefresh(0) error = nil, want an error")
+	}
+
+	// the token expires within the margin, hence it is refreshed right away
+	if err := g.StartTokenRefresh(time.Hour + time.Minute); err != nil {
+		t.Fatalf("GraphClient.StartTokenRefresh() error = %v", err)
+	}
+	if err := g.StartTokenRefresh(time.Minute); err == nil {
+		t.Errorf("GraphClient.StartTokenRefresh() while running error = nil, want an error")
+	}
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&refreshes) < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	if err := g.Close(); err != nil {
+		t.Errorf("GraphClient.Close() error = %v", err)
+	}
+	stopped := atomic.LoadInt32(&refreshes)
+	if stopped < 2 {
+		t.Errorf("GraphClient.StartTokenRefresh() refreshed the token %v times, want a background refresh", stopped)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&refreshes); got != stopped {
+		t.Errorf("GraphClient.Close() refreshed the token %v times after it returned, want none", got-stopped)
+	}
+}
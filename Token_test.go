@@ -0,0 +1,161 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT with the given claims, the signature is a dummy as it's not verified
+func testJWT(claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","nonce":"xbW6KbPzgqPQs8mj","alg":"RS256","x5t":"nOo3ZDrODXEK1jKWhXslHR_KXEg","kid":"nOo3ZDrODXEK1jKWhXslHR_KXEg"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+}
+
+var (
+	// testAppToken are the claims of a token acquired with the client credentials flow
+	testAppToken = testJWT(`{"aud":"https://graph.microsoft.com","iss":"https://sts.windows.net/72f988bf-86f1-41af-91ab-2d7cd011db47/",` +
+		`"iat":1614829567,"nbf":1614829567,"exp":1614833467,"aio":"E2ZgYFj+0n1/","app_displayname":"go-msgraph",` +
+		`"appid":"1f2d3c4b-5a69-4788-9a0b-c1d2e3f40516","appidacr":"1","idp":"https://sts.windows.net/72f988bf-86f1-41af-91ab-2d7cd011db47/",` +
+		`"idtyp":"app","oid":"0d8e3c4f-1b2a-4c5d-8e9f-a0b1c2d3e4f5","rh":"0.AQsAv4j5cvGG","roles":["User.Read.All","Group.Read.All","Calendars.Read"],` +
+		`"sub":"0d8e3c4f-1b2a-4c5d-8e9f-a0b1c2d3e4f5","tenant_region_scope":"EU","tid":"72f988bf-86f1-41af-91ab-2d7cd011db47","uti":"x3Kq","ver":"1.0"}`)
+	// testDelegatedToken are the claims of a token acquired on behalf of a user
+	testDelegatedToken = testJWT(`{"aud":"00000003-0000-0000-c000-000000000000","iss":"https://sts.windows.net/72f988bf-86f1-41af-91ab-2d7cd011db47/",` +
+		`"iat":1614829567,"nbf":1614829567,"exp":1614833467,"acct":0,"acr":"1","amr":["pwd","mfa"],"app_displayname":"go-msgraph",` +
+		`"appid":"1f2d3c4b-5a69-4788-9a0b-c1d2e3f40516","family_name":"Doe","given_name":"Alice","idtyp":"user","name":"Alice Doe",` +
+		`"scp":"Calendars.ReadWrite Mail.Send openid profile User.Read email","tid":"72f988bf-86f1-41af-91ab-2d7cd011db47",` +
+		`"unique_name":"alice@contoso.com","upn":"alice@contoso.com","ver":"1.0"}`)
+)
+
+func TestToken_Roles(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      Token
+		wantRoles  []string
+		wantScopes []string
+	}{
+		{name: "application", token: Token{AccessToken: testAppToken}, wantRoles: []string{"User.Read.All", "Group.Read.All", "Calendars.Read"}},
+		{name: "delegated", token: Token{AccessToken: testDelegatedToken}, wantScopes: []string{"Calendars.ReadWrite", "Mail.Send", "openid", "profile", "User.Read", "email"}},
+		{name: "no JWT", token: Token{AccessToken: "opaque"}},
+		{name: "invalid claims", token: Token{AccessToken: "a.!!!.c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.Roles(); !reflect.DeepEqual(got, tt.wantRoles) {
+				t.Errorf("Token.Roles() = %v, want %v", got, tt.wantRoles)
+			}
+			if got := tt.token.Scopes(); !reflect.DeepEqual(got, tt.wantScopes) {
+				t.Errorf("Token.Scopes() = %v, want %v", got, tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestNewGraphClient_RequireRoles(t *testing.T) {
+	accessToken := testAppToken
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_on": "%v", "not_before": "%v", "resource": "https://graph.microsoft.com", "access_token": "%v"}`,
+			time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Minute).Unix(), accessToken)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClient("tenant", "app", "secret", RequireRoles("User.Read.All", "Calendars.Read"))
+	if err != nil {
+		t.Fatalf("NewGraphClient() error = %v", err)
+	}
+	if got := g.TokenRoles(); len(got) != 3 {
+		t.Errorf("GraphClient.TokenRoles() = %v, want 3 roles", got)
+	}
+
+	_, err = NewGraphClient("tenant", "app", "secret", RequireRoles("User.Read.All", "Mail.Send", "Sites.Read.All"))
+	if !errors.Is(err, ErrMissingRoles) || !strings.Contains(err.Error(), "Mail.Send, Sites.Read.All (") {
+		t.Errorf("NewGraphClient() error = %v, want %v listing Mail.Send and Sites.Read.All", err, ErrMissingRoles)
+	}
+
+	accessToken = testDelegatedToken
+	g, err = NewGraphClient("tenant", "app", "secret", RequireRoles("Mail.Send"))
+	if err != nil {
+		t.Fatalf("NewGraphClient() of a delegated token error = %v", err)
+	}
+	if got := g.TokenRoles(); len(got) != 6 || got[1] != "Mail.Send" {
+		t.Errorf("GraphClient.TokenRoles() of a delegated token = %v, want its scopes", got)
+	}
+}
+
+func TestNewGraphClient_WithTokenEndpointV2(t *testing.T) {
+	var form url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		fmt.Fprintf(w, `{"token_type": "Bearer", "expires_in": 3599, "ext_expires_in": 3599, "access_token": "%v"}`, testAppToken)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClient("tenant", "app", "secret", WithTokenEndpointV2())
+	if err != nil {
+		t.Fatalf("NewGraphClient() error = %v", err)
+	}
+	if form.Get("scope") != "https://graph.microsoft.com/.default" || form.Get("resource") != "" || form.Get("client_secret") != "secret" {
+		t.Errorf("token request = %v, want the .default scope of msgraph without resource", form)
+	}
+	if until := time.Until(g.token.ExpiresOn); until < 59*time.Minute || until > time.Hour {
+		t.Errorf("Token.ExpiresOn = %v, want in 3599 seconds", g.token.ExpiresOn)
+	}
+
+	form = nil
+	if _, err := NewGraphClient("tenant", "app", "secret", WithTokenScopes("openid"), WithTokenEndpointV2()); err == nil || form != nil {
+		t.Errorf("NewGraphClient(WithTokenScopes, WithTokenEndpointV2) error = %v after token request %v, want an error without token request", err, form)
+	}
+	if _, err := g.Clone(WithTokenScopes("openid")); err == nil {
+		t.Errorf("GraphClient.Clone(WithTokenScopes) of a v2.0 client error = nil, want an error")
+	}
+
+	var token Token
+	if err := json.Unmarshal([]byte(`{"token_type": "Bearer", "expires_in": "60", "access_token": "a"}`), &token); err != nil || token.HasExpired() {
+		t.Errorf("Token.UnmarshalJSON() of expires_in as string = %v, %v, want a valid token", token, err)
+	}
+}
+
+func TestNewGraphClient_WithTokenRefreshMargin(t *testing.T) {
+	var refreshed []Token
+	provider := TokenProviderFunc(func(ctx context.Context) (Token, error) {
+		return Token{AccessToken: testAppToken, ExpiresOn: time.Now().Add(time.Minute)}, nil
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "u1"}`)
+	})
+	newTestGraphClient(t, mux)
+
+	g, err := NewGraphClientWithTokenProvider(provider, OnTokenRefresh(func(token Token) { refreshed = append(refreshed, token) }))
+	if err != nil || len(refreshed) != 1 || !g.TokenExpiresOn().Equal(refreshed[0].ExpiresOn) {
+		t.Fatalf("NewGraphClientWithTokenProvider() error = %v with %v refreshes, want 1 with the TokenExpiresOn", err, len(refreshed))
+	}
+	if _, err := g.GetUser("u1"); err != nil || len(refreshed) != 1 {
+		t.Errorf("GraphClient.GetUser() error = %v with %v refreshes, want no refresh with the default margin", err, len(refreshed))
+	}
+
+	clone, err := g.Clone(WithTokenRefreshMargin(5 * time.Minute))
+	if err != nil || len(refreshed) != 2 {
+		t.Fatalf("GraphClient.Clone() error = %v with %v refreshes, want a refresh with a margin of 5 minutes", err, len(refreshed))
+	}
+	if _, err := clone.GetUser("u1"); err != nil || len(refreshed) != 3 {
+		t.Errorf("GraphClient.GetUser() error = %v with %v refreshes, want a refresh with a margin of 5 minutes", err, len(refreshed))
+	}
+	if token, err := clone.GetToken(); err != nil || len(refreshed) != 4 || token.AccessToken != testAppToken || !token.ExpiresOn.Equal(clone.TokenExpiresOn()) {
+		t.Errorf("GraphClient.GetToken() = %v, %v with %v refreshes, want the refreshed token", token, err, len(refreshed))
+	}
+	if _, err := g.Clone(WithTokenRefreshMargin(-time.Second)); err == nil {
+		t.Errorf("GraphClient.Clone() with a negative margin error = nil, want an error")
+	}
+}
@@ -1,23 +1,45 @@
 package msgraph
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 	"time"
 )
 
+// UserType is the type of a user account in the directory
+type UserType string
+
+// User types as returned by msgraph in userType
+const (
+	UserTypeMember UserType = "Member"
+	UserTypeGuest  UserType = "Guest"
+)
+
+// IsValid returns true if t is one of the UserType constants
+func (t UserType) IsValid() bool {
+	return t == UserTypeMember || t == UserTypeGuest
+}
+
 // User represents a user from the ms graph API
 type User struct {
-	ID                string   `json:"id"`
-	BusinessPhones    []string `json:"businessPhones"`
-	DisplayName       string   `json:"displayName"`
-	GivenName         string   `json:"givenName"`
-	Mail              string   `json:"mail"`
-	MobilePhone       string   `json:"mobilePhone"`
-	PreferredLanguage string   `json:"preferredLanguage"`
-	Surname           string   `json:"surname"`
-	UserPrincipalName string   `json:"userPrincipalName"`
+	ID                string           `json:"id"`
+	BusinessPhones    []string         `json:"businessPhones"`
+	DisplayName       string           `json:"displayName"`
+	GivenName         string           `json:"givenName"`
+	Mail              string           `json:"mail"`
+	MobilePhone       string           `json:"mobilePhone"`
+	PreferredLanguage string           `json:"preferredLanguage"`
+	Surname           string           `json:"surname"`
+	UserPrincipalName string           `json:"userPrincipalName"`
+	UserType          UserType         `json:"userType"`                   // only returned by msgraph if selected
+	PasswordPolicies  PasswordPolicies `json:"passwordPolicies,omitempty"` // only returned by msgraph if selected
+	// OnPremisesProvisioningErrors of the synchronization by Azure AD Connect, only returned by msgraph if selected
+	OnPremisesProvisioningErrors []OnPremisesProvisioningError `json:"onPremisesProvisioningErrors,omitempty"`
+
+	AdditionalData AdditionalData `json:"-"` // properties that are not mapped to a field, see CaptureAdditionalData
 
 	activePhone string       // private cache for the active phone number
 	graphClient *GraphClient // the graphClient that called the user
@@ -26,9 +48,97 @@ type User struct {
 func (u *User) String() string {
 	return fmt.Sprintf("User(ID: \"%v\", BusinessPhones: \"%v\", DisplayName: \"%v\", GivenName: \"%v\", "+
 		"Mail: \"%v\", MobilePhone: \"%v\", PreferredLanguage: \"%v\", Surname: \"%v\", UserPrincipalName: \"%v\", "+
-		"ActivePhone: \"%v\", DirectAPIConnection: %v)",
+		"UserType: \"%v\", PasswordPolicies: \"%v\", ActivePhone: \"%v\", DirectAPIConnection: %v)",
 		u.ID, u.BusinessPhones, u.DisplayName, u.GivenName, u.Mail, u.MobilePhone, u.PreferredLanguage, u.Surname,
-		u.UserPrincipalName, u.activePhone, u.graphClient != nil)
+		u.UserPrincipalName, u.UserType, u.PasswordPolicies, u.activePhone, u.graphClient != nil)
+}
+
+// Validate returns an error if an enum field of the user, e.g. UserType or PasswordPolicies, has a value that is
+// unknown to msgraph. Empty fields are valid, they have not been returned by msgraph.
+func (u User) Validate() error {
+	if u.UserType != "" && !u.UserType.IsValid() {
+		return fmt.Errorf("invalid UserType %q", u.UserType)
+	}
+	return u.PasswordPolicies.Validate()
+}
+
+// UnmarshalJSON implements the json unmarshal to be used by the json-library
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User // user has no UnmarshalJSON, prevents the recursion
+	if err := json.Unmarshal(data, (*user)(u)); err != nil {
+		return err
+	}
+	var err error
+	u.AdditionalData, err = extractAdditionalData(data, u)
+	return err
+}
+
+// MarshalJSON implements the json marshal to be used by the json-library, the AdditionalData is included
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User // user has no MarshalJSON, prevents the recursion
+	marshalled, err := json.Marshal(user(u))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAdditionalData(marshalled, u.AdditionalData)
+}
+
+// UserUpdate contains the properties of a user that are changed by GraphClient.UpdateUser. Only properties that are
+// not nil are sent to msgraph, a pointer to an empty string clears the property. Use
+// PasswordPolicies{PasswordPolicyNone} to remove all password policies.
+type UserUpdate struct {
+	AccountEnabled    *bool            `json:"accountEnabled,omitempty"`
+	BusinessPhones    []string         `json:"businessPhones,omitempty"`
+	Department        *string          `json:"department,omitempty"`
+	DisplayName       *string          `json:"displayName,omitempty"`
+	GivenName         *string          `json:"givenName,omitempty"`
+	JobTitle          *string          `json:"jobTitle,omitempty"`
+	MobilePhone       *string          `json:"mobilePhone,omitempty"`
+	OfficeLocation    *string          `json:"officeLocation,omitempty"`
+	PreferredLanguage *string          `json:"preferredLanguage,omitempty"`
+	Surname           *string          `json:"surname,omitempty"`
+	UsageLocation     *string          `json:"usageLocation,omitempty"`
+	PasswordPolicies  PasswordPolicies `json:"passwordPolicies,omitempty"`
+}
+
+// Validate returns an error if a property of the update has a value that is not accepted by msgraph
+func (u UserUpdate) Validate() error {
+	return u.PasswordPolicies.Validate()
+}
+
+// UserCreate contains the properties of a user created by GraphClient.CreateUser. All properties except
+// UsageLocation are required by msgraph.
+type UserCreate struct {
+	AccountEnabled    bool            `json:"accountEnabled"`
+	DisplayName       string          `json:"displayName"`
+	MailNickname      string          `json:"mailNickname"`
+	UserPrincipalName string          `json:"userPrincipalName"`
+	PasswordProfile   PasswordProfile `json:"passwordProfile"`
+	UsageLocation     string          `json:"usageLocation,omitempty"` // GraphClient.DefaultUsageLocation if empty
+}
+
+// Validate returns an error if a property required by msgraph is missing
+func (u UserCreate) Validate() error {
+	switch {
+	case u.DisplayName == "":
+		return fmt.Errorf("displayName of the new user is required")
+	case u.MailNickname == "":
+		return fmt.Errorf("mailNickname of the new user is required")
+	case u.UserPrincipalName == "":
+		return fmt.Errorf("userPrincipalName of the new user is required")
+	case u.PasswordProfile.Password == "":
+		return fmt.Errorf("password of the new user is required")
+	}
+	return nil
+}
+
+// PasswordProfile is the password of a user created by GraphClient.CreateUser or reset by GraphClient.ResetUserPassword
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/passwordprofile
+type PasswordProfile struct {
+	ForceChangePasswordNextSignIn        bool   `json:"forceChangePasswordNextSignIn"`
+	ForceChangePasswordNextSignInWithMfa bool   `json:"forceChangePasswordNextSignInWithMfa"` // the user has to perform a multi-factor authentication before the change
+	Password                             string `json:"password"`
 }
 
 // setGraphClient sets the graphClient instance in this instance and all child-instances (if any)
@@ -40,17 +150,18 @@ func (u *User) setGraphClient(gC *GraphClient) {
 //
 // Reference: https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/user_list_calendars
 func (u User) ListCalendars() (Calendars, error) {
+	return u.ListCalendarsContext(context.Background())
+}
+
+// ListCalendarsContext is ListCalendars with a context.
+func (u User) ListCalendarsContext(ctx context.Context) (Calendars, error) {
 	if u.graphClient == nil {
 		return Calendars{}, ErrNotGraphClientSourced
 	}
-	resource := fmt.Sprintf("/users/%v/calendars", u.ID)
-
-	var marsh struct {
-		Calendars Calendars `json:"value"`
+	if err := u.graphClient.checkUserIdentifier(u.ID); err != nil {
+		return Calendars{}, err
 	}
-	err := u.graphClient.makeGETAPICall(resource, nil, &marsh)
-	marsh.Calendars.setGraphClient(u.graphClient)
-	return marsh.Calendars, err
+	return u.graphClient.listCalendars(ctx, fmt.Sprintf("/users/%v", u.ID))
 }
 
 // ListCalendarView returns the CalendarEvents of the given user within the specified
@@ -60,19 +171,45 @@ func (u User) ListCalendars() (Calendars, error) {
 //
 // See https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/user_list_calendarview
 func (u User) ListCalendarView(startDateTime, endDateTime time.Time) (CalendarEvents, error) {
+	return u.ListCalendarViewContext(context.Background(), startDateTime, endDateTime)
+}
+
+// ListCalendarViewContext is ListCalendarView with a context.
+func (u User) ListCalendarViewContext(ctx context.Context, startDateTime, endDateTime time.Time) (CalendarEvents, error) {
 	if u.graphClient == nil {
 		return CalendarEvents{}, ErrNotGraphClientSourced
 	}
+	if err := u.graphClient.checkUserIdentifier(u.ID); err != nil {
+		return CalendarEvents{}, err
+	}
+	return u.graphClient.listCalendarView(ctx, fmt.Sprintf("/users/%v", u.ID), startDateTime, endDateTime)
+}
 
-	if len(globalSupportedTimeZones.Value) == 0 {
-		var err error
-		globalSupportedTimeZones, err = u.getTimeZoneChoices()
-		if err != nil {
-			return CalendarEvents{}, err
-		}
+// getTimeZoneChoices grabs all supported time zones from microsoft for this user.
+// This should actually be the same for every user. Only used internally by this
+// msgraph package.
+//
+// See https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/outlookuser_supportedtimezones
+func (u User) getTimeZoneChoices(ctx context.Context) (supportedTimeZones, error) {
+	return u.graphClient.getTimeZoneChoices(ctx, fmt.Sprintf("/users/%s", u.ID))
+}
+
+// listCalendars returns the calendars of the user resource, e.g. /users/{id} or /me
+func (g *GraphClient) listCalendars(ctx context.Context, userResource string) (Calendars, error) {
+	var marsh struct {
+		Calendars Calendars `json:"value"`
 	}
+	err := g.makeGETAPICall(ctx, userResource+"/calendars", nil, &marsh)
+	marsh.Calendars.setGraphClient(g)
+	return marsh.Calendars, err
+}
 
-	resource := fmt.Sprintf("/users/%v/calendar/calendarview", u.ID)
+// listCalendarView returns the CalendarEvents of the default calendar of the user resource, e.g. /users/{id} or /me,
+// within the specified start- and endDateTime
+func (g *GraphClient) listCalendarView(ctx context.Context, userResource string, startDateTime, endDateTime time.Time) (CalendarEvents, error) {
+	if err := g.loadSupportedTimeZones(ctx, userResource); err != nil {
+		return CalendarEvents{}, err
+	}
 
 	// set GET-Params for start and end time
 	getParams := url.Values{}
@@ -80,17 +217,13 @@ func (u User) ListCalendarView(startDateTime, endDateTime time.Time) (CalendarEv
 	getParams.Add("enddatetime", endDateTime.Format("2006-01-02T00:00:00"))
 
 	var calendarEvents CalendarEvents
-	return calendarEvents, u.graphClient.makeGETAPICall(resource, getParams, &calendarEvents)
+	return calendarEvents, g.makeGETAPICall(ctx, userResource+"/calendar/calendarview", getParams, &calendarEvents)
 }
 
-// getTimeZoneChoices grabs all supported time zones from microsoft for this user.
-// This should actually be the same for every user. Only used internally by this
-// msgraph package.
-//
-// See https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/outlookuser_supportedtimezones
-func (u User) getTimeZoneChoices() (supportedTimeZones, error) {
+// getTimeZoneChoices grabs all supported time zones from microsoft for the user resource, e.g. /users/{id} or /me
+func (g *GraphClient) getTimeZoneChoices(ctx context.Context, userResource string) (supportedTimeZones, error) {
 	var ret supportedTimeZones
-	err := u.graphClient.makeGETAPICall(fmt.Sprintf("/users/%s/outlook/supportedTimeZones", u.ID), nil, &ret)
+	err := g.makeGETAPICall(ctx, userResource+"/outlook/supportedTimeZones", nil, &ret)
 	return ret, err
 }
 
@@ -138,5 +271,5 @@ func (u User) Equal(other User) bool {
 	equalBool = equalBool && len(u.BusinessPhones) == len(other.BusinessPhones)
 	return equalBool && u.ID == other.ID && u.DisplayName == other.DisplayName && u.GivenName == other.GivenName &&
 		u.Mail == other.Mail && u.MobilePhone == other.MobilePhone && u.PreferredLanguage == other.PreferredLanguage &&
-		u.Surname == other.Surname && u.UserPrincipalName == other.UserPrincipalName
+		u.Surname == other.Surname && u.UserPrincipalName == other.UserPrincipalName && u.UserType == other.UserType
 }
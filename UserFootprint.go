@@ -0,0 +1,206 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Section names of a UserFootprint, used as keys of UserFootprint.Errors
+const (
+	FootprintSectionOwnedGroups        = "ownedGroups"
+	FootprintSectionOwnedDevices       = "ownedDevices"
+	FootprintSectionRegisteredDevices  = "registeredDevices"
+	FootprintSectionAppRoleAssignments = "appRoleAssignments"
+	FootprintSectionLicenses           = "licenses"
+	FootprintSectionManager            = "manager"
+	FootprintSectionDirectReports      = "directReports"
+	FootprintSectionSignInActivity     = "signInActivity"
+)
+
+// defaultFootprintConcurrency is the number of sections of a UserFootprint that are loaded at the same time
+const defaultFootprintConcurrency = 4
+
+// UserFootprint summarizes everything a user owns or is assigned to in the directory, e.g. to be reviewed
+// before the user is offboarded. Every section is loaded independently, a section that could not be loaded
+// stays empty and its error is stored in Errors with the section name (FootprintSection...) as key. The errors wrap
+// their cause, e.g. errors.Is(err, ErrForbidden) if the permission for the section is missing.
+type UserFootprint struct {
+	OwnedGroups        Groups
+	OwnedDevices       []Device
+	RegisteredDevices  []Device
+	AppRoleAssignments []AppRoleAssignment
+	Licenses           []LicenseDetail
+	Manager            *User // nil if the user has no manager
+	DirectReports      Users
+	LastSignInDateTime time.Time // only loaded with FootprintIncludeSignInActivity, zero if the user never signed in
+	Errors             map[string]error
+}
+
+func (u UserFootprint) String() string {
+	var manager string
+	if u.Manager != nil {
+		manager = u.Manager.UserPrincipalName
+	}
+	return fmt.Sprintf("UserFootprint(OwnedGroups: %v, OwnedDevices: %v, RegisteredDevices: %v, AppRoleAssignments: %v, Licenses: %v, "+
+		"Manager: \"%v\", DirectReports: %v, LastSignInDateTime: \"%v\", Errors: %v)",
+		len(u.OwnedGroups), len(u.OwnedDevices), len(u.RegisteredDevices), len(u.AppRoleAssignments), len(u.Licenses),
+		manager, len(u.DirectReports), u.LastSignInDateTime, len(u.Errors))
+}
+
+type footprintOptions struct {
+	concurrency           int
+	includeSignInActivity bool
+}
+
+// FootprintOption configures GetUserFootprint
+type FootprintOption func(*footprintOptions)
+
+// FootprintIncludeSignInActivity additionally loads the last sign-in of the user. This requires the
+// AuditLog.Read.All permission and an Azure AD Premium license.
+func FootprintIncludeSignInActivity() FootprintOption {
+	return func(o *footprintOptions) { o.includeSignInActivity = true }
+}
+
+// FootprintConcurrency sets the number of sections that are loaded at the same time, defaults to 4
+func FootprintConcurrency(concurrency int) FootprintOption {
+	return func(o *footprintOptions) { o.concurrency = concurrency }
+}
+
+// GetUserFootprint concurrently gathers the owned groups, owned and registered devices, app role assignments,
+// licenses, manager and direct reports of the user identified by either the given ID or userPrincipalName.
+//
+// A section that fails does not abort the other sections, its error is collected in UserFootprint.Errors.
+// The returned error is only non-nil if no section could be loaded at all.
+func (g *GraphClient) GetUserFootprint(identifier string, opts ...FootprintOption) (UserFootprint, error) {
+	return g.GetUserFootprintContext(context.Background(), identifier, opts...)
+}
+
+// GetUserFootprintContext is GetUserFootprint with a context.
+// Sections that have not been started when ctx is done are skipped and ctx.Err() is stored in UserFootprint.Errors.
+func (g *GraphClient) GetUserFootprintContext(ctx context.Context, identifier string, opts ...FootprintOption) (UserFootprint, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return UserFootprint{}, err
+	}
+	options := footprintOptions{concurrency: defaultFootprintConcurrency}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var footprint UserFootprint
+	sections := []struct {
+		name string
+		load func() error
+	}{
+		{FootprintSectionOwnedGroups, func() (err error) {
+			footprint.OwnedGroups, err = g.listGroupsPaged(ctx, fmt.Sprintf("/users/%v/ownedObjects/microsoft.graph.group", identifier))
+			return err
+		}},
+		{FootprintSectionOwnedDevices, func() (err error) {
+			footprint.OwnedDevices, err = g.listUserDevices(ctx, fmt.Sprintf("/users/%v/ownedDevices", identifier))
+			return err
+		}},
+		{FootprintSectionRegisteredDevices, func() (err error) {
+			footprint.RegisteredDevices, err = g.listUserDevices(ctx, fmt.Sprintf("/users/%v/registeredDevices", identifier))
+			return err
+		}},
+		{FootprintSectionAppRoleAssignments, func() error {
+			resource := fmt.Sprintf("/users/%v/appRoleAssignments", identifier)
+			return g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+				var page []AppRoleAssignment
+				err := json.Unmarshal(value, &page)
+				footprint.AppRoleAssignments = append(footprint.AppRoleAssignments, page...)
+				return true, err
+			})
+		}},
+		{FootprintSectionLicenses, func() (err error) {
+			footprint.Licenses, err = g.ListUserLicenseDetailsContext(ctx, identifier)
+			return err
+		}},
+		{FootprintSectionManager, func() error {
+			manager := User{graphClient: g}
+			err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v/manager", identifier), nil, &manager)
+			if hasStatusCode(err, http.StatusNotFound) { // the user has no manager
+				return nil
+			}
+			if err == nil {
+				footprint.Manager = &manager
+			}
+			return err
+		}},
+		{FootprintSectionDirectReports, func() error {
+			resource := fmt.Sprintf("/users/%v/directReports/microsoft.graph.user", identifier)
+			return g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+				var page Users
+				err := json.Unmarshal(value, &page)
+				footprint.DirectReports = append(footprint.DirectReports, page.setGraphClient(g)...)
+				return true, err
+			})
+		}},
+	}
+	if options.includeSignInActivity {
+		sections = append(sections, struct {
+			name string
+			load func() error
+		}{FootprintSectionSignInActivity, func() error {
+			var marsh struct {
+				SignInActivity struct {
+					LastSignInDateTime time.Time `json:"lastSignInDateTime"`
+				} `json:"signInActivity"`
+			}
+			getParams := url.Values{}
+			getParams.Add("$select", "signInActivity")
+			err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v", identifier), getParams, &marsh)
+			footprint.LastSignInDateTime = marsh.SignInActivity.LastSignInDateTime
+			return err
+		}})
+	}
+
+	// every section writes its own fields of footprint only, hence just the errors have to be collected separately
+	errs := make([]error, len(sections))
+	forEachConcurrently(len(sections), options.concurrency, func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		errs[i] = sections[i].load()
+	})
+
+	footprint.Errors = make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			footprint.Errors[sections[i].name] = fmt.Errorf("cannot load %v: %w", sections[i].name, err)
+		}
+	}
+	if len(footprint.Errors) == len(sections) {
+		return footprint, fmt.Errorf("cannot load any section of the footprint of %v: %w", identifier, footprint.Errors[FootprintSectionOwnedGroups])
+	}
+	return footprint, nil
+}
+
+// listGroupsPaged returns all groups of the given resource, following @odata.nextLink
+func (g *GraphClient) listGroupsPaged(ctx context.Context, resource string) (Groups, error) {
+	var groups Groups
+	err := g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+		var page Groups
+		err := json.Unmarshal(value, &page)
+		groups = append(groups, page.setGraphClient(g)...)
+		return true, err
+	})
+	return groups, err
+}
+
+// listUserDevices returns all devices of the given resource, following @odata.nextLink
+func (g *GraphClient) listUserDevices(ctx context.Context, resource string) ([]Device, error) {
+	var devices []Device
+	err := g.makePagedGETAPICall(ctx, resource, nil, func(value json.RawMessage) (bool, error) {
+		var page []Device
+		err := json.Unmarshal(value, &page)
+		devices = append(devices, page...)
+		return true, err
+	})
+	return devices, err
+}
@@ -0,0 +1,73 @@
+package msgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGraphClient_GetUserFootprint(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/alice/ownedObjects/microsoft.graph.group", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "g1", "displayName": "Technicians"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice/ownedDevices", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "d1", "displayName": "LAPTOP-01", "operatingSystem": "Windows"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice/registeredDevices", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice/appRoleAssignments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "a1", "appRoleId": "role1", "resourceDisplayName": "Contoso HR"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice/licenseDetails", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "l1", "skuId": "sku1", "skuPartNumber": "ENTERPRISEPACK"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice/manager", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": "Request_ResourceNotFound", "message": ""}}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice/directReports/microsoft.graph.user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"id": "u2", "userPrincipalName": "bob@contoso.com"}]}`)
+	})
+	mux.HandleFunc("/v1.0/users/alice", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"signInActivity": {"lastSignInDateTime": "2021-03-04T05:06:07Z"}}`)
+	})
+	g := newTestGraphClient(t, mux)
+
+	t.Run("partial result", func(t *testing.T) {
+		got, err := g.GetUserFootprint("alice", FootprintIncludeSignInActivity(), FootprintConcurrency(2))
+		if err != nil {
+			t.Fatalf("GraphClient.GetUserFootprint() error = %v", err)
+		}
+		if len(got.Errors) != 1 || !errors.Is(got.Errors[FootprintSectionRegisteredDevices], ErrForbidden) {
+			t.Errorf("GraphClient.GetUserFootprint() Errors = %v, want an error for %v only", got.Errors, FootprintSectionRegisteredDevices)
+		}
+		if len(got.OwnedGroups) != 1 || len(got.OwnedDevices) != 1 || len(got.AppRoleAssignments) != 1 ||
+			len(got.Licenses) != 1 || len(got.DirectReports) != 1 {
+			t.Errorf("GraphClient.GetUserFootprint() = %v, want one entry in every loaded section", got)
+		}
+		if got.RegisteredDevices != nil || got.Manager != nil {
+			t.Errorf("GraphClient.GetUserFootprint() RegisteredDevices = %v, Manager = %v, want both empty", got.RegisteredDevices, got.Manager)
+		}
+		if want := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC); !got.LastSignInDateTime.Equal(want) {
+			t.Errorf("GraphClient.GetUserFootprint() LastSignInDateTime = %v, want %v", got.LastSignInDateTime, want)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		got, err := g.GetUserFootprintContext(ctx, "alice")
+		if !errors.Is(err, context.Canceled) || !errors.Is(got.Errors[FootprintSectionManager], context.Canceled) {
+			t.Errorf("GraphClient.GetUserFootprintContext() error = %v, want %v", err, context.Canceled)
+		}
+		if len(got.Errors) != 7 {
+			t.Errorf("GraphClient.GetUserFootprintContext() Errors = %v, want all 7 sections to fail", got.Errors)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetUserManager returns the manager of the user identified by either the given ID or userPrincipalName.
+// Returns an error with StatusCode 404 if the user has no manager.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-manager
+func (g *GraphClient) GetUserManager(identifier string) (User, error) {
+	return g.GetUserManagerContext(context.Background(), identifier)
+}
+
+// GetUserManagerContext is GetUserManager with a context.
+func (g *GraphClient) GetUserManagerContext(ctx context.Context, identifier string) (User, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return User{}, err
+	}
+	manager := User{graphClient: g}
+	err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v/manager", identifier), nil, &manager)
+	return manager, err
+}
+
+// SetUserManager makes the user identified by managerID the manager of the user identified by userID, an existing
+// manager is replaced.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-post-manager
+func (g *GraphClient) SetUserManager(userID, managerID string) error {
+	return g.SetUserManagerContext(context.Background(), userID, managerID)
+}
+
+// SetUserManagerContext is SetUserManager with a context.
+func (g *GraphClient) SetUserManagerContext(ctx context.Context, userID, managerID string) error {
+	body := map[string]string{
+		"@odata.id": fmt.Sprintf("%v/%v/users/%v", g.endpoints().BaseURL, APIVersion, managerID),
+	}
+	return g.makePUTAPICall(ctx, fmt.Sprintf("/users/%v/manager/$ref", userID), body, nil)
+}
+
+// RemoveUserManager removes the manager of the user identified by userID
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-delete-manager
+func (g *GraphClient) RemoveUserManager(userID string) error {
+	return g.RemoveUserManagerContext(context.Background(), userID)
+}
+
+// RemoveUserManagerContext is RemoveUserManager with a context.
+func (g *GraphClient) RemoveUserManagerContext(ctx context.Context, userID string) error {
+	return g.makeDELETEAPICall(ctx, fmt.Sprintf("/users/%v/manager/$ref", userID))
+}
+
+// odataTypeUser is the @odata.type of a user within a collection of directory objects
+const odataTypeUser = "#microsoft.graph.user"
+
+// ListUserDirectReports returns the users that report to the user identified by either the given ID or
+// userPrincipalName. Direct reports that are not users, e.g. organizational contacts, are skipped.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/user-list-directreports
+func (g *GraphClient) ListUserDirectReports(identifier string) (Users, error) {
+	return g.ListUserDirectReportsContext(context.Background(), identifier)
+}
+
+// ListUserDirectReportsContext is ListUserDirectReports with a context.
+func (g *GraphClient) ListUserDirectReportsContext(ctx context.Context, identifier string) (Users, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	var marsh struct {
+		Objects []json.RawMessage `json:"value"`
+	}
+	if err := g.makeGETAPICall(ctx, fmt.Sprintf("/users/%v/directReports", identifier), nil, &marsh); err != nil {
+		return nil, err
+	}
+	var reports Users
+	for _, object := range marsh.Objects {
+		var base DirectoryObject
+		if err := json.Unmarshal(object, &base); err != nil {
+			return reports, err
+		}
+		if base.ODataType != odataTypeUser {
+			continue
+		}
+		report := User{graphClient: g}
+		if err := json.Unmarshal(object, &report); err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// ListUserTransitiveReports returns the users that report to the user identified by either the given ID or
+// userPrincipalName directly or indirectly, up to depth levels below the user, e.g. 1 for the direct reports only.
+// The reporting lines are traversed breadth first, hence the users are ordered by their level. Every user is
+// returned once, even if the reporting lines contain a cycle.
+func (g *GraphClient) ListUserTransitiveReports(identifier string, depth int) (Users, error) {
+	return g.ListUserTransitiveReportsContext(context.Background(), identifier, depth)
+}
+
+// ListUserTransitiveReportsContext is ListUserTransitiveReports with a context.
+func (g *GraphClient) ListUserTransitiveReportsContext(ctx context.Context, identifier string, depth int) (Users, error) {
+	var reports Users
+	seen := map[string]bool{identifier: true}
+	level := []string{identifier}
+	for ; depth > 0 && len(level) > 0; depth-- {
+		var next []string
+		for _, managerID := range level {
+			directReports, err := g.ListUserDirectReportsContext(ctx, managerID)
+			if err != nil {
+				return reports, fmt.Errorf("cannot list direct reports of %v: %w", managerID, err)
+			}
+			for _, report := range directReports {
+				if seen[report.ID] {
+					continue
+				}
+				seen[report.ID] = true
+				reports = append(reports, report)
+				next = append(next, report.ID)
+			}
+		}
+		level = next
+	}
+	return reports, nil
+}
@@ -0,0 +1,68 @@
+package msgraph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGraphClient_UserManager(t *testing.T) {
+	var requests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1/manager", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"@odata.type": "#microsoft.graph.user", "id": "m1", "displayName": "Manager"}`)
+	})
+	mux.HandleFunc("/v1.0/users/u1/manager/$ref", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%v %s", r.Method, body))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	g := newTestGraphClient(t, mux)
+
+	manager, err := g.GetUserManager("u1")
+	if err != nil || manager.ID != "m1" || manager.graphClient != g {
+		t.Errorf("GraphClient.GetUserManager() = %v, %v, want m1 with its GraphClient", manager, err)
+	}
+	if err := g.SetUserManager("u1", "m2"); err != nil {
+		t.Errorf("GraphClient.SetUserManager() error = %v", err)
+	}
+	if err := g.RemoveUserManager("u1"); err != nil {
+		t.Errorf("GraphClient.RemoveUserManager() error = %v", err)
+	}
+	want := `[PUT {"@odata.id":"https://graph.microsoft.com/v1.0/users/m2"} DELETE ]`
+	if fmt.Sprint(requests) != want {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestGraphClient_ListUserTransitiveReports(t *testing.T) {
+	reports := map[string]string{
+		"m1": `{"@odata.type": "#microsoft.graph.user", "id": "u1"}, {"@odata.type": "#microsoft.graph.orgContact", "id": "c1"}, {"@odata.type": "#microsoft.graph.user", "id": "u2"}`,
+		"u1": `{"@odata.type": "#microsoft.graph.user", "id": "u3"}`,
+		"u2": `{"@odata.type": "#microsoft.graph.user", "id": "m1"}`, // a cycle
+		"u3": `{"@odata.type": "#microsoft.graph.user", "id": "u4"}`,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1.0/users/"), "/directReports")
+		fmt.Fprintf(w, `{"value": [%v]}`, reports[id])
+	})
+	g := newTestGraphClient(t, mux)
+
+	direct, err := g.ListUserDirectReports("m1")
+	if err != nil || len(direct) != 2 || direct[0].graphClient != g {
+		t.Errorf("GraphClient.ListUserDirectReports() = %v, %v, want u1 and u2 without the contact", direct, err)
+	}
+	for depth, want := range map[int]string{0: "[]", 1: "[u1 u2]", 2: "[u1 u2 u3]", 5: "[u1 u2 u3 u4]"} {
+		got, err := g.ListUserTransitiveReports("m1", depth)
+		var ids []string
+		for _, user := range got {
+			ids = append(ids, user.ID)
+		}
+		if err != nil || fmt.Sprint(ids) != want {
+			t.Errorf("GraphClient.ListUserTransitiveReports(%v) = %v, %v, want %v", depth, ids, err, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package msgraph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetUserPhoto returns the binary data and the content type, e.g. "image/jpeg", of the profile photo of the user
+// identified by either the given ID or userPrincipalName. Returns an error with StatusCode 404 if the user has no photo.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/profilephoto-get
+func (g *GraphClient) GetUserPhoto(identifier string) ([]byte, string, error) {
+	return g.GetUserPhotoContext(context.Background(), identifier)
+}
+
+// GetUserPhotoContext is GetUserPhoto with a context.
+func (g *GraphClient) GetUserPhotoContext(ctx context.Context, identifier string) ([]byte, string, error) {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return nil, "", err
+	}
+	resource := fmt.Sprintf("/users/%v/photo/$value", identifier)
+	var data []byte
+	var header http.Header
+	err := g.makeAPICall(ctx, http.MethodGet, resource, nil, nil, &data, ResponseHeader(&header))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, header.Get("Content-Type"), nil
+}
+
+// SetUserPhoto replaces the profile photo of the user identified by either the given ID or userPrincipalName with
+// the binary data of the given content type, e.g. "image/jpeg". The photo must not be larger than 4 MB.
+//
+// Reference: https://docs.microsoft.com/en-us/graph/api/profilephoto-update
+func (g *GraphClient) SetUserPhoto(identifier string, contentType string, data []byte) error {
+	return g.SetUserPhotoContext(context.Background(), identifier, contentType, data)
+}
+
+// SetUserPhotoContext is SetUserPhoto with a context.
+func (g *GraphClient) SetUserPhotoContext(ctx context.Context, identifier string, contentType string, data []byte) error {
+	if err := g.checkUserIdentifier(identifier); err != nil {
+		return err
+	}
+	resource := fmt.Sprintf("/users/%v/photo/$value", identifier)
+	return g.makePUTAPICall(ctx, resource, data, nil, withContentType(contentType))
+}
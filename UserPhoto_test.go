@@ -0,0 +1,41 @@
+package msgraph
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestGraphClient_UserPhoto(t *testing.T) {
+	jpeg := []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 'J', 'F', 'I', 'F', 0x00, 0xff, 0xd9}
+	var stored []byte
+	var storedType string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/u1/photo/$value", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			stored, _ = ioutil.ReadAll(r.Body)
+			storedType = r.Header.Get("Content-Type")
+		case http.MethodGet:
+			if stored == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", storedType)
+			w.Write(stored)
+		}
+	})
+	g := newTestGraphClient(t, mux)
+
+	if _, _, err := g.GetUserPhoto("u1"); !hasStatusCode(err, http.StatusNotFound) {
+		t.Errorf("GraphClient.GetUserPhoto() without photo error = %v, want StatusCode 404", err)
+	}
+	if err := g.SetUserPhoto("u1", "image/jpeg", jpeg); err != nil {
+		t.Fatalf("GraphClient.SetUserPhoto() error = %v", err)
+	}
+	data, contentType, err := g.GetUserPhoto("u1")
+	if err != nil || !bytes.Equal(data, jpeg) || contentType != "image/jpeg" {
+		t.Errorf("GraphClient.GetUserPhoto() = %x, %v, %v, want %x, image/jpeg", data, contentType, err, jpeg)
+	}
+}
@@ -1,6 +1,11 @@
 package msgraph
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -34,7 +39,7 @@ func TestUser_getTimeZoneChoices(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := tt.u.getTimeZoneChoices()
+			_, err := tt.u.getTimeZoneChoices(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("User.getTimeZoneChoices() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -125,3 +130,43 @@ func TestUser_ListCalendarView(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_ListCalendarView_concurrent(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1.0/users/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/supportedTimeZones") {
+			fmt.Fprint(w, `{"value": [{"alias": "W. Europe Standard Time", "displayName": "(UTC+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"value": [{"id": "e1", "subject": "Meeting",
+			"createdDateTime": "2021-02-01T10:00:00Z", "lastModifiedDateTime": "2021-02-01T10:00:00Z",
+			"originalStartTimeZone": "W. Europe Standard Time", "originalEndTimeZone": "W. Europe Standard Time",
+			"start": {"dateTime": "2021-03-01T08:00:00.0000000", "timeZone": "UTC"},
+			"end": {"dateTime": "2021-03-01T09:00:00.0000000", "timeZone": "UTC"}}]}`)
+	})
+	g := newTestGraphClient(t, mux)
+	origTimeZones := currentSupportedTimeZones()
+	globalSupportedTimeZonesLock.Lock()
+	globalSupportedTimeZones = supportedTimeZones{} // every ListCalendarView has to load them
+	globalSupportedTimeZonesLock.Unlock()
+	t.Cleanup(func() {
+		globalSupportedTimeZonesLock.Lock()
+		globalSupportedTimeZones = origTimeZones
+		globalSupportedTimeZonesLock.Unlock()
+	})
+
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			u := User{ID: fmt.Sprintf("u%v", i), graphClient: g}
+			events, err := u.ListCalendarView(start, start.Add(24*time.Hour))
+			if err != nil || len(events) != 1 || events[0].StartTime.Location().String() != "Europe/Berlin" {
+				t.Errorf("User.ListCalendarView() = %v, %v", events, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,72 @@
+package msgraph
+
+import (
+	"context"
+	"sync"
+)
+
+// forEachConcurrently calls fn for every index from 0 to n-1 with at most concurrency goroutines
+// running at the same time and returns as soon as all calls have finished. A concurrency lower
+// than 1 is treated as 1.
+func forEachConcurrently(n, concurrency int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrency && worker < n; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// contextMutex is a mutual exclusion lock like sync.Mutex, but waiting for it can be abandoned when a
+// context is done, see LockContext. The zero value is an unlocked mutex.
+type contextMutex struct {
+	once   sync.Once
+	locked chan struct{} // holds a value while the mutex is locked
+}
+
+func (m *contextMutex) init() {
+	m.once.Do(func() { m.locked = make(chan struct{}, 1) })
+}
+
+// Lock locks m, it blocks until m is available
+func (m *contextMutex) Lock() {
+	m.init()
+	m.locked <- struct{}{}
+}
+
+// LockContext locks m like Lock, but returns ctx.Err() without locking m if ctx is done before m is available
+func (m *contextMutex) LockContext(ctx context.Context) error {
+	m.init()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case m.locked <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Unlock unlocks m, it panics if m is not locked
+func (m *contextMutex) Unlock() {
+	m.init()
+	select {
+	case <-m.locked:
+	default:
+		panic("msgraph: unlock of unlocked contextMutex")
+	}
+}
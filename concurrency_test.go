@@ -0,0 +1,34 @@
+package msgraph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_forEachConcurrently(t *testing.T) {
+	results := make([]int, 100)
+	forEachConcurrently(len(results), 7, func(i int) {
+		results[i] = i * i
+	})
+	for i, result := range results {
+		if result != i*i {
+			t.Fatalf("forEachConcurrently() results[%v] = %v, want %v", i, result, i*i)
+		}
+	}
+}
+
+func Test_contextMutex(t *testing.T) {
+	var m contextMutex
+	if err := m.LockContext(context.Background()); err != nil {
+		t.Fatalf("contextMutex.LockContext() of unlocked mutex error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := m.LockContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("contextMutex.LockContext() of locked mutex error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	m.Unlock()
+	m.Lock()
+	m.Unlock()
+}
@@ -13,6 +13,10 @@ import (
 // probably even back to time.UTC
 var FullDayEventTimeZone = time.Local
 
+// MinPasswordLength is the minimum number of characters of a password passed to GraphClient.ResetUserPassword,
+// shorter passwords are rejected before the API-call. The default matches the password policy of Azure AD.
+var MinPasswordLength = 8
+
 // LoginBaseURL represents the basic url used to acquire a token for the msgraph api
 const LoginBaseURL string = "https://login.microsoftonline.com"
 
@@ -22,7 +26,10 @@ const BaseURL string = "https://graph.microsoft.com"
 // APIVersion represents the APIVersion of msgraph used by this implementation
 const APIVersion string = "v1.0"
 
-// MaxPageSize is the maximum Page size for an API-call. This will be rewritten to use paging some day. Currently limits environments to 999 entries (e.g. Users, CalendarEvents etc.)
+// betaAPIVersion represents the version of the msgraph beta endpoint, which is used for functionality that is not available in APIVersion
+const betaAPIVersion string = "beta"
+
+// MaxPageSize is the maximum Page size for an API-call. Collections with more entries are loaded page by page.
 const MaxPageSize int = 999
 
 var (
@@ -32,6 +39,40 @@ var (
 	ErrFindGroup = errors.New("unable to find group")
 	// ErrFindCalendar is returned on any func that tries to find a calendar with the given parameters that cannot be found
 	ErrFindCalendar = errors.New("unable to find calendar")
+	// ErrFindServicePlan is returned on any func that tries to find a service plan with the given parameters that cannot be found
+	ErrFindServicePlan = errors.New("unable to find service plan")
 	// ErrNotGraphClientSourced is returned if e.g. a ListMembers() is called but the Group has not been created by a graphClient query
 	ErrNotGraphClientSourced = errors.New("instance is not created from a GraphClient API-Call, cannot directly get further information")
+	// ErrMissingRoles is returned by NewGraphClient if the token lacks a permission required by RequireRoles
+	ErrMissingRoles = errors.New("token is missing required roles")
+	// ErrClaimsChallenge is returned if msgraph still challenges the claims of the token after it has been refreshed with
+	// them, e.g. by Continuous Access Evaluation. The error includes the claims, which require an interactive sign-in
+	// of the user of a delegated token.
+	ErrClaimsChallenge = errors.New("token does not satisfy the claims challenge")
+	// ErrPrintNotLicensed is returned by the Universal Print funcs if the tenant or the user has no Universal Print license
+	ErrPrintNotLicensed = errors.New("universal print is not licensed")
+	// ErrPrintPermission is returned by the Universal Print funcs if the token lacks a Printer or PrintJob permission,
+	// most of them require a delegated token
+	ErrPrintPermission = errors.New("insufficient privileges for universal print")
+	// ErrMeWithApplicationToken is returned if "me" is passed as user identifier but the GraphClient holds an application
+	// token, which has no signed-in user. Use GraphClient.Me() with a delegated token instead.
+	ErrMeWithApplicationToken = errors.New(`"me" is not a valid user identifier for an application token, use GraphClient.Me() with a delegated token`)
+	// ErrNamedLocationInUse is returned if a named location cannot be deleted because it is still referenced by a conditional access policy
+	ErrNamedLocationInUse = errors.New("named location is still referenced by a conditional access policy")
+	// ErrCustomSecurityAttributesPermission is returned if custom security attributes cannot be accessed. This requires the
+	// CustomSecAttributeAssignment permissions AND the Attribute Assignment Reader/Administrator role for the application
+	ErrCustomSecurityAttributesPermission = errors.New("insufficient privileges for custom security attributes, the CustomSecAttributeAssignment permission and the Attribute Assignment role are required")
+	// ErrTokenExpired is returned by the API-calls of a GraphClient created by NewGraphClientWithToken after its token
+	// has expired, as it cannot be refreshed
+	ErrTokenExpired = errors.New("access token has expired and cannot be refreshed")
+	// ErrUnauthorized matches a GraphError with StatusCode 401 with errors.Is, e.g. the token is invalid
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden matches a GraphError with StatusCode 403 with errors.Is, e.g. the token lacks a permission
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound matches a GraphError with StatusCode 404 with errors.Is, e.g. GetUser of a user that does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict matches a GraphError with StatusCode 409 with errors.Is, e.g. the object has been changed concurrently
+	ErrConflict = errors.New("conflict")
+	// ErrThrottled matches a GraphError with StatusCode 429 with errors.Is, msgraph throttled the API-call even after retries
+	ErrThrottled = errors.New("throttled")
 )
@@ -1,3 +1,5 @@
 module github.com/AutomatedElectricalSolutions/go-msgraph
 
 go 1.16
+
+require gopkg.in/yaml.v3 v3.0.1
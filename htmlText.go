@@ -0,0 +1,125 @@
+package msgraph
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// htmlSkippedElements are elements whose content is not readable text, e.g. the style definitions Word puts into the head
+var htmlSkippedElements = map[string]bool{"head": true, "style": true, "script": true, "title": true}
+
+// htmlBlockElements are elements that start and end on their own line
+var htmlBlockElements = map[string]bool{
+	"p": true, "div": true, "table": true, "tr": true, "ul": true, "ol": true, "blockquote": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// htmlToText converts the HTML of an e-mail or event body to readable plain text. Line breaks and paragraphs
+// become newlines, list items become bullets, entities are decoded and the markup of Word (styles, comments,
+// office namespaced tags) is dropped. Consecutive empty lines are collapsed into one.
+func htmlToText(content string) string {
+	var out strings.Builder
+	last := '\n' // the last rune written to out, \n lets leading whitespace be dropped
+	write := func(s string) {
+		out.WriteString(s)
+		if r, _ := utf8.DecodeLastRuneInString(s); r != utf8.RuneError {
+			last = r
+		}
+	}
+	ensureNewline := func() {
+		if last != '\n' {
+			write("\n")
+		}
+	}
+	writeText := func(text string) {
+		for _, r := range html.UnescapeString(text) {
+			switch r {
+			case ' ', '\t', '\r', '\n', '\f': // whitespace of the source is not significant in HTML
+				if last != ' ' && last != '\n' {
+					write(" ")
+				}
+			default: // includes &nbsp; which is kept to preserve lines that only consist of it
+				write(string(r))
+			}
+		}
+	}
+
+	for len(content) > 0 {
+		start := strings.IndexByte(content, '<')
+		if start < 0 {
+			writeText(content)
+			break
+		}
+		writeText(content[:start])
+		content = content[start:]
+
+		if strings.HasPrefix(content, "<!--") {
+			end := strings.Index(content, "-->")
+			if end < 0 {
+				break
+			}
+			content = content[end+len("-->"):]
+			continue
+		}
+		end := strings.IndexByte(content, '>')
+		if end < 0 {
+			break
+		}
+		name, closing := htmlTagName(content[1:end])
+		content = content[end+1:]
+
+		switch {
+		case htmlSkippedElements[name] && !closing:
+			if end := strings.Index(strings.ToLower(content), "</"+name); end >= 0 {
+				content = content[end:]
+			} else {
+				content = ""
+			}
+		case name == "br":
+			write("\n")
+		case name == "li" && !closing:
+			ensureNewline()
+			write("• ")
+		case name == "li" || htmlBlockElements[name]:
+			ensureNewline()
+		case name == "td" || name == "th":
+			if closing && last != ' ' && last != '\n' {
+				write(" ")
+			}
+		}
+	}
+
+	// trim every line and collapse empty lines
+	var lines []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\u00a0", " "))
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// htmlTagName returns the lower case name of the tag with the given content (without < and >) and whether it is a closing tag
+func htmlTagName(tag string) (string, bool) {
+	closing := strings.HasPrefix(tag, "/")
+	tag = strings.TrimPrefix(tag, "/")
+	if end := strings.IndexAny(tag, " \t\r\n/"); end >= 0 {
+		tag = tag[:end]
+	}
+	return strings.ToLower(tag), closing
+}
+
+// truncateRunes returns s truncated to at most n runes, if s has been truncated its last rune is "…"
+func truncateRunes(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimRight(string(runes[:n-1]), " ") + "…"
+}
@@ -0,0 +1,130 @@
+package msgraph
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// outlookHTMLBody is a body as generated by Outlook (Word HTML) for an event invitation
+const outlookHTMLBody = `<html xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:w="urn:schemas-microsoft-com:office:word" xmlns:m="http://schemas.microsoft.com/office/2004/12/omml" xmlns="http://www.w3.org/TR/REC-html40">
+<head>
+<meta http-equiv="Content-Type" content="text/html; charset=utf-8">
+<meta name="Generator" content="Microsoft Word 15 (filtered medium)">
+<style><!--
+/* Font Definitions */
+@font-face
+	{font-family:"Cambria Math";
+	panose-1:2 4 5 3 5 4 6 3 2 4;}
+p.MsoNormal, li.MsoNormal, div.MsoNormal
+	{margin:0cm;
+	font-size:11.0pt;
+	font-family:"Calibri",sans-serif;}
+--></style><!--[if gte mso 9]><xml>
+<o:shapedefaults v:ext="edit" spidmax="1026" />
+</xml><![endif]-->
+</head>
+<body lang="DE-AT" link="#0563C1" vlink="#954F72" style="word-wrap:break-word">
+<div class="WordSection1">
+<p class="MsoNormal"><span lang="EN-US">Hi&nbsp;Bob,<o:p></o:p></span></p>
+<p class="MsoNormal"><span lang="EN-US"><o:p>&nbsp;</o:p></span></p>
+<p class="MsoNormal"><span lang="EN-US">please bring the following to the site visit &amp; sign the
+ protocol:<o:p></o:p></span></p>
+<ul style="margin-top:0cm" type="disc">
+<li class="MsoListParagraph" style="margin-left:0cm;mso-list:l0 level1 lfo1"><span lang="EN-US">Helmet<o:p></o:p></span></li>
+<li class="MsoListParagraph" style="margin-left:0cm;mso-list:l0 level1 lfo1"><span lang="EN-US">Multimeter &lt;Fluke&gt;<o:p></o:p></span></li>
+</ul>
+<p class="MsoNormal"><span lang="EN-US"><o:p>&nbsp;</o:p></span></p>
+<p class="MsoNormal"><span lang="EN-US">Thanks<br>
+Alice<o:p></o:p></span></p>
+</div>
+</body>
+</html>
+`
+
+func TestMsgBody_Text(t *testing.T) {
+	tests := []struct {
+		name string
+		body MsgBody
+		want string
+	}{
+		{
+			name: "outlook HTML",
+			body: MsgBody{ContentType: "html", Content: outlookHTMLBody},
+			want: "Hi Bob,\n\nplease bring the following to the site visit & sign the protocol:\n• Helmet\n• Multimeter <Fluke>\n\nThanks\nAlice",
+		}, {
+			name: "text is unchanged",
+			body: MsgBody{ContentType: "text", Content: "  Hi <Bob>,\n\n\n&nbsp;"},
+			want: "  Hi <Bob>,\n\n\n&nbsp;",
+		}, {
+			name: "table cells",
+			body: MsgBody{ContentType: "HTML", Content: "<table><tr><td>Room</td><td>A1</td></tr><tr><td>Time</td><td>10:00</td></tr></table>"},
+			want: "Room A1\nTime 10:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.body.Text(); got != tt.want {
+				t.Errorf("MsgBody.Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarEvent_BodyPreviewTruncated(t *testing.T) {
+	tests := []struct {
+		name  string
+		event CalendarEvent
+		n     int
+		want  string
+	}{
+		{
+			name:  "short preview",
+			event: CalendarEvent{BodyPreview: "Grüße"},
+			n:     5,
+			want:  "Grüße",
+		}, {
+			name:  "truncated on runes",
+			event: CalendarEvent{BodyPreview: "Grüße aus Wien"},
+			n:     4,
+			want:  "Grü…",
+		}, {
+			name:  "falls back to the body",
+			event: CalendarEvent{Body: MsgBody{ContentType: "html", Content: "<p>Hello</p><p>World</p>"}},
+			n:     8,
+			want:  "Hello\nW…",
+		}, {
+			name:  "unique body",
+			event: CalendarEvent{Body: MsgBody{ContentType: "text", Content: "full"}, UniqueBody: &MsgBody{ContentType: "text", Content: "unique"}},
+			n:     10,
+			want:  "full",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.BodyPreviewTruncated(tt.n); got != tt.want {
+				t.Errorf("CalendarEvent.BodyPreviewTruncated() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	event := CalendarEvent{Body: MsgBody{ContentType: "text", Content: "full"}, UniqueBody: &MsgBody{ContentType: "html", Content: "<b>unique</b>"}}
+	if got := event.UniqueBodyText(); got != "unique" {
+		t.Errorf("CalendarEvent.UniqueBodyText() = %q, want %q", got, "unique")
+	}
+}
+
+func Test_truncateRunes(t *testing.T) {
+	s := "Grüße aus Wien"
+	for n := 0; n <= utf8.RuneCountInString(s)+1; n++ {
+		got := truncateRunes(s, n)
+		if utf8.RuneCountInString(got) > n {
+			t.Errorf("truncateRunes(%q, %v) = %q, want at most %v runes", s, n, got, n)
+		}
+		if n >= utf8.RuneCountInString(s) && got != s {
+			t.Errorf("truncateRunes(%q, %v) = %q, want it unchanged", s, n, got)
+		}
+		if n > 0 && n < utf8.RuneCountInString(s) && !strings.HasSuffix(got, "…") {
+			t.Errorf("truncateRunes(%q, %v) = %q, want it to end with …", s, n, got)
+		}
+	}
+}
@@ -1,15 +1,46 @@
 package msgraph
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 )
 
 // globalSupportedTimeZones represents the instance that will be initialized once on runtime
-// and load all TimeZones form Microsoft, correlate them to IANA and set proper time.Location
+// and load all TimeZones form Microsoft, correlate them to IANA and set proper time.Location.
+// It is loaded by concurrent API-calls, use currentSupportedTimeZones and loadSupportedTimeZones.
 var globalSupportedTimeZones supportedTimeZones
 
+// globalSupportedTimeZonesLock guards globalSupportedTimeZones
+var globalSupportedTimeZonesLock sync.RWMutex
+
+// currentSupportedTimeZones returns the globalSupportedTimeZones, they are empty if they have not been loaded yet
+func currentSupportedTimeZones() supportedTimeZones {
+	globalSupportedTimeZonesLock.RLock()
+	defer globalSupportedTimeZonesLock.RUnlock()
+	return globalSupportedTimeZones
+}
+
+// loadSupportedTimeZones loads the globalSupportedTimeZones from the user resource, e.g. /users/{id} or /me, unless
+// they have been loaded already. Concurrent callers may load them twice, the first result is kept.
+func (g *GraphClient) loadSupportedTimeZones(ctx context.Context, userResource string) error {
+	if len(currentSupportedTimeZones().Value) > 0 {
+		return nil
+	}
+	timeZones, err := g.getTimeZoneChoices(ctx, userResource)
+	if err != nil {
+		return err
+	}
+	globalSupportedTimeZonesLock.Lock()
+	defer globalSupportedTimeZonesLock.Unlock()
+	if len(globalSupportedTimeZones.Value) == 0 {
+		globalSupportedTimeZones = timeZones
+	}
+	return nil
+}
+
 // supportedTimeZones represents multiple instances grabbed by https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/outlookuser_supportedtimezones
 type supportedTimeZones struct {
 	Value []supportedTimeZone
@@ -37,6 +68,17 @@ func (s supportedTimeZones) GetTimeZoneByDisplayName(displayName string) (*time.
 	return nil, fmt.Errorf("could not find given time.Location for DisplayName %v", displayName)
 }
 
+// GetAliasByTimeZone searches in the given set of supportedTimeZones for the alias of the given time.Location, i.e. it
+// is the inverse of GetTimeZoneByAlias. Returns an error if it cannot be found.
+func (s supportedTimeZones) GetAliasByTimeZone(loc *time.Location) (string, error) {
+	for _, searchItem := range s.Value {
+		if searchItem.TimeLoc != nil && loc != nil && searchItem.TimeLoc.String() == loc.String() {
+			return searchItem.Alias, nil
+		}
+	}
+	return "", fmt.Errorf("could not find given Alias for time.Location %v", loc)
+}
+
 // supportedTimeZone represents one instance grabbed by https://developer.microsoft.com/en-us/graph/docs/api-reference/v1.0/api/outlookuser_supportedtimezones
 type supportedTimeZone struct {
 	Alias       string
@@ -0,0 +1,50 @@
+package msgraph
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// uploadChunkSize is the size of the chunks uploadToSession PUTs, msgraph requires a multiple of 320 KiB
+var uploadChunkSize = 10 * 320 * 1024
+
+// uploadSession is the response of a createUploadSession, e.g. of a DriveItem or a PrintDocument
+//
+// See https://docs.microsoft.com/en-us/graph/api/resources/uploadsession
+type uploadSession struct {
+	UploadURL string `json:"uploadUrl"`
+}
+
+// uploadToSession uploads content to the uploadURL of an upload session in chunks of uploadChunkSize, each with
+// its Content-Range. The uploadURL is pre-authenticated, hence the chunks are sent without the token. The
+// response of the last chunk is unmarshalled into v.
+//
+// See https://docs.microsoft.com/en-us/graph/api/driveitem-createuploadsession#upload-bytes-to-the-upload-session
+func (g *GraphClient) uploadToSession(ctx context.Context, uploadURL string, content []byte, v interface{}) error {
+	for start := 0; start < len(content); start += uploadChunkSize {
+		end := start + uploadChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(content[start:end]))
+		if err != nil {
+			return fmt.Errorf("HTTP request error: %v", err)
+		}
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %v-%v/%v", start, end-1, len(content)))
+		var chunkResponse interface{}
+		if end == len(content) {
+			chunkResponse = v
+		}
+		if err := g.acquireRequest(ctx); err != nil {
+			return err
+		}
+		err = g.performRequest(req, chunkResponse, requestOptions{})
+		g.releaseRequest()
+		if err != nil {
+			return fmt.Errorf("cannot upload bytes %v-%v of %v: %w", start, end-1, len(content), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package msgraph
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+)
+
+// idempotencyKeyNamespace is the namespace of the UUIDs returned by NewIdempotencyKey, the URL namespace of RFC 4122
+var idempotencyKeyNamespace = [16]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+// NewIdempotencyKey returns a UUID version 5 derived from the given seed, e.g. the mail address of a user plus a
+// timestamp. The same seed always results in the same key, hence a retried operation can reuse its key. See IdempotencyKey.
+func NewIdempotencyKey(seed string) string {
+	return uuidV5(idempotencyKeyNamespace, seed)
+}
+
+// NewRequestID returns a random UUID version 4, as used for the client-request-id of every API-call that has no
+// IdempotencyKey. The same ID is sent with every retry of the API-call.
+func NewRequestID() string {
+	return uuidV4()
+}
+
+// uuidV4 returns a random UUID, see RFC 4122 section 4.4
+func uuidV4() string {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		panic(fmt.Sprintf("cannot read random bytes for a UUID: %v", err)) // crypto/rand does not fail on supported platforms
+	}
+	return formatUUID(uuid, 0x40) // version 4
+}
+
+// uuidV5 returns the name based (SHA-1) UUID of the given name within the given namespace, see RFC 4122 section 4.3
+func uuidV5(namespace [16]byte, name string) string {
+	hash := sha1.New()
+	hash.Write(namespace[:])
+	hash.Write([]byte(name))
+	sum := hash.Sum(nil)
+
+	var uuid [16]byte
+	copy(uuid[:], sum)
+	return formatUUID(uuid, 0x50) // version 5
+}
+
+// formatUUID sets the given version and the RFC 4122 variant in uuid and returns its string representation
+func formatUUID(uuid [16]byte, version byte) string {
+	uuid[6] = (uuid[6] & 0x0f) | version
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // variant RFC 4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
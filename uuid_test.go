@@ -0,0 +1,42 @@
+package msgraph
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	// expected values have been generated with python: uuid.uuid5(uuid.NAMESPACE_URL, seed)
+	tests := []struct {
+		seed string
+		want string
+	}{
+		{seed: "bob@contoso.com 2021-03-04T05:06:07Z", want: "3cf0406e-9b9f-5b6f-80f5-d21c7d74d7c4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.seed, func(t *testing.T) {
+			if got := NewIdempotencyKey(tt.seed); got != tt.want {
+				t.Errorf("NewIdempotencyKey() = %v, want %v", got, tt.want)
+			}
+			if got := NewIdempotencyKey(tt.seed + "x"); got == tt.want {
+				t.Errorf("NewIdempotencyKey() of a different seed = %v, want a different key", got)
+			}
+		})
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	forEachConcurrently(100, 10, func(i int) {
+		id := NewRequestID()
+		mu.Lock()
+		defer mu.Unlock()
+		if !pattern.MatchString(id) || seen[id] {
+			t.Errorf("NewRequestID() = %v, want a unique UUID version 4", id)
+		}
+		seen[id] = true
+	})
+}